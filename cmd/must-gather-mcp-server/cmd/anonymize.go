@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/must-gather-mcp-server/pkg/anonymize"
+)
+
+var (
+	anonymizeIn             string
+	anonymizeOut            string
+	anonymizeMapping        string
+	anonymizeNamespaces     []string
+	anonymizeUserNamespaces bool
+	anonymizePrivateIPs     bool
+	anonymizeRedact         bool
+)
+
+var anonymizeCmd = &cobra.Command{
+	Use:   "anonymize",
+	Short: "Write an anonymized copy of a must-gather for sharing",
+	Long: `Rewrite a must-gather replacing hostnames, IP addresses, the cluster domain,
+user names and, on request, namespaces with stable placeholders. The copy keeps
+the must-gather layout so it can be analyzed by this server. Replacements are
+recorded in a mapping file so findings can be mapped back; keep that file private.
+Reusing the mapping file on later runs keeps replacements stable across gathers.`,
+	RunE: runAnonymize,
+}
+
+func init() {
+	anonymizeCmd.Flags().StringVar(&anonymizeIn, "in", "", "Path to the must-gather to anonymize (required)")
+	anonymizeCmd.Flags().StringVar(&anonymizeOut, "out", "", "Path for the anonymized copy, must not exist (required)")
+	anonymizeCmd.Flags().StringVar(&anonymizeMapping, "mapping", "", "Mapping file to create or reuse (default \"<out>.mapping.json\")")
+	anonymizeCmd.Flags().StringSliceVar(&anonymizeNamespaces, "namespaces", nil, "Comma-separated namespaces whose names should be replaced")
+	anonymizeCmd.Flags().BoolVar(&anonymizeUserNamespaces, "user-namespaces", false, "Replace the names of all non-platform namespaces")
+	anonymizeCmd.Flags().BoolVar(&anonymizePrivateIPs, "private-ips", false, "Also replace private (RFC 1918) addresses")
+	anonymizeCmd.Flags().BoolVar(&anonymizeRedact, "redact", true, "Mask secrets, tokens and private keys in the copy")
+	anonymizeCmd.MarkFlagRequired("in")
+	anonymizeCmd.MarkFlagRequired("out")
+
+	rootCmd.AddCommand(anonymizeCmd)
+}
+
+func runAnonymize(cmd *cobra.Command, args []string) error {
+	mappingPath := anonymizeMapping
+	if mappingPath == "" {
+		mappingPath = filepath.Clean(anonymizeOut) + ".mapping.json"
+	}
+
+	fmt.Printf("Anonymizing must-gather: %s -> %s\n", anonymizeIn, anonymizeOut)
+
+	summary, err := anonymize.Run(anonymize.Options{
+		InPath:         anonymizeIn,
+		OutPath:        anonymizeOut,
+		MappingPath:    mappingPath,
+		Namespaces:     anonymizeNamespaces,
+		UserNamespaces: anonymizeUserNamespaces,
+		PrivateIPs:     anonymizePrivateIPs,
+		Redact:         anonymizeRedact,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to anonymize must-gather: %w", err)
+	}
+
+	fmt.Printf("Processed %d files (%d binary, copied unchanged)\n", summary.Files, summary.BinaryFiles)
+
+	categories := make([]string, 0, len(summary.Mapping.Mappings))
+	for category := range summary.Mapping.Mappings {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	for _, category := range categories {
+		fmt.Printf("  %-12s %4d mapped, %6d occurrences replaced\n",
+			category+":", summary.Mapping.Count(category), summary.Replacements[category])
+	}
+
+	fmt.Printf("Mapping written to %s (contains original values, do not share)\n", mappingPath)
+	if len(anonymizeNamespaces) == 0 && !anonymizeUserNamespaces {
+		fmt.Printf("Namespaces were kept; use --namespaces or --user-namespaces to replace them\n")
+	}
+	if !anonymizePrivateIPs {
+		fmt.Printf("Private IP addresses were kept; use --private-ips to replace them\n")
+	}
+
+	return nil
+}
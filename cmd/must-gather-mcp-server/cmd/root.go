@@ -2,16 +2,22 @@ package cmd
 
 import (
 	"fmt"
+	"log/slog"
 	"os"
 
 	"github.com/spf13/cobra"
 
 	// Import toolsets to register them
+	_ "github.com/openshift/must-gather-mcp-server/pkg/toolsets/audit"
 	_ "github.com/openshift/must-gather-mcp-server/pkg/toolsets/cluster"
+	_ "github.com/openshift/must-gather-mcp-server/pkg/toolsets/cnv"
 	_ "github.com/openshift/must-gather-mcp-server/pkg/toolsets/core"
 	_ "github.com/openshift/must-gather-mcp-server/pkg/toolsets/diagnostics"
+	_ "github.com/openshift/must-gather-mcp-server/pkg/toolsets/hostservices"
+	_ "github.com/openshift/must-gather-mcp-server/pkg/toolsets/logging"
 	_ "github.com/openshift/must-gather-mcp-server/pkg/toolsets/monitoring"
 	_ "github.com/openshift/must-gather-mcp-server/pkg/toolsets/network"
+	_ "github.com/openshift/must-gather-mcp-server/pkg/toolsets/odf"
 
 	"github.com/openshift/must-gather-mcp-server/pkg/mcp"
 	"github.com/openshift/must-gather-mcp-server/pkg/mustgather"
@@ -24,6 +30,9 @@ var (
 	showVersion    bool
 	httpMode       bool
 	httpAddr       string
+	redact         bool
+	redactionRules string
+	logLevel       string
 )
 
 var rootCmd = &cobra.Command{
@@ -40,6 +49,9 @@ func init() {
 	rootCmd.Flags().BoolVar(&showVersion, "version", false, "Show version information")
 	rootCmd.Flags().BoolVar(&httpMode, "http", false, "Run in HTTP/SSE mode instead of STDIO")
 	rootCmd.Flags().StringVar(&httpAddr, "http-addr", "localhost:8080", "HTTP server address (only used with --http)")
+	rootCmd.Flags().BoolVar(&redact, "redact", true, "Mask secrets, tokens, passwords and private keys in tool output")
+	rootCmd.Flags().StringVar(&redactionRules, "redaction-rules", "", "Path to a YAML file with additional redaction rules")
+	rootCmd.Flags().StringVar(&logLevel, "log-level", "info", "Log level for server logs written to stderr (debug, info, warn, error)")
 	rootCmd.MarkFlagRequired("must-gather-path")
 }
 
@@ -54,6 +66,11 @@ func run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Server logs go to stderr so they never mix with the STDIO transport
+	if err := setupLogging(logLevel); err != nil {
+		return err
+	}
+
 	// Verify must-gather path
 	if mustGatherPath == "" {
 		return fmt.Errorf("must-gather-path is required")
@@ -63,22 +80,35 @@ func run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("must-gather path does not exist: %s", mustGatherPath)
 	}
 
+	// Configure redaction
+	redaction := mustgather.DefaultRedactionConfig()
+	if redactionRules != "" {
+		rules, err := mustgather.LoadRedactionConfig(redactionRules)
+		if err != nil {
+			return err
+		}
+		redaction = rules
+	}
+	if !redact {
+		redaction.Enabled = false
+	}
+
 	// Create must-gather provider
-	provider, err := mustgather.NewProvider(mustGatherPath)
+	provider, err := mustgather.NewProviderWithOptions(mustGatherPath, mustgather.ProviderOptions{Redaction: redaction})
 	if err != nil {
 		return fmt.Errorf("failed to create must-gather provider: %w", err)
 	}
 
-	// Get all registered toolsets
-	allToolsets := toolsets.All()
-	if len(allToolsets) == 0 {
+	// Get the registered toolsets that apply to this must-gather
+	enabledToolsets := toolsets.Enabled(provider)
+	if len(enabledToolsets) == 0 {
 		return fmt.Errorf("no toolsets registered")
 	}
 
-	fmt.Printf("Registered %d toolsets\n", len(allToolsets))
+	slog.Info("registered toolsets", "count", len(enabledToolsets), "available", len(toolsets.All()))
 
 	// Create MCP server
-	server, err := mcp.NewServer(provider, allToolsets)
+	server, err := mcp.NewServer(provider, enabledToolsets)
 	if err != nil {
 		return fmt.Errorf("failed to create MCP server: %w", err)
 	}
@@ -87,12 +117,12 @@ func run(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
 	if httpMode {
-		fmt.Printf("Starting must-gather MCP server in HTTP/SSE mode...\n")
+		slog.Info("starting must-gather MCP server", "transport", "http")
 		if err := server.ServeHTTP(ctx, httpAddr); err != nil {
 			return fmt.Errorf("failed to start MCP server: %w", err)
 		}
 	} else {
-		fmt.Printf("Starting must-gather MCP server in STDIO mode...\n")
+		slog.Info("starting must-gather MCP server", "transport", "stdio")
 		if err := server.ServeStdio(ctx); err != nil {
 			return fmt.Errorf("failed to start MCP server: %w", err)
 		}
@@ -100,3 +130,14 @@ func run(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// setupLogging routes all server logging through a structured logger on stderr
+func setupLogging(level string) error {
+	var slogLevel slog.Level
+	if err := slogLevel.UnmarshalText([]byte(level)); err != nil {
+		return fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slogLevel})))
+	return nil
+}
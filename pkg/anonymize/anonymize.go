@@ -0,0 +1,234 @@
+// Package anonymize rewrites a must-gather so it can be shared outside the
+// organization, replacing cluster identifiers consistently and recording the
+// replacements in a mapping file.
+package anonymize
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/openshift/must-gather-mcp-server/pkg/mustgather"
+)
+
+// Options configures an anonymization run
+type Options struct {
+	InPath      string
+	OutPath     string
+	MappingPath string
+
+	// Namespaces lists namespaces whose names must be replaced
+	Namespaces []string
+	// UserNamespaces replaces the names of all non-platform namespaces
+	UserNamespaces bool
+	// PrivateIPs also replaces RFC 1918 and CGNAT addresses. They are kept by
+	// default so pod, service and machine networks stay consistent.
+	PrivateIPs bool
+	// Redact masks secrets, tokens and private keys in the copy
+	Redact bool
+}
+
+// Summary reports what an anonymization run changed
+type Summary struct {
+	Files        int
+	BinaryFiles  int
+	Replacements map[string]int // category -> occurrences replaced
+	Mapping      *Mapping
+}
+
+// Run anonymizes the must-gather at opts.InPath into opts.OutPath
+func Run(opts Options) (*Summary, error) {
+	if _, err := os.Stat(opts.InPath); err != nil {
+		return nil, fmt.Errorf("must-gather path does not exist: %s", opts.InPath)
+	}
+	if _, err := os.Stat(opts.OutPath); err == nil {
+		return nil, fmt.Errorf("output path already exists: %s", opts.OutPath)
+	}
+
+	inAbs, err := filepath.Abs(opts.InPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve must-gather path: %w", err)
+	}
+	outAbs, err := filepath.Abs(opts.OutPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve output path: %w", err)
+	}
+	mappingAbs, err := filepath.Abs(opts.MappingPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve mapping path: %w", err)
+	}
+	// The walk would copy the output into itself
+	if outAbs == inAbs || strings.HasPrefix(outAbs, inAbs+string(filepath.Separator)) {
+		return nil, fmt.Errorf("output path must not be inside the must-gather: %s", opts.OutPath)
+	}
+	if strings.HasPrefix(mappingAbs, inAbs+string(filepath.Separator)) {
+		return nil, fmt.Errorf("mapping file must not be inside the must-gather: %s", opts.MappingPath)
+	}
+
+	mapping, err := LoadMapping(opts.MappingPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := discover(opts, mapping); err != nil {
+		return nil, err
+	}
+
+	var redactor *mustgather.Redactor
+	if opts.Redact {
+		redactor, err = mustgather.NewRedactor(mustgather.DefaultRedactionConfig())
+		if err != nil {
+			return nil, err
+		}
+	}
+	r := newRewriter(mapping, opts.PrivateIPs, redactor)
+
+	summary := &Summary{Mapping: mapping}
+
+	err = filepath.WalkDir(opts.InPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(opts.InPath, path)
+		if err != nil {
+			return err
+		}
+		outPath := filepath.Join(opts.OutPath, rewritePath(r, rel))
+
+		if d.IsDir() {
+			return os.MkdirAll(outPath, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		binary, err := rewriteFile(r, path, outPath)
+		if err != nil {
+			return fmt.Errorf("failed to anonymize %s: %w", rel, err)
+		}
+		summary.Files++
+		if binary {
+			summary.BinaryFiles++
+		}
+		return nil
+	})
+	if err != nil {
+		// Don't leave a partially anonymized copy behind
+		os.RemoveAll(opts.OutPath)
+		return nil, err
+	}
+
+	if err := mapping.Save(opts.MappingPath); err != nil {
+		os.RemoveAll(opts.OutPath)
+		return nil, err
+	}
+
+	summary.Replacements = r.changes
+	return summary, nil
+}
+
+// rewritePath anonymizes each component of a relative path, e.g. node and namespace directories
+func rewritePath(r *rewriter, rel string) string {
+	if rel == "." {
+		return rel
+	}
+
+	parts := strings.Split(rel, string(filepath.Separator))
+	for i, part := range parts {
+		parts[i] = r.rewriteNames(part)
+	}
+	return filepath.Join(parts...)
+}
+
+// rewriteFile anonymizes a single file line by line, handling gzip-compressed
+// files transparently. Binary files are copied unchanged.
+func rewriteFile(r *rewriter, inPath, outPath string) (bool, error) {
+	in, err := os.Open(inPath)
+	if err != nil {
+		return false, err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return false, err
+	}
+
+	out, err := os.OpenFile(outPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return false, err
+	}
+
+	binary, err := rewriteContent(r, in, out, strings.HasSuffix(inPath, ".gz"))
+	// Close errors matter here, a failed final flush leaves a truncated file
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return binary, err
+}
+
+// rewriteContent copies in to out, anonymizing text and recompressing gzip content
+func rewriteContent(r *rewriter, in *os.File, out io.Writer, compressed bool) (bool, error) {
+	reader := bufio.NewReader(in)
+
+	if compressed {
+		gzReader, err := gzip.NewReader(reader)
+		if err != nil {
+			// Not actually gzip, copy as is
+			if _, err := in.Seek(0, io.SeekStart); err != nil {
+				return false, err
+			}
+			_, err = io.Copy(out, in)
+			return true, err
+		}
+		defer gzReader.Close()
+
+		gzWriter := gzip.NewWriter(out)
+		binary, err := rewriteStream(r, bufio.NewReader(gzReader), gzWriter)
+		if closeErr := gzWriter.Close(); err == nil {
+			err = closeErr
+		}
+		return binary, err
+	}
+
+	return rewriteStream(r, reader, out)
+}
+
+// rewriteStream anonymizes text line by line, or copies binary content unchanged
+func rewriteStream(r *rewriter, reader *bufio.Reader, writer io.Writer) (bool, error) {
+	if isBinary(reader) {
+		_, err := io.Copy(writer, reader)
+		return true, err
+	}
+
+	bufWriter := bufio.NewWriter(writer)
+	for {
+		line, readErr := reader.ReadString('\n')
+		if len(line) > 0 {
+			if _, err := bufWriter.WriteString(r.rewrite(line)); err != nil {
+				return false, err
+			}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return false, readErr
+		}
+	}
+
+	return false, bufWriter.Flush()
+}
+
+// isBinary peeks at the start of the stream for NUL bytes
+func isBinary(reader *bufio.Reader) bool {
+	head, _ := reader.Peek(8000)
+	return bytes.IndexByte(head, 0) >= 0
+}
@@ -0,0 +1,220 @@
+package anonymize
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/openshift/must-gather-mcp-server/pkg/mustgather"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// discover loads the must-gather and assigns replacements for the cluster
+// identifiers found in its resources
+func discover(opts Options, mapping *Mapping) error {
+	result, err := mustgather.Load(os.DirFS(opts.InPath), opts.InPath)
+	if err != nil {
+		return fmt.Errorf("failed to load must-gather: %w", err)
+	}
+
+	hostnames := make(map[string]string) // hostname -> replacement prefix
+	clusterDomains := make(map[string]bool)
+	ingressDomains := make(map[string]bool)
+	clusters := make(map[string]bool)
+	users := make(map[string]bool)
+	namespaces := make(map[string]bool)
+
+	for _, ns := range opts.Namespaces {
+		namespaces[ns] = true
+	}
+	if opts.UserNamespaces {
+		for _, ns := range result.Namespaces {
+			if !isPlatformNamespace(ns) {
+				namespaces[ns] = true
+			}
+		}
+	}
+
+	for _, obj := range result.Resources {
+		switch obj.GetKind() {
+		case "Node":
+			hostnames[obj.GetName()] = nodeRole(obj) + "-node"
+			addresses, _, _ := unstructured.NestedSlice(obj.Object, "status", "addresses")
+			for _, a := range addresses {
+				addr, ok := a.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				addrType, _ := addr["type"].(string)
+				value, _ := addr["address"].(string)
+				if (addrType == "Hostname" || addrType == "InternalDNS" || addrType == "ExternalDNS") && net.ParseIP(value) == nil {
+					if _, known := hostnames[value]; !known {
+						hostnames[value] = "host"
+					}
+				}
+			}
+
+		case "Infrastructure":
+			if name, _, _ := unstructured.NestedString(obj.Object, "status", "infrastructureName"); name != "" {
+				clusters[name] = true
+			}
+			for _, field := range []string{"apiServerURL", "apiServerInternalURI"} {
+				if apiURL, _, _ := unstructured.NestedString(obj.Object, "status", field); apiURL != "" {
+					if u, err := url.Parse(apiURL); err == nil {
+						host := u.Hostname()
+						host = strings.TrimPrefix(host, "api-int.")
+						host = strings.TrimPrefix(host, "api.")
+						if host != "" && net.ParseIP(host) == nil {
+							clusterDomains[host] = true
+						}
+					}
+				}
+			}
+
+		case "DNS":
+			if baseDomain, _, _ := unstructured.NestedString(obj.Object, "spec", "baseDomain"); baseDomain != "" {
+				clusterDomains[baseDomain] = true
+			}
+
+		case "Ingress":
+			if obj.GetAPIVersion() == "config.openshift.io/v1" {
+				if domain, _, _ := unstructured.NestedString(obj.Object, "spec", "domain"); domain != "" {
+					ingressDomains[domain] = true
+				}
+			}
+
+		case "User":
+			if obj.GetAPIVersion() == "user.openshift.io/v1" && !isSystemUser(obj.GetName()) {
+				users[obj.GetName()] = true
+			}
+
+		case "Group":
+			groupUsers, _, _ := unstructured.NestedStringSlice(obj.Object, "users")
+			for _, user := range groupUsers {
+				if !isSystemUser(user) {
+					users[user] = true
+				}
+			}
+
+		case "RoleBinding", "ClusterRoleBinding":
+			subjects, _, _ := unstructured.NestedSlice(obj.Object, "subjects")
+			for _, s := range subjects {
+				subject, ok := s.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				kind, _ := subject["kind"].(string)
+				name, _ := subject["name"].(string)
+				if kind == "User" && !isSystemUser(name) {
+					users[name] = true
+				}
+			}
+		}
+
+		if requester := obj.GetAnnotations()["openshift.io/requester"]; requester != "" && !isSystemUser(requester) {
+			users[requester] = true
+		}
+	}
+
+	// Domains below a cluster domain are rewritten along with it, so only the
+	// cluster domains and their base domains need their own mapping
+	baseDomains := make(map[string]bool)
+	for domain := range clusterDomains {
+		if i := strings.Index(domain, "."); i > 0 && strings.Contains(domain[i+1:], ".") {
+			baseDomains[domain[i+1:]] = true
+		}
+	}
+	for domain := range ingressDomains {
+		if !hasDomainSuffix(domain, clusterDomains) {
+			clusterDomains[domain] = true
+		}
+	}
+
+	for _, domain := range sortedKeys(clusterDomains) {
+		mapping.Assign(CategoryDomain, domain, func(n int) string {
+			return fmt.Sprintf("cluster-%d.example.com", n)
+		})
+	}
+	for _, domain := range sortedKeys(baseDomains) {
+		mapping.Assign(CategoryBaseDomain, domain, func(n int) string {
+			if n == 1 {
+				return "example.com"
+			}
+			return fmt.Sprintf("example-%d.com", n)
+		})
+	}
+	// Hosts are numbered per role, e.g. control-plane-node-1..3
+	roleSequence := make(map[string]int)
+	for _, host := range sortedKeys(hostnames) {
+		role := hostnames[host]
+		mapping.Assign(CategoryHostname, host, func(int) string {
+			roleSequence[role]++
+			return fmt.Sprintf("%s-%d", role, roleSequence[role])
+		})
+	}
+	for _, name := range sortedKeys(clusters) {
+		mapping.Assign(CategoryCluster, name, func(n int) string {
+			return fmt.Sprintf("cluster-%d", n)
+		})
+	}
+	for _, ns := range sortedKeys(namespaces) {
+		mapping.Assign(CategoryNamespace, ns, func(n int) string {
+			return fmt.Sprintf("namespace-%d", n)
+		})
+	}
+	for _, user := range sortedKeys(users) {
+		mapping.Assign(CategoryUser, user, func(n int) string {
+			return fmt.Sprintf("user-%d", n)
+		})
+	}
+
+	return nil
+}
+
+// hasDomainSuffix reports whether a domain is one of, or below one of, the given domains
+func hasDomainSuffix(domain string, domains map[string]bool) bool {
+	for parent := range domains {
+		if domain == parent || strings.HasSuffix(domain, "."+parent) {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeRole returns a replacement prefix that keeps the node's role visible
+func nodeRole(node *unstructured.Unstructured) string {
+	labels := node.GetLabels()
+	if _, ok := labels["node-role.kubernetes.io/master"]; ok {
+		return "control-plane"
+	}
+	if _, ok := labels["node-role.kubernetes.io/control-plane"]; ok {
+		return "control-plane"
+	}
+	if _, ok := labels["node-role.kubernetes.io/infra"]; ok {
+		return "infra"
+	}
+	return "worker"
+}
+
+// isPlatformNamespace reports whether a namespace belongs to the platform rather than users
+func isPlatformNamespace(ns string) bool {
+	return ns == "default" || ns == "openshift" ||
+		strings.HasPrefix(ns, "openshift-") || strings.HasPrefix(ns, "kube-")
+}
+
+// isSystemUser reports whether a user name is a built-in identity that carries no personal data
+func isSystemUser(name string) bool {
+	return name == "" || strings.HasPrefix(name, "system:") || name == "kube:admin"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,146 @@
+package anonymize
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+)
+
+// Mapping categories
+const (
+	CategoryHostname   = "hostname"
+	CategoryDomain     = "domain"
+	CategoryBaseDomain = "base-domain"
+	CategoryCluster    = "cluster"
+	CategoryIP         = "ip"
+	CategoryNamespace  = "namespace"
+	CategoryUser       = "user"
+)
+
+// Mapping records every original value and its replacement, per category.
+// It is saved next to the anonymized copy so findings can be mapped back,
+// and reloaded on later runs so the same input always gets the same output.
+type Mapping struct {
+	Version  int                          `json:"version"`
+	Mappings map[string]map[string]string `json:"mappings"`
+
+	// used tracks replacements already handed out, per category
+	used map[string]map[string]bool
+}
+
+// NewMapping creates an empty mapping
+func NewMapping() *Mapping {
+	return &Mapping{
+		Version:  1,
+		Mappings: make(map[string]map[string]string),
+		used:     make(map[string]map[string]bool),
+	}
+}
+
+// LoadMapping reads a mapping file, returning an empty mapping if it does not exist
+func LoadMapping(path string) (*Mapping, error) {
+	m := NewMapping()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("failed to parse mapping file: %w", err)
+	}
+
+	if m.Mappings == nil {
+		m.Mappings = make(map[string]map[string]string)
+	}
+	for category, values := range m.Mappings {
+		for _, replacement := range values {
+			m.markUsed(category, replacement)
+		}
+	}
+
+	return m, nil
+}
+
+// Save writes the mapping file. It holds the original identifiers, so it is
+// only readable by the owner and must not be shared with the anonymized copy.
+func (m *Mapping) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode mapping: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write mapping file: %w", err)
+	}
+	return nil
+}
+
+// Get returns the replacement for a value, if one was assigned
+func (m *Mapping) Get(category, original string) (string, bool) {
+	replacement, ok := m.Mappings[category][original]
+	return replacement, ok
+}
+
+// Assign returns the existing replacement for a value or allocates a new one
+// using the generator, which receives a 1-based sequence number
+func (m *Mapping) Assign(category, original string, generate func(n int) string) string {
+	if replacement, ok := m.Get(category, original); ok {
+		return replacement
+	}
+
+	if m.Mappings[category] == nil {
+		m.Mappings[category] = make(map[string]string)
+	}
+
+	// Skip candidates already used, e.g. by a mapping loaded from a previous run
+	n := len(m.Mappings[category]) + 1
+	replacement := generate(n)
+	for m.used[category][replacement] {
+		n++
+		replacement = generate(n)
+	}
+
+	m.Mappings[category][original] = replacement
+	m.markUsed(category, replacement)
+	return replacement
+}
+
+// Values returns the original values of a category, sorted
+func (m *Mapping) Values(category string) []string {
+	values := make([]string, 0, len(m.Mappings[category]))
+	for original := range m.Mappings[category] {
+		values = append(values, original)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// Count returns the number of mapped values in a category
+func (m *Mapping) Count(category string) int {
+	return len(m.Mappings[category])
+}
+
+func (m *Mapping) markUsed(category, replacement string) {
+	if m.used == nil {
+		m.used = make(map[string]map[string]bool)
+	}
+	if m.used[category] == nil {
+		m.used[category] = make(map[string]bool)
+	}
+	m.used[category][replacement] = true
+}
+
+// ipFromSequence returns the n-th address of a base network
+func ipFromSequence(base net.IP, n int) string {
+	ip := make(net.IP, 4)
+	copy(ip, base.To4())
+
+	value := uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])
+	value += uint32(n)
+
+	return net.IPv4(byte(value>>24), byte(value>>16), byte(value>>8), byte(value)).String()
+}
@@ -0,0 +1,215 @@
+package anonymize
+
+import (
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openshift/must-gather-mcp-server/pkg/mustgather"
+)
+
+var ipv4Pattern = regexp.MustCompile(`\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}`)
+
+var (
+	// Public addresses are replaced from the benchmarking range (RFC 2544)
+	publicReplacementBase = net.ParseIP("198.18.0.0")
+	// Private addresses, when requested, are replaced from 10.0.0.0/8
+	privateReplacementBase = net.ParseIP("10.0.0.0")
+
+	keptNetworks = mustParseCIDRs("0.0.0.0/8", "127.0.0.0/8", "169.254.0.0/16", "224.0.0.0/3")
+	privateNets  = mustParseCIDRs("10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10")
+)
+
+// rewriter applies a mapping to text
+type rewriter struct {
+	mapping    *Mapping
+	privateIPs bool
+	redactor   *mustgather.Redactor
+
+	names   *regexp.Regexp
+	lookup  map[string]string
+	changes map[string]int // category -> replacements made
+	origin  map[string]string
+}
+
+func newRewriter(mapping *Mapping, privateIPs bool, redactor *mustgather.Redactor) *rewriter {
+	r := &rewriter{
+		mapping:    mapping,
+		privateIPs: privateIPs,
+		redactor:   redactor,
+		lookup:     make(map[string]string),
+		origin:     make(map[string]string),
+		changes:    make(map[string]int),
+	}
+
+	for _, category := range []string{CategoryHostname, CategoryDomain, CategoryBaseDomain, CategoryCluster, CategoryNamespace, CategoryUser} {
+		for original, replacement := range mapping.Mappings[category] {
+			r.lookup[original] = replacement
+			r.origin[original] = category
+		}
+	}
+
+	if len(r.lookup) > 0 {
+		// Longest first so cluster domains win over their base domains
+		originals := make([]string, 0, len(r.lookup))
+		for original := range r.lookup {
+			originals = append(originals, original)
+		}
+		sort.Slice(originals, func(i, j int) bool {
+			if len(originals[i]) != len(originals[j]) {
+				return len(originals[i]) > len(originals[j])
+			}
+			return originals[i] < originals[j]
+		})
+
+		quoted := make([]string, len(originals))
+		for i, original := range originals {
+			quoted[i] = regexp.QuoteMeta(original)
+		}
+		r.names = regexp.MustCompile(strings.Join(quoted, "|"))
+	}
+
+	return r
+}
+
+// rewrite anonymizes one line or path component
+func (r *rewriter) rewrite(s string) string {
+	if r.redactor != nil {
+		s = r.redactor.RedactText(s)
+	}
+	s = r.rewriteNames(s)
+	s = r.rewriteIPs(s)
+	return s
+}
+
+// rewriteNames replaces mapped identifiers that stand as whole names, so the
+// namespace "web" does not clobber "webhook" or "my-web"
+func (r *rewriter) rewriteNames(s string) string {
+	if r.names == nil {
+		return s
+	}
+
+	matches := r.names.FindAllStringIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if (start > 0 && isNameChar(s[start-1])) || (end < len(s) && isNameChar(s[end])) {
+			continue
+		}
+		original := s[start:end]
+		category := r.origin[original]
+		if (category == CategoryNamespace || category == CategoryUser) && isKeyPosition(s[end:]) {
+			continue
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(r.lookup[original])
+		r.changes[category]++
+		last = end
+	}
+	b.WriteString(s[last:])
+
+	return b.String()
+}
+
+// rewriteIPs replaces IPv4 addresses with stable substitutes
+func (r *rewriter) rewriteIPs(s string) string {
+	matches := ipv4Pattern.FindAllStringIndex(s, -1)
+	if len(matches) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		// Skip parts of longer dotted numbers such as versions
+		if (start > 0 && (isDigit(s[start-1]) || s[start-1] == '.')) ||
+			(end < len(s) && (isDigit(s[end]) || (s[end] == '.' && end+1 < len(s) && isDigit(s[end+1])))) {
+			continue
+		}
+
+		original := s[start:end]
+		replacement, ok := r.replaceIP(original)
+		if !ok {
+			continue
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(replacement)
+		r.changes[CategoryIP]++
+		last = end
+	}
+	b.WriteString(s[last:])
+
+	return b.String()
+}
+
+func (r *rewriter) replaceIP(original string) (string, bool) {
+	// Addresses mapped on a previous run stay mapped regardless of flags
+	if replacement, ok := r.mapping.Get(CategoryIP, original); ok {
+		return replacement, true
+	}
+
+	ip := net.ParseIP(original).To4()
+	if ip == nil || ip[0] == 255 || containsIP(keptNetworks, ip) {
+		return "", false
+	}
+
+	base := publicReplacementBase
+	if containsIP(privateNets, ip) {
+		if !r.privateIPs {
+			return "", false
+		}
+		base = privateReplacementBase
+	}
+
+	return r.mapping.Assign(CategoryIP, original, func(n int) string {
+		return ipFromSequence(base, n)
+	}), true
+}
+
+// isKeyPosition reports whether a match is used as a map key or label prefix
+// rather than a value, e.g. "app: web" or "app.kubernetes.io/name". Namespaces
+// and user names are often common words, so these are left alone. Service
+// DNS names ("web.app.svc") still refer to the namespace.
+func isKeyPosition(rest string) bool {
+	rest = strings.TrimPrefix(rest, `"`)
+	if strings.HasPrefix(rest, ":") && !strings.HasPrefix(rest, "://") {
+		return true
+	}
+	return strings.HasPrefix(rest, ".") && !strings.HasPrefix(rest, ".svc")
+}
+
+func isNameChar(c byte) bool {
+	return c == '-' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func containsIP(networks []*net.IPNet, ip net.IP) bool {
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
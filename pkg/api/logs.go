@@ -33,3 +33,13 @@ type NodeDiagnostics struct {
 	Dmesg         string
 	ProcCmdline   string
 }
+
+// HostServiceLog describes a host service journal collected for a node role
+// (host_service_logs/<role>/<service>_service.log)
+type HostServiceLog struct {
+	Role       string // e.g. "masters", "workers"
+	Service    string // e.g. "crio", "kubelet", "NetworkManager"
+	Path       string // relative to the primary gather directory
+	Size       int64
+	Compressed bool
+}
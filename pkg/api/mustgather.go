@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"io/fs"
 	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -17,6 +18,18 @@ type MustGatherProvider interface {
 	GetResource(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error)
 	ListResources(ctx context.Context, gvk schema.GroupVersionKind, namespace string, opts ListOptions) (*unstructured.UnstructuredList, error)
 
+	// GetResourceSources returns the gather directories an object was found in
+	GetResourceSources(gvk schema.GroupVersionKind, namespace, name string) []string
+
+	// ListGVKs returns all GroupVersionKinds present in the must-gather
+	ListGVKs() []schema.GroupVersionKind
+
+	// HasGroupKind reports whether resources of a group and kind were gathered, in any version
+	HasGroupKind(group, kind string) bool
+	// ListResourcesByGroupKind lists resources of a group and kind across all gathered
+	// versions, sorted by namespace and name
+	ListResourcesByGroupKind(ctx context.Context, group, kind, namespace string, opts ListOptions) (*unstructured.UnstructuredList, error)
+
 	// Namespace operations
 	ListNamespaces(ctx context.Context) ([]string, error)
 
@@ -28,9 +41,31 @@ type MustGatherProvider interface {
 	GetPodLog(opts PodLogOptions) (string, error)
 	ListPodContainers(namespace, pod string) ([]string, error)
 
+	// Host service logs
+	ListHostServiceLogs() ([]HostServiceLog, error)
+	GetHostServiceLog(role, service string) (string, error)
+
 	// Node diagnostics
 	GetNodeDiagnostics(nodeName string) (*NodeDiagnostics, error)
 	ListNodes() ([]string, error)
+
+	// File access: Files is rooted at the primary gather directory, RootFiles
+	// at the must-gather root (collection logs, other gather directories by
+	// their GatherDir.Name)
+	Files() GatherFS
+	RootFiles() GatherFS
+
+	// RedactOutput masks sensitive values in tool output and notes what was redacted
+	RedactOutput(content string) string
+}
+
+// GatherFS reads gathered files using slash-separated paths relative to its
+// root. Files ending in .gz are decompressed when opened or read; Stat and
+// ReadDir report them as stored.
+type GatherFS interface {
+	fs.ReadDirFS
+	fs.ReadFileFS
+	fs.StatFS
 }
 
 // MustGatherMetadata contains metadata about the must-gather
@@ -41,6 +76,23 @@ type MustGatherMetadata struct {
 	EndTime        time.Time
 	ResourceCount  int
 	NamespaceCount int
+	LoadErrors     []LoadError
+	GatherDirs     []GatherDir
+}
+
+// GatherDir is a directory with gathered content, typically one per image
+type GatherDir struct {
+	Path    string
+	Name    string // relative to the must-gather path, "." for the path itself
+	Primary bool   // the default gather, used for node, etcd and host data
+}
+
+// LoadError describes a resource file that was skipped during loading
+type LoadError struct {
+	Path  string
+	Type  string // read, parse or invalid
+	GVK   string // best-effort guess, e.g. "config.openshift.io/v1, Kind=ClusterOperator"
+	Error string
 }
 
 // ListOptions contains options for listing resources
@@ -28,6 +28,15 @@ type Toolset interface {
 	GetTools() []ServerTool
 }
 
+// DetectableToolset is a Toolset that only applies to some must-gathers,
+// typically the ones collected with an additional image (ODF, CNV, logging)
+type DetectableToolset interface {
+	Toolset
+
+	// Detect reports whether the must-gather holds data for this toolset
+	Detect(provider MustGatherProvider) bool
+}
+
 // ToolCallRequest provides access to tool call arguments
 type ToolCallRequest interface {
 	GetArguments() map[string]any
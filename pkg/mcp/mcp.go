@@ -5,19 +5,30 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
+	"sync"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/openshift/must-gather-mcp-server/pkg/api"
 	"github.com/openshift/must-gather-mcp-server/pkg/version"
 )
 
+// maxLoadErrorNotifications bounds the load errors sent to a client as log notifications
+const maxLoadErrorNotifications = 100
+
+// loaderLoggerName is the logger name of load error notifications
+const loaderLoggerName = "must-gather-loader"
+
 // Server represents the MCP server
 type Server struct {
 	server   *mcp.Server
 	provider api.MustGatherProvider
 	toolsets []api.Toolset
+
+	// reported tracks sessions that already received the load errors
+	reported sync.Map
 }
 
 // NewServer creates a new MCP server
@@ -34,12 +45,17 @@ func NewServer(provider api.MustGatherProvider, toolsets []api.Toolset) (*Server
 			Version: version.Version,
 		},
 		&mcp.ServerOptions{
+			Logger: slog.Default(),
 			Capabilities: &mcp.ServerCapabilities{
-				Tools: &mcp.ToolCapabilities{},
+				Tools:   &mcp.ToolCapabilities{},
+				Logging: &mcp.LoggingCapabilities{},
 			},
 		},
 	)
 
+	// Clients only receive log notifications after setting a level
+	s.server.AddReceivingMiddleware(s.loadErrorsMiddleware)
+
 	// Register all tools
 	if err := s.registerTools(); err != nil {
 		return nil, fmt.Errorf("failed to register tools: %w", err)
@@ -69,9 +85,10 @@ func (s *Server) ServeHTTP(ctx context.Context, addr string) error {
 		Handler: handler,
 	}
 
-	fmt.Printf("Starting MCP server on http://%s\n", addr)
-	fmt.Printf("SSE endpoint: http://%s/sse (GET request to establish connection)\n", addr)
-	fmt.Printf("Message endpoint: http://%s/messages/<session-id> (POST for sending messages)\n", addr)
+	slog.Info("starting MCP server",
+		"url", fmt.Sprintf("http://%s", addr),
+		"sse", fmt.Sprintf("http://%s/sse", addr),
+		"messages", fmt.Sprintf("http://%s/messages/<session-id>", addr))
 
 	// Start HTTP server
 	errChan := make(chan error, 1)
@@ -82,7 +99,7 @@ func (s *Server) ServeHTTP(ctx context.Context, addr string) error {
 	// Wait for context cancellation or server error
 	select {
 	case <-ctx.Done():
-		fmt.Println("Shutting down HTTP server...")
+		slog.Info("shutting down HTTP server")
 		return httpServer.Shutdown(context.Background())
 	case err := <-errChan:
 		if err != nil && err != http.ErrServerClosed {
@@ -92,11 +109,69 @@ func (s *Server) ServeHTTP(ctx context.Context, addr string) error {
 	}
 }
 
+// loadErrorsMiddleware sends the files skipped while loading the must-gather
+// as log notifications once a session has set its logging level
+func (s *Server) loadErrorsMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		result, err := next(ctx, method, req)
+		if err != nil || method != "logging/setLevel" {
+			return result, err
+		}
+
+		session, ok := req.GetSession().(*mcp.ServerSession)
+		if !ok {
+			return result, err
+		}
+		if _, done := s.reported.LoadOrStore(session, true); !done {
+			s.notifyLoadErrors(ctx, session)
+		}
+
+		return result, err
+	}
+}
+
+// notifyLoadErrors sends one warning per skipped file, then a summary
+func (s *Server) notifyLoadErrors(ctx context.Context, session *mcp.ServerSession) {
+	loadErrors := s.provider.GetMetadata().LoadErrors
+	if len(loadErrors) == 0 {
+		return
+	}
+
+	for i, loadErr := range loadErrors {
+		if i >= maxLoadErrorNotifications {
+			break
+		}
+		if err := session.Log(ctx, &mcp.LoggingMessageParams{
+			Level:  "warning",
+			Logger: loaderLoggerName,
+			Data: map[string]string{
+				"message": "skipped resource file",
+				"path":    loadErr.Path,
+				"type":    loadErr.Type,
+				"gvk":     loadErr.GVK,
+				"error":   loadErr.Error,
+			},
+		}); err != nil {
+			slog.Debug("failed to send load error notification", "error", err)
+			return
+		}
+	}
+
+	_ = session.Log(ctx, &mcp.LoggingMessageParams{
+		Level:  "warning",
+		Logger: loaderLoggerName,
+		Data: map[string]any{
+			"message":      "some must-gather files could not be loaded, use mustgather_info for details",
+			"skippedFiles": len(loadErrors),
+		},
+	})
+}
+
 // registerTools registers all tools from toolsets
 func (s *Server) registerTools() error {
 	for _, toolset := range s.toolsets {
 		tools := toolset.GetTools()
-		fmt.Printf("Registering %d tools from toolset: %s\n", len(tools), toolset.Name())
+		slog.Debug("registering toolset", "toolset", toolset.Name(), "tools", len(tools))
 
 		for _, tool := range tools {
 			if err := s.registerTool(tool); err != nil {
@@ -143,11 +218,20 @@ func ServerToolToMCPTool(s *Server, tool api.ServerTool) (*mcp.Tool, mcp.ToolHan
 			ToolCallRequest:    toolCallRequest,
 		})
 		if err != nil {
-			return nil, err
+			return nil, errors.New(s.provider.RedactOutput(err.Error()))
+		}
+
+		// Toolsets also read raw files, so redact the final output as well
+		content := s.provider.RedactOutput(result.Content)
+
+		// Error messages carry resource names and paths too
+		toolErr := result.Error
+		if toolErr != nil {
+			toolErr = errors.New(s.provider.RedactOutput(toolErr.Error()))
 		}
 
 		// Return result
-		return NewTextResult(result.Content, result.Error), nil
+		return NewTextResult(content, toolErr), nil
 	}
 
 	return mcpTool, mcpHandler, nil
@@ -0,0 +1,101 @@
+package mustgather
+
+import (
+	"compress/gzip"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+)
+
+// gatherFS implements api.GatherFS on top of any fs.FS (a directory, an
+// archive reader or an in-memory filesystem in tests)
+type gatherFS struct {
+	fsys fs.FS
+}
+
+var _ api.GatherFS = (*gatherFS)(nil)
+
+// NewGatherFS wraps fsys with gzip-aware reads
+func NewGatherFS(fsys fs.FS) api.GatherFS {
+	return &gatherFS{fsys: fsys}
+}
+
+// subGatherFS returns the gather files below dir
+func subGatherFS(fsys fs.FS, dir string) (api.GatherFS, error) {
+	if dir == "" || dir == "." {
+		return NewGatherFS(fsys), nil
+	}
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	return NewGatherFS(sub), nil
+}
+
+// Open opens a file, decompressing it when its name ends in .gz
+func (g *gatherFS) Open(name string) (fs.File, error) {
+	file, err := g.fsys.Open(cleanPath(name))
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(name, ".gz") {
+		return file, nil
+	}
+
+	if info, err := file.Stat(); err == nil && info.IsDir() {
+		return file, nil
+	}
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &gzipFile{File: file, reader: gz}, nil
+}
+
+// ReadFile reads a whole file, decompressing it when its name ends in .gz
+func (g *gatherFS) ReadFile(name string) ([]byte, error) {
+	file, err := g.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// Stat returns the file info as stored
+func (g *gatherFS) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(g.fsys, cleanPath(name))
+}
+
+// ReadDir lists a directory sorted by name
+func (g *gatherFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	return fs.ReadDir(g.fsys, cleanPath(name))
+}
+
+// cleanPath accepts "", leading slashes and redundant elements in paths
+func cleanPath(name string) string {
+	name = path.Clean("/" + name)
+	if name == "/" {
+		return "."
+	}
+	return strings.TrimPrefix(name, "/")
+}
+
+// gzipFile is an open .gz file read through a decompressor
+type gzipFile struct {
+	fs.File
+	reader *gzip.Reader
+}
+
+func (f *gzipFile) Read(p []byte) (int, error) {
+	return f.reader.Read(p)
+}
+
+func (f *gzipFile) Close() error {
+	f.reader.Close()
+	return f.File.Close()
+}
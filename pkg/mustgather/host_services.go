@@ -0,0 +1,110 @@
+package mustgather
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+)
+
+// hostServiceLogsRole is used for logs collected directly under host_service_logs/
+const hostServiceLogsRole = "all"
+
+// ListHostServiceLogs lists the host service journals in host_service_logs/
+func (p *Provider) ListHostServiceLogs() ([]api.HostServiceLog, error) {
+	logsDir := "host_service_logs"
+	if _, err := p.files.Stat(logsDir); err != nil {
+		return []api.HostServiceLog{}, nil
+	}
+
+	entries, err := p.files.ReadDir(logsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read host_service_logs directory: %w", err)
+	}
+
+	logs := make([]api.HostServiceLog, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			if log, ok := p.hostServiceLogFromFile(hostServiceLogsRole, path.Join(logsDir, entry.Name())); ok {
+				logs = append(logs, log)
+			}
+			continue
+		}
+
+		roleDir := path.Join(logsDir, entry.Name())
+		files, err := p.files.ReadDir(roleDir)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			if file.IsDir() {
+				continue
+			}
+			if log, ok := p.hostServiceLogFromFile(entry.Name(), path.Join(roleDir, file.Name())); ok {
+				logs = append(logs, log)
+			}
+		}
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].Role != logs[j].Role {
+			return logs[i].Role < logs[j].Role
+		}
+		return logs[i].Service < logs[j].Service
+	})
+
+	return logs, nil
+}
+
+// GetHostServiceLog returns the journal of a host service for a node role
+func (p *Provider) GetHostServiceLog(role, service string) (string, error) {
+	logs, err := p.ListHostServiceLogs()
+	if err != nil {
+		return "", err
+	}
+
+	for _, log := range logs {
+		if log.Role != role || log.Service != service {
+			continue
+		}
+
+		content, err := p.readTextFile(log.Path)
+		if err != nil {
+			return "", fmt.Errorf("failed to read host service log: %w", err)
+		}
+
+		return p.redactor.RedactText(content), nil
+	}
+
+	return "", fmt.Errorf("host service log not found: %s/%s", role, service)
+}
+
+// hostServiceLogFromFile derives the service name from a log file name such as
+// crio_service.log, kubelet_service.log.gz or NetworkManager.log
+func (p *Provider) hostServiceLogFromFile(role, name string) (api.HostServiceLog, bool) {
+	logPath := name
+	name = path.Base(name)
+
+	compressed := strings.HasSuffix(name, ".gz")
+	name = strings.TrimSuffix(name, ".gz")
+	if !strings.HasSuffix(name, ".log") {
+		return api.HostServiceLog{}, false
+	}
+	name = strings.TrimSuffix(name, ".log")
+	name = strings.TrimSuffix(name, "_service")
+
+	info, err := p.files.Stat(logPath)
+	if err != nil {
+		return api.HostServiceLog{}, false
+	}
+
+	return api.HostServiceLog{
+		Role:       role,
+		Service:    name,
+		Path:       logPath,
+		Size:       info.Size(),
+		Compressed: compressed,
+	}, true
+}
@@ -0,0 +1,110 @@
+package mustgather
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// gatherMarkers identify a directory holding gathered resources, whatever the
+// image it was collected by (quay.io, registry.redhat.io, a local oc adm inspect)
+var gatherMarkers = []string{"cluster-scoped-resources", "namespaces"}
+
+// defaultGatherContent is only collected by the default must-gather image, so
+// it tells the default directory apart from plugin directories (ODF, CNV, ...)
+var defaultGatherContent = []string{
+	"etcd_info",
+	"host_service_logs",
+	"monitoring",
+	"network_logs",
+	"nodes",
+	"pod_network_connectivity_check",
+	"static-pods",
+	"audit_logs",
+}
+
+// GatherDir is a directory with gathered content, typically one per image
+type GatherDir struct {
+	Path string
+	// Name is the directory relative to the must-gather root ("." for the root itself)
+	Name string
+	// Primary marks the default gather, used for node, etcd and host data
+	Primary bool
+}
+
+// DetectGatherDirs finds the directories holding gathered content by looking
+// for cluster-scoped-resources/ or namespaces/ in the root of fsys and its
+// subdirectories. basePath is the location of fsys, used for GatherDir.Path.
+// The primary directory comes first.
+func DetectGatherDirs(fsys fs.FS, basePath string) ([]GatherDir, error) {
+	dirs := make([]GatherDir, 0)
+
+	if hasGatherContent(fsys, ".") {
+		// oc adm inspect output or a gather directory passed directly
+		dirs = append(dirs, GatherDir{Path: basePath, Name: "."})
+	}
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if gatherMarkerDir(entry.Name()) || !hasGatherContent(fsys, entry.Name()) {
+			continue
+		}
+		dirs = append(dirs, GatherDir{Path: filepath.Join(basePath, entry.Name()), Name: entry.Name()})
+	}
+
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no gathered content (cluster-scoped-resources/ or namespaces/) found in %s or its subdirectories", basePath)
+	}
+
+	// The directory with the most default-only content is the default gather
+	sort.SliceStable(dirs, func(i, j int) bool {
+		si, sj := defaultContentScore(fsys, dirs[i].Name), defaultContentScore(fsys, dirs[j].Name)
+		if si != sj {
+			return si > sj
+		}
+		return dirs[i].Name < dirs[j].Name
+	})
+	dirs[0].Primary = true
+
+	return dirs, nil
+}
+
+func hasGatherContent(fsys fs.FS, dir string) bool {
+	for _, marker := range gatherMarkers {
+		if info, err := fs.Stat(fsys, path.Join(dir, marker)); err == nil && info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+func gatherMarkerDir(name string) bool {
+	for _, marker := range gatherMarkers {
+		if name == marker {
+			return true
+		}
+	}
+	return false
+}
+
+func defaultContentScore(fsys fs.FS, dir string) int {
+	score := 0
+	for _, name := range defaultGatherContent {
+		if _, err := fs.Stat(fsys, path.Join(dir, name)); err == nil {
+			score++
+		}
+	}
+	// Cluster-scoped resources are gathered by the default image, rarely by plugins
+	if _, err := fs.Stat(fsys, path.Join(dir, "cluster-scoped-resources", "config.openshift.io")); err == nil {
+		score++
+	}
+	return score
+}
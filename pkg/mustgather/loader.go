@@ -1,14 +1,20 @@
 package mustgather
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"io/fs"
+	"log/slog"
+	"path"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // LoadResult contains the result of loading a must-gather
@@ -16,6 +22,15 @@ type LoadResult struct {
 	Resources  []*unstructured.Unstructured
 	Namespaces []string
 	Metadata   *LoadMetadata
+	// Errors lists resource files that were skipped
+	Errors []*LoadError
+	// Sources maps ResourceKey to the gather directories the object was found in
+	Sources map[string][]string
+}
+
+// ResourceKey identifies an object across gather directories
+func ResourceKey(gvk schema.GroupVersionKind, namespace, name string) string {
+	return gvk.String() + "|" + namespace + "/" + name
 }
 
 // LoadMetadata contains metadata extracted during loading
@@ -26,12 +41,123 @@ type LoadMetadata struct {
 	EndTime        time.Time
 	ResourceCount  int
 	NamespaceCount int
+	GatherDirs     []GatherDir
+}
+
+// LoadErrorType classifies why a resource file was skipped
+type LoadErrorType string
+
+const (
+	// LoadErrorRead means the file could not be read
+	LoadErrorRead LoadErrorType = "read"
+	// LoadErrorParse means the file is not valid YAML
+	LoadErrorParse LoadErrorType = "parse"
+	// LoadErrorInvalid means the YAML is not a Kubernetes object
+	LoadErrorInvalid LoadErrorType = "invalid"
+)
+
+// LoadError describes a resource file that was skipped during loading
+type LoadError struct {
+	Path string
+	Type LoadErrorType
+	// GVK is guessed from the file content or, failing that, its path
+	GVK schema.GroupVersionKind
+	// Resource is the resource type guessed from the path
+	Resource string
+	Err      error
+}
+
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("%s error in %s: %v", e.Type, e.Path, e.Err)
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// GVKString renders the guessed GVK, falling back to the resource type from the path
+func (e *LoadError) GVKString() string {
+	if e.GVK.Kind != "" || e.GVK.Version != "" {
+		return e.GVK.GroupVersion().String() + ", Kind=" + e.GVK.Kind
+	}
+	if e.GVK.Group != "" {
+		return e.GVK.Group + "/" + e.Resource
+	}
+	return e.Resource
 }
 
-// Load loads a must-gather from the specified path
-func Load(mustGatherPath string) (*LoadResult, error) {
+var (
+	apiVersionLinePattern = regexp.MustCompile(`(?m)^apiVersion:\s*["']?([^\s"']+)`)
+	kindLinePattern       = regexp.MustCompile(`(?m)^kind:\s*["']?([^\s"']+)`)
+)
+
+// newLoadError builds a LoadError with a best-effort GVK guess. Broken files
+// usually still have readable apiVersion and kind lines; otherwise the group
+// and resource come from the must-gather directory layout.
+func newLoadError(name string, errType LoadErrorType, data []byte, err error) *LoadError {
+	loadErr := &LoadError{Path: name, Type: errType, Err: err}
+
+	if m := apiVersionLinePattern.FindSubmatch(data); m != nil {
+		if gv, err := schema.ParseGroupVersion(string(m[1])); err == nil {
+			loadErr.GVK.Group = gv.Group
+			loadErr.GVK.Version = gv.Version
+		}
+	}
+	if m := kindLinePattern.FindSubmatch(data); m != nil {
+		loadErr.GVK.Kind = string(m[1])
+	}
+
+	group, resource := layoutGroupResource(name)
+	loadErr.Resource = resource
+	if loadErr.GVK.Group == "" && loadErr.GVK.Version == "" {
+		loadErr.GVK.Group = group
+	}
+
+	return loadErr
+}
+
+// layoutGroupResource reads the API group and resource from a file's place in
+// the must-gather layout, the core group being returned as "":
+//
+//	namespaces/{ns}/{group}/{resource}.yaml
+//	namespaces/{ns}/{group}/{resource}/{name}.yaml
+//	cluster-scoped-resources/{group}/{resource}.yaml
+//	cluster-scoped-resources/{group}/{resource}/{name}.yaml
+func layoutGroupResource(name string) (string, string) {
+	parts := strings.Split(filepath.ToSlash(name), "/")
+
+	var rest []string
+	for i, part := range parts {
+		tail := parts[i+1:]
+		if part == "namespaces" && (len(tail) == 3 || len(tail) == 4) {
+			rest = tail[1:]
+			break
+		}
+		if part == "cluster-scoped-resources" && (len(tail) == 2 || len(tail) == 3) {
+			rest = tail
+			break
+		}
+	}
+	if rest == nil {
+		return "", ""
+	}
+
+	group := rest[0]
+	if group == "core" {
+		group = ""
+	}
+	resource := rest[1]
+	if len(rest) == 2 {
+		resource = strings.TrimSuffix(resource, path.Ext(resource))
+	}
+	return group, resource
+}
+
+// Load loads a must-gather from fsys. mustGatherPath is where fsys comes
+// from, used in metadata and error messages.
+func Load(fsys fs.FS, mustGatherPath string) (*LoadResult, error) {
 	// Verify path exists
-	if _, err := os.Stat(mustGatherPath); os.IsNotExist(err) {
+	if _, err := fs.Stat(fsys, "."); err != nil {
 		return nil, fmt.Errorf("must-gather path does not exist: %s", mustGatherPath)
 	}
 
@@ -42,78 +168,104 @@ func Load(mustGatherPath string) (*LoadResult, error) {
 		},
 	}
 
-	// Find the actual must-gather container directory
-	containerDir, err := findContainerDir(mustGatherPath)
+	// Find the directories with gathered content, one per image
+	gatherDirs, err := DetectGatherDirs(fsys, mustGatherPath)
 	if err != nil {
-		// If no container dir found, use the path as-is
-		containerDir = mustGatherPath
+		// Nothing recognisable, use the path as-is
+		slog.Warn("no gathered content detected, loading path as-is", "path", mustGatherPath, "error", err)
+		gatherDirs = []GatherDir{{Path: mustGatherPath, Name: ".", Primary: true}}
 	}
-
-	// Load metadata files
-	if err := loadMetadata(containerDir, result.Metadata); err != nil {
-		// Non-fatal, just log
-		fmt.Printf("Warning: could not load metadata: %v\n", err)
+	result.Metadata.GatherDirs = gatherDirs
+	for _, dir := range gatherDirs {
+		slog.Info("detected gather directory", "dir", dir.Name, "primary", dir.Primary)
 	}
 
-	// Load cluster-scoped resources
-	clusterScopedDir := filepath.Join(containerDir, "cluster-scoped-resources")
-	if _, err := os.Stat(clusterScopedDir); err == nil {
-		resources, err := loadClusterScopedResources(clusterScopedDir)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load cluster-scoped resources: %w", err)
+	// Load metadata files, preferring the primary directory
+	for _, dir := range gatherDirs {
+		if err := loadMetadata(fsys, dir.Name, result.Metadata); err != nil {
+			// Non-fatal, just log
+			slog.Warn("could not load must-gather metadata", "dir", dir.Name, "error", err)
+		}
+		if !result.Metadata.StartTime.IsZero() {
+			break
 		}
-		result.Resources = append(result.Resources, resources...)
 	}
 
-	// Load namespaced resources
-	namespacesDir := filepath.Join(containerDir, "namespaces")
-	if _, err := os.Stat(namespacesDir); err == nil {
-		resources, namespaces, err := loadNamespacedResources(namespacesDir)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load namespaced resources: %w", err)
+	// Merge resources from all gather directories into one set. An object
+	// gathered by several images is kept once, from the first directory.
+	result.Sources = make(map[string][]string)
+	namespaceSet := make(map[string]bool)
+	for _, dir := range gatherDirs {
+		dirResources := make([]*unstructured.Unstructured, 0)
+
+		// Load cluster-scoped resources
+		clusterScopedDir := path.Join(dir.Name, "cluster-scoped-resources")
+		if _, err := fs.Stat(fsys, clusterScopedDir); err == nil {
+			resources, err := loadClusterScopedResources(fsys, clusterScopedDir, result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load cluster-scoped resources from %s: %w", dir.Name, err)
+			}
+			dirResources = append(dirResources, resources...)
+		}
+
+		// Load namespaced resources
+		namespacesDir := path.Join(dir.Name, "namespaces")
+		if _, err := fs.Stat(fsys, namespacesDir); err == nil {
+			resources, namespaces, err := loadNamespacedResources(fsys, namespacesDir, result)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load namespaced resources from %s: %w", dir.Name, err)
+			}
+			dirResources = append(dirResources, resources...)
+			for _, ns := range namespaces {
+				namespaceSet[ns] = true
+			}
+		}
+
+		for _, resource := range dirResources {
+			key := ResourceKey(resource.GroupVersionKind(), resource.GetNamespace(), resource.GetName())
+			if _, seen := result.Sources[key]; !seen {
+				result.Resources = append(result.Resources, resource)
+			}
+			result.Sources[key] = append(result.Sources[key], dir.Name)
 		}
-		result.Resources = append(result.Resources, resources...)
-		result.Namespaces = namespaces
 	}
 
+	result.Namespaces = make([]string, 0, len(namespaceSet))
+	for ns := range namespaceSet {
+		result.Namespaces = append(result.Namespaces, ns)
+	}
+	sort.Strings(result.Namespaces)
+
 	result.Metadata.ResourceCount = len(result.Resources)
 	result.Metadata.NamespaceCount = len(result.Namespaces)
 
 	return result, nil
 }
 
-// findContainerDir finds the must-gather container directory
-// Must-gather structure can be: must-gather-root/quay-io-...-sha256-.../
-func findContainerDir(basePath string) (string, error) {
-	entries, err := os.ReadDir(basePath)
-	if err != nil {
-		return "", err
-	}
+// addError records a skipped resource file, name being relative to the must-gather root
+func (r *LoadResult) addError(name string, err error) {
+	filePath := filepath.Join(r.Metadata.Path, filepath.FromSlash(name))
 
-	// Look for directory starting with "quay" or containing "sha256"
-	for _, entry := range entries {
-		if entry.IsDir() {
-			name := entry.Name()
-			if strings.HasPrefix(name, "quay") || strings.Contains(name, "sha256") {
-				return filepath.Join(basePath, name), nil
-			}
-		}
+	var loadErr *LoadError
+	if !errors.As(err, &loadErr) {
+		loadErr = newLoadError(filePath, LoadErrorRead, nil, err)
 	}
-
-	return "", fmt.Errorf("container directory not found")
+	loadErr.Path = filePath
+	slog.Warn("skipped resource file", "path", filePath, "type", loadErr.Type, "gvk", loadErr.GVKString(), "error", loadErr.Err)
+	r.Errors = append(r.Errors, loadErr)
 }
 
 // loadMetadata loads metadata from timestamp and version files
-func loadMetadata(containerDir string, metadata *LoadMetadata) error {
+func loadMetadata(fsys fs.FS, containerDir string, metadata *LoadMetadata) error {
 	// Load version
-	versionFile := filepath.Join(containerDir, "version")
-	if data, err := os.ReadFile(versionFile); err == nil {
+	versionFile := path.Join(containerDir, "version")
+	if data, err := fs.ReadFile(fsys, versionFile); err == nil {
 		metadata.Version = strings.TrimSpace(string(data))
 	}
 
 	// Load timestamps
-	timestampFile := filepath.Join(containerDir, "timestamp")
-	if data, err := os.ReadFile(timestampFile); err == nil {
+	timestampFile := path.Join(containerDir, "timestamp")
+	if data, err := fs.ReadFile(fsys, timestampFile); err == nil {
 		lines := strings.Split(string(data), "\n")
 		for _, line := range lines {
 			line = strings.TrimSpace(line)
@@ -136,24 +288,24 @@ func loadMetadata(containerDir string, metadata *LoadMetadata) error {
 
 // loadClusterScopedResources loads cluster-scoped resources
 // Structure: cluster-scoped-resources/{api-group}/{resource-type}/{resource-name}.yaml
-func loadClusterScopedResources(clusterScopedDir string) ([]*unstructured.Unstructured, error) {
+func loadClusterScopedResources(fsys fs.FS, clusterScopedDir string, result *LoadResult) ([]*unstructured.Unstructured, error) {
 	resources := make([]*unstructured.Unstructured, 0)
 
 	// Walk all subdirectories
-	err := filepath.Walk(clusterScopedDir, func(path string, info os.FileInfo, err error) error {
+	err := fs.WalkDir(fsys, clusterScopedDir, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
 
 		// Only process YAML files
-		if info.IsDir() || !isYAMLFile(path) {
+		if d.IsDir() || !isYAMLFile(path) {
 			return nil
 		}
 
 		// Load the resource
-		resource, err := loadSingleResourceFile(path)
+		resource, err := loadSingleResourceFile(fsys, path)
 		if err != nil {
-			fmt.Printf("Warning: failed to load %s: %v\n", path, err)
+			result.addError(path, err)
 			return nil // Continue processing other files
 		}
 
@@ -169,12 +321,12 @@ func loadClusterScopedResources(clusterScopedDir string) ([]*unstructured.Unstru
 
 // loadNamespacedResources loads namespaced resources
 // Structure: namespaces/{namespace}/{api-group}/{resource-type}.yaml
-func loadNamespacedResources(namespacesDir string) ([]*unstructured.Unstructured, []string, error) {
+func loadNamespacedResources(fsys fs.FS, namespacesDir string, result *LoadResult) ([]*unstructured.Unstructured, []string, error) {
 	resources := make([]*unstructured.Unstructured, 0)
 	namespaceSet := make(map[string]bool)
 
 	// List namespace directories
-	namespaceEntries, err := os.ReadDir(namespacesDir)
+	namespaceEntries, err := fs.ReadDir(fsys, namespacesDir)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -186,23 +338,23 @@ func loadNamespacedResources(namespacesDir string) ([]*unstructured.Unstructured
 
 		namespace := nsEntry.Name()
 		namespaceSet[namespace] = true
-		nsDir := filepath.Join(namespacesDir, namespace)
+		nsDir := path.Join(namespacesDir, namespace)
 
 		// Walk the namespace directory
-		err := filepath.Walk(nsDir, func(path string, info os.FileInfo, err error) error {
+		err := fs.WalkDir(fsys, nsDir, func(path string, d fs.DirEntry, err error) error {
 			if err != nil {
 				return err
 			}
 
 			// Only process YAML files
-			if info.IsDir() || !isYAMLFile(path) {
+			if d.IsDir() || !isYAMLFile(path) {
 				return nil
 			}
 
 			// Load resources (can be multiple per file)
-			fileResources, err := loadMultiResourceFile(path)
+			fileResources, err := loadMultiResourceFile(fsys, path)
 			if err != nil {
-				fmt.Printf("Warning: failed to load %s: %v\n", path, err)
+				result.addError(path, err)
 				return nil // Continue processing
 			}
 
@@ -225,22 +377,25 @@ func loadNamespacedResources(namespacesDir string) ([]*unstructured.Unstructured
 }
 
 // loadSingleResourceFile loads a single resource from a YAML file
-func loadSingleResourceFile(path string) (*unstructured.Unstructured, error) {
-	data, err := os.ReadFile(path)
+func loadSingleResourceFile(fsys fs.FS, path string) (*unstructured.Unstructured, error) {
+	data, err := fs.ReadFile(fsys, path)
 	if err != nil {
-		return nil, err
+		return nil, newLoadError(path, LoadErrorRead, nil, err)
 	}
 
 	// Parse YAML
 	var obj map[string]interface{}
 	if err := yaml.Unmarshal(data, &obj); err != nil {
-		return nil, err
+		return nil, newLoadError(path, LoadErrorParse, data, err)
 	}
 
 	// Skip empty files
 	if len(obj) == 0 {
 		return nil, nil
 	}
+	if _, ok := obj["kind"].(string); !ok {
+		return nil, newLoadError(path, LoadErrorInvalid, data, fmt.Errorf("object has no kind"))
+	}
 
 	// Normalize YAML types to JSON-compatible types
 	normalizeYAMLTypes(obj)
@@ -250,10 +405,10 @@ func loadSingleResourceFile(path string) (*unstructured.Unstructured, error) {
 
 // loadMultiResourceFile loads multiple resources from a YAML file
 // Handles both single resources and lists
-func loadMultiResourceFile(path string) ([]*unstructured.Unstructured, error) {
-	data, err := os.ReadFile(path)
+func loadMultiResourceFile(fsys fs.FS, path string) ([]*unstructured.Unstructured, error) {
+	data, err := fs.ReadFile(fsys, path)
 	if err != nil {
-		return nil, err
+		return nil, newLoadError(path, LoadErrorRead, nil, err)
 	}
 
 	resources := make([]*unstructured.Unstructured, 0)
@@ -261,13 +416,16 @@ func loadMultiResourceFile(path string) ([]*unstructured.Unstructured, error) {
 	// Try to parse as a list first
 	var obj map[string]interface{}
 	if err := yaml.Unmarshal(data, &obj); err != nil {
-		return nil, err
+		return nil, newLoadError(path, LoadErrorParse, data, err)
 	}
 
 	// Skip empty files
 	if len(obj) == 0 {
 		return resources, nil
 	}
+	if _, ok := obj["kind"].(string); !ok {
+		return nil, newLoadError(path, LoadErrorInvalid, data, fmt.Errorf("object has no kind"))
+	}
 
 	// Normalize YAML types to JSON-compatible types
 	normalizeYAMLTypes(obj)
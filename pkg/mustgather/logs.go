@@ -1,13 +1,8 @@
 package mustgather
 
 import (
-	"bufio"
-	"bytes"
-	"compress/gzip"
 	"fmt"
-	"io"
-	"os"
-	"path/filepath"
+	"path"
 	"strings"
 
 	"github.com/openshift/must-gather-mcp-server/pkg/api"
@@ -15,32 +10,35 @@ import (
 
 // GetPodLog retrieves pod container logs
 func (p *Provider) GetPodLog(opts api.PodLogOptions) (string, error) {
-	containerDir, err := findContainerDir(p.path)
-	if err != nil {
-		containerDir = p.path
-	}
-
 	// Construct log path: namespaces/{ns}/pods/{pod}/{container}/{container}/logs/{logtype}.log
+	// Plugin images gather their own namespaces, so look in every gather directory
 	logFile := string(opts.LogType) + ".log"
-	logPath := filepath.Join(
-		containerDir,
-		"namespaces",
-		opts.Namespace,
-		"pods",
-		opts.Pod,
-		opts.Container,
-		opts.Container, // Container name appears twice in path
-		"logs",
-		logFile,
-	)
+	logPath := ""
+	for _, dir := range p.gatherDirNames() {
+		candidate := path.Join(
+			dir,
+			"namespaces",
+			opts.Namespace,
+			"pods",
+			opts.Pod,
+			opts.Container,
+			opts.Container, // Container name appears twice in path
+			"logs",
+			logFile,
+		)
+		if _, err := p.root.Stat(candidate); err == nil {
+			logPath = candidate
+			break
+		}
+	}
 
 	// Check if file exists
-	if _, err := os.Stat(logPath); os.IsNotExist(err) {
-		return "", fmt.Errorf("log file not found: %s", logPath)
+	if logPath == "" {
+		return "", fmt.Errorf("log file not found: namespaces/%s/pods/%s/%s/%s/logs/%s", opts.Namespace, opts.Pod, opts.Container, opts.Container, logFile)
 	}
 
 	// Read the log file
-	data, err := os.ReadFile(logPath)
+	data, err := p.root.ReadFile(logPath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read log file: %w", err)
 	}
@@ -52,25 +50,27 @@ func (p *Provider) GetPodLog(opts api.PodLogOptions) (string, error) {
 		content = TailLines(content, opts.TailLines)
 	}
 
-	return content, nil
+	return p.redactor.RedactText(content), nil
 }
 
 // ListPodContainers lists all containers for a pod
 func (p *Provider) ListPodContainers(namespace, pod string) ([]string, error) {
-	containerDir, err := findContainerDir(p.path)
-	if err != nil {
-		containerDir = p.path
+	podsDir := ""
+	for _, dir := range p.gatherDirNames() {
+		candidate := path.Join(dir, "namespaces", namespace, "pods", pod)
+		if _, err := p.root.Stat(candidate); err == nil {
+			podsDir = candidate
+			break
+		}
 	}
 
-	podsDir := filepath.Join(containerDir, "namespaces", namespace, "pods", pod)
-
 	// Check if pod directory exists
-	if _, err := os.Stat(podsDir); os.IsNotExist(err) {
+	if podsDir == "" {
 		return nil, fmt.Errorf("pod directory not found: %s/%s", namespace, pod)
 	}
 
 	// List subdirectories (containers)
-	entries, err := os.ReadDir(podsDir)
+	entries, err := p.root.ReadDir(podsDir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read pod directory: %w", err)
 	}
@@ -80,8 +80,8 @@ func (p *Provider) ListPodContainers(namespace, pod string) ([]string, error) {
 		if entry.IsDir() {
 			name := entry.Name()
 			// Check if it has logs subdirectory
-			logsDir := filepath.Join(podsDir, name, name, "logs")
-			if _, err := os.Stat(logsDir); err == nil {
+			logsDir := path.Join(podsDir, name, name, "logs")
+			if _, err := p.root.Stat(logsDir); err == nil {
 				containers = append(containers, name)
 			}
 		}
@@ -92,15 +92,10 @@ func (p *Provider) ListPodContainers(namespace, pod string) ([]string, error) {
 
 // GetNodeDiagnostics retrieves node diagnostic information
 func (p *Provider) GetNodeDiagnostics(nodeName string) (*api.NodeDiagnostics, error) {
-	containerDir, err := findContainerDir(p.path)
-	if err != nil {
-		containerDir = p.path
-	}
-
-	nodeDir := filepath.Join(containerDir, "nodes", nodeName)
+	nodeDir := path.Join("nodes", nodeName)
 
 	// Check if node directory exists
-	if _, err := os.Stat(nodeDir); os.IsNotExist(err) {
+	if _, err := p.files.Stat(nodeDir); err != nil {
 		return nil, fmt.Errorf("node directory not found: %s", nodeName)
 	}
 
@@ -109,68 +104,63 @@ func (p *Provider) GetNodeDiagnostics(nodeName string) (*api.NodeDiagnostics, er
 	}
 
 	// Read kubelet log (gzipped)
-	kubeletLogPath := filepath.Join(nodeDir, nodeName+"_logs_kubelet.gz")
-	if content, err := readGzipFile(kubeletLogPath); err == nil {
+	kubeletLogPath := path.Join(nodeDir, nodeName+"_logs_kubelet.gz")
+	if content, err := p.readTextFile(kubeletLogPath); err == nil {
 		diag.KubeletLog = content
 	}
 
 	// Read sysinfo.log
-	if content, err := readTextFile(filepath.Join(nodeDir, "sysinfo.log")); err == nil {
+	if content, err := p.readTextFile(path.Join(nodeDir, "sysinfo.log")); err == nil {
 		diag.SysInfo = content
 	}
 
 	// Read JSON files
-	if content, err := readTextFile(filepath.Join(nodeDir, "cpu_affinities.json")); err == nil {
+	if content, err := p.readTextFile(path.Join(nodeDir, "cpu_affinities.json")); err == nil {
 		diag.CPUAffinities = content
 	}
 
-	if content, err := readTextFile(filepath.Join(nodeDir, "irq_affinities.json")); err == nil {
+	if content, err := p.readTextFile(path.Join(nodeDir, "irq_affinities.json")); err == nil {
 		diag.IRQAffinities = content
 	}
 
-	if content, err := readTextFile(filepath.Join(nodeDir, "pods_info.json")); err == nil {
+	if content, err := p.readTextFile(path.Join(nodeDir, "pods_info.json")); err == nil {
 		diag.PodsInfo = content
 	}
 
-	if content, err := readTextFile(filepath.Join(nodeDir, "podresources.json")); err == nil {
+	if content, err := p.readTextFile(path.Join(nodeDir, "podresources.json")); err == nil {
 		diag.PodResources = content
 	}
 
 	// Read system info files
-	if content, err := readTextFile(filepath.Join(nodeDir, "lscpu")); err == nil {
+	if content, err := p.readTextFile(path.Join(nodeDir, "lscpu")); err == nil {
 		diag.Lscpu = content
 	}
 
-	if content, err := readTextFile(filepath.Join(nodeDir, "lspci")); err == nil {
+	if content, err := p.readTextFile(path.Join(nodeDir, "lspci")); err == nil {
 		diag.Lspci = content
 	}
 
-	if content, err := readTextFile(filepath.Join(nodeDir, "dmesg")); err == nil {
+	if content, err := p.readTextFile(path.Join(nodeDir, "dmesg")); err == nil {
 		diag.Dmesg = content
 	}
 
-	if content, err := readTextFile(filepath.Join(nodeDir, "proc_cmdline")); err == nil {
+	if content, err := p.readTextFile(path.Join(nodeDir, "proc_cmdline")); err == nil {
 		diag.ProcCmdline = content
 	}
 
+	p.redactNodeDiagnostics(diag)
+
 	return diag, nil
 }
 
 // ListNodes lists all nodes in the must-gather
 func (p *Provider) ListNodes() ([]string, error) {
-	containerDir, err := findContainerDir(p.path)
-	if err != nil {
-		containerDir = p.path
-	}
-
-	nodesDir := filepath.Join(containerDir, "nodes")
-
 	// Check if nodes directory exists
-	if _, err := os.Stat(nodesDir); os.IsNotExist(err) {
+	if _, err := p.files.Stat("nodes"); err != nil {
 		return []string{}, nil
 	}
 
-	entries, err := os.ReadDir(nodesDir)
+	entries, err := p.files.ReadDir("nodes")
 	if err != nil {
 		return nil, fmt.Errorf("failed to read nodes directory: %w", err)
 	}
@@ -185,35 +175,24 @@ func (p *Provider) ListNodes() ([]string, error) {
 	return nodes, nil
 }
 
-// Helper functions
-
-func readTextFile(path string) (string, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return "", err
+// redactNodeDiagnostics masks sensitive values in the free-text node diagnostics
+func (p *Provider) redactNodeDiagnostics(diag *api.NodeDiagnostics) {
+	for _, field := range []*string{
+		&diag.KubeletLog, &diag.SysInfo, &diag.PodsInfo, &diag.PodResources, &diag.Dmesg, &diag.ProcCmdline,
+	} {
+		*field = p.redactor.RedactText(*field)
 	}
-	return string(data), nil
 }
 
-func readGzipFile(path string) (string, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return "", err
-	}
-	defer file.Close()
+// Helper functions
 
-	gz, err := gzip.NewReader(file)
+// readTextFile reads a file of the primary gather directory, decompressing .gz files
+func (p *Provider) readTextFile(name string) (string, error) {
+	data, err := p.files.ReadFile(name)
 	if err != nil {
 		return "", err
 	}
-	defer gz.Close()
-
-	var buf bytes.Buffer
-	if _, err := io.Copy(&buf, gz); err != nil {
-		return "", err
-	}
-
-	return buf.String(), nil
+	return string(data), nil
 }
 
 // TailLines returns the last n lines from the content
@@ -225,29 +204,3 @@ func TailLines(content string, n int) string {
 
 	return strings.Join(lines[len(lines)-n:], "\n")
 }
-
-// tailLinesFromGzip reads the last n lines from a gzipped file efficiently
-func tailLinesFromGzip(path string, n int) (string, error) {
-	// For simplicity, decompress entire file and tail
-	// In production, you might want to optimize this
-	content, err := readGzipFile(path)
-	if err != nil {
-		return "", err
-	}
-
-	if n == 0 {
-		return content, nil
-	}
-
-	// Get last n lines
-	scanner := bufio.NewScanner(strings.NewReader(content))
-	lines := make([]string, 0)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-		if len(lines) > n {
-			lines = lines[1:]
-		}
-	}
-
-	return strings.Join(lines, "\n"), nil
-}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io/fs"
+	"log/slog"
 	"os"
-	"path/filepath"
+	"path"
+	"sort"
 	"strings"
 
 	"github.com/openshift/must-gather-mcp-server/pkg/api"
@@ -18,24 +21,56 @@ type Provider struct {
 	path     string
 	index    *ResourceIndex
 	metadata *api.MustGatherMetadata
+	redactor *Redactor
+	sources  map[string][]string
+
+	// root holds the whole must-gather, files the primary gather directory
+	root  api.GatherFS
+	files api.GatherFS
+}
+
+// ProviderOptions configures a must-gather provider
+type ProviderOptions struct {
+	Redaction RedactionConfig
+	// FS holds the must-gather content, the directory at the must-gather path
+	// when nil. Set it to load from an archive or an in-memory filesystem.
+	FS fs.FS
 }
 
-// NewProvider creates a new must-gather provider
+// NewProvider creates a new must-gather provider with default options
 func NewProvider(mustGatherPath string) (*Provider, error) {
-	fmt.Printf("Loading must-gather from: %s\n", mustGatherPath)
+	return NewProviderWithOptions(mustGatherPath, ProviderOptions{Redaction: DefaultRedactionConfig()})
+}
+
+// NewProviderWithOptions creates a new must-gather provider
+func NewProviderWithOptions(mustGatherPath string, opts ProviderOptions) (*Provider, error) {
+	redactor, err := NewRedactor(opts.Redaction)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redaction configuration: %w", err)
+	}
+	if !redactor.Enabled() {
+		slog.Warn("redaction is disabled, secrets will be returned verbatim")
+	}
+
+	slog.Info("loading must-gather", "path", mustGatherPath)
+
+	fsys := opts.FS
+	if fsys == nil {
+		fsys = os.DirFS(mustGatherPath)
+	}
 
 	// Load the must-gather
-	result, err := Load(mustGatherPath)
+	result, err := Load(fsys, mustGatherPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load must-gather: %w", err)
 	}
 
-	fmt.Printf("Loaded %d resources from %d namespaces\n", result.Metadata.ResourceCount, result.Metadata.NamespaceCount)
+	slog.Info("loaded must-gather", "resources", result.Metadata.ResourceCount, "namespaces", result.Metadata.NamespaceCount, "skippedFiles", len(result.Errors))
 
 	// Build index
-	fmt.Printf("Building resource index...\n")
+	slog.Debug("building resource index")
 	index := BuildIndex(result.Resources, result.Namespaces)
-	fmt.Printf("Index built with %d resources\n", index.Count())
+	slog.Info("index built", "resources", index.Count())
 
 	// Convert metadata
 	metadata := &api.MustGatherMetadata{
@@ -46,14 +81,47 @@ func NewProvider(mustGatherPath string) (*Provider, error) {
 		ResourceCount:  result.Metadata.ResourceCount,
 		NamespaceCount: result.Metadata.NamespaceCount,
 	}
+	primary := "."
+	for _, dir := range result.Metadata.GatherDirs {
+		metadata.GatherDirs = append(metadata.GatherDirs, api.GatherDir{Path: dir.Path, Name: dir.Name, Primary: dir.Primary})
+		if dir.Primary {
+			primary = dir.Name
+		}
+	}
+	files, err := subGatherFS(fsys, primary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open gather directory %s: %w", primary, err)
+	}
+	for _, loadErr := range result.Errors {
+		metadata.LoadErrors = append(metadata.LoadErrors, api.LoadError{
+			Path:  loadErr.Path,
+			Type:  string(loadErr.Type),
+			GVK:   loadErr.GVKString(),
+			Error: loadErr.Err.Error(),
+		})
+	}
 
 	return &Provider{
 		path:     mustGatherPath,
 		index:    index,
 		metadata: metadata,
+		redactor: redactor,
+		sources:  result.Sources,
+		root:     NewGatherFS(fsys),
+		files:    files,
 	}, nil
 }
 
+// Files returns the files of the primary gather directory
+func (p *Provider) Files() api.GatherFS {
+	return p.files
+}
+
+// RootFiles returns the files of the must-gather root
+func (p *Provider) RootFiles() api.GatherFS {
+	return p.root
+}
+
 // GetMetadata returns must-gather metadata
 func (p *Provider) GetMetadata() *api.MustGatherMetadata {
 	return p.metadata
@@ -61,7 +129,28 @@ func (p *Provider) GetMetadata() *api.MustGatherMetadata {
 
 // GetResource retrieves a specific resource
 func (p *Provider) GetResource(ctx context.Context, gvk schema.GroupVersionKind, namespace, name string) (*unstructured.Unstructured, error) {
-	return p.index.Get(gvk, namespace, name)
+	resource, err := p.index.Get(gvk, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return p.redactor.RedactObject(resource), nil
+}
+
+// GetResourceSources returns the gather directories an object was found in
+func (p *Provider) GetResourceSources(gvk schema.GroupVersionKind, namespace, name string) []string {
+	return p.sources[ResourceKey(gvk, namespace, name)]
+}
+
+// gatherDirNames returns all gather directories relative to the root, primary first
+func (p *Provider) gatherDirNames() []string {
+	names := make([]string, 0, len(p.metadata.GatherDirs))
+	for _, dir := range p.metadata.GatherDirs {
+		names = append(names, dir.Name)
+	}
+	if len(names) == 0 {
+		names = append(names, ".")
+	}
+	return names
 }
 
 // ListResources lists resources matching the given criteria
@@ -114,10 +203,55 @@ func (p *Provider) ListResources(ctx context.Context, gvk schema.GroupVersionKin
 			"apiVersion": gvk.GroupVersion().String(),
 			"kind":       gvk.Kind + "List",
 		},
-		Items: convertToUnstructuredSlice(resources),
+		Items: p.convertToUnstructuredSlice(resources),
 	}, nil
 }
 
+// ListGVKs returns all GroupVersionKinds present in the index
+func (p *Provider) ListGVKs() []schema.GroupVersionKind {
+	return p.index.ListGVKs()
+}
+
+// HasGroupKind reports whether resources of a group and kind were gathered, in any version
+func (p *Provider) HasGroupKind(group, kind string) bool {
+	for _, gvk := range p.index.ListGVKs() {
+		if gvk.Group == group && gvk.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// ListResourcesByGroupKind lists resources of a group and kind across all gathered versions
+func (p *Provider) ListResourcesByGroupKind(ctx context.Context, group, kind, namespace string, opts api.ListOptions) (*unstructured.UnstructuredList, error) {
+	result := &unstructured.UnstructuredList{
+		Object: map[string]interface{}{
+			"kind": kind + "List",
+		},
+		Items: make([]unstructured.Unstructured, 0),
+	}
+
+	for _, gvk := range p.index.ListGVKs() {
+		if gvk.Group != group || gvk.Kind != kind {
+			continue
+		}
+		list, err := p.ListResources(ctx, gvk, namespace, opts)
+		if err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, list.Items...)
+	}
+
+	sort.Slice(result.Items, func(i, j int) bool {
+		if result.Items[i].GetNamespace() != result.Items[j].GetNamespace() {
+			return result.Items[i].GetNamespace() < result.Items[j].GetNamespace()
+		}
+		return result.Items[i].GetName() < result.Items[j].GetName()
+	})
+
+	return result, nil
+}
+
 // ListNamespaces returns all namespaces
 func (p *Provider) ListNamespaces(ctx context.Context) ([]string, error) {
 	return p.index.ListNamespaces(), nil
@@ -125,17 +259,8 @@ func (p *Provider) ListNamespaces(ctx context.Context) ([]string, error) {
 
 // GetETCDHealth returns ETCD health information
 func (p *Provider) GetETCDHealth() (*api.ETCDHealth, error) {
-	// Find container directory
-	containerDir, err := findContainerDir(p.path)
-	if err != nil {
-		containerDir = p.path
-	}
-
-	etcdInfoDir := filepath.Join(containerDir, "etcd_info")
-
 	// Read endpoint_health.json
-	healthFile := filepath.Join(etcdInfoDir, "endpoint_health.json")
-	healthData, err := os.ReadFile(healthFile)
+	healthData, err := p.files.ReadFile(path.Join("etcd_info", "endpoint_health.json"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to read ETCD health data: %w", err)
 	}
@@ -212,8 +337,7 @@ func (p *Provider) GetETCDHealth() (*api.ETCDHealth, error) {
 	}
 
 	// Read alarm_list.json if it exists
-	alarmFile := filepath.Join(etcdInfoDir, "alarm_list.json")
-	if alarmData, err := os.ReadFile(alarmFile); err == nil {
+	if alarmData, err := p.files.ReadFile(path.Join("etcd_info", "alarm_list.json")); err == nil {
 		var alarms []struct {
 			Alarm string `json:"alarm"`
 		}
@@ -229,16 +353,7 @@ func (p *Provider) GetETCDHealth() (*api.ETCDHealth, error) {
 
 // GetETCDObjectCount returns ETCD object counts by resource type
 func (p *Provider) GetETCDObjectCount() (map[string]int64, error) {
-	// Find container directory
-	containerDir, err := findContainerDir(p.path)
-	if err != nil {
-		containerDir = p.path
-	}
-
-	etcdInfoDir := filepath.Join(containerDir, "etcd_info")
-	objectCountFile := filepath.Join(etcdInfoDir, "object_count.json")
-
-	data, err := os.ReadFile(objectCountFile)
+	data, err := p.files.ReadFile(path.Join("etcd_info", "object_count.json"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to read ETCD object count data: %w", err)
 	}
@@ -291,11 +406,26 @@ func (p *Provider) applyFieldSelector(resources []*unstructured.Unstructured, fi
 	return filtered, nil
 }
 
-// convertToUnstructuredSlice converts []*unstructured.Unstructured to []unstructured.Unstructured
-func convertToUnstructuredSlice(resources []*unstructured.Unstructured) []unstructured.Unstructured {
+// convertToUnstructuredSlice converts []*unstructured.Unstructured to []unstructured.Unstructured,
+// redacting sensitive values on the way out
+func (p *Provider) convertToUnstructuredSlice(resources []*unstructured.Unstructured) []unstructured.Unstructured {
 	result := make([]unstructured.Unstructured, len(resources))
 	for i, resource := range resources {
-		result[i] = *resource
+		result[i] = *p.redactor.RedactObject(resource)
 	}
 	return result
 }
+
+// RedactOutput masks any sensitive values left in tool output and appends
+// an audit note describing what was redacted
+func (p *Provider) RedactOutput(content string) string {
+	if !p.redactor.Enabled() {
+		return content
+	}
+
+	content = p.redactor.RedactText(content)
+	if note := FormatRedactionNote(RedactionSummary(content)); note != "" {
+		content += "\n\n" + note + "\n"
+	}
+	return content
+}
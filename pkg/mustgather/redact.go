@@ -0,0 +1,346 @@
+package mustgather
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// redactionMarkerFormat is the placeholder that replaces masked values.
+// The category is recovered from the marker to build the audit note.
+const redactionMarkerFormat = "<redacted:%s>"
+
+var (
+	redactionMarkerPattern = regexp.MustCompile(`<redacted:([a-z0-9-]+)>`)
+	invalidCategoryChars   = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+// defaultSensitiveKeyPattern matches env var and data key names that hold credentials
+const defaultSensitiveKeyPattern = `(?i)(password|passwd|secret|token|api[_-]?key|credential|private[_-]?key|access[_-]?key)`
+
+// nonSensitiveKeySuffix excludes keys that only reference where a credential lives
+var nonSensitiveKeySuffix = regexp.MustCompile(`(?i)(_file|_path|_dir|_name|_ref)$`)
+
+// RedactionConfig controls masking of sensitive values
+type RedactionConfig struct {
+	// Enabled turns redaction on (default: true)
+	Enabled bool `yaml:"enabled"`
+
+	// SensitiveKeys are additional regular expressions matched against env var
+	// names and data keys whose values must be masked
+	SensitiveKeys []string `yaml:"sensitiveKeys"`
+
+	// Patterns are additional regular expressions masked in any text
+	Patterns []RedactionPattern `yaml:"patterns"`
+}
+
+// RedactionPattern is a named regular expression whose matches are masked
+type RedactionPattern struct {
+	Name  string `yaml:"name"`
+	Regex string `yaml:"regex"`
+}
+
+// DefaultRedactionConfig returns the configuration used when none is given
+func DefaultRedactionConfig() RedactionConfig {
+	return RedactionConfig{Enabled: true}
+}
+
+// LoadRedactionConfig reads additional redaction rules from a YAML file
+func LoadRedactionConfig(path string) (RedactionConfig, error) {
+	config := DefaultRedactionConfig()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("failed to read redaction rules: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return config, fmt.Errorf("failed to parse redaction rules: %w", err)
+	}
+
+	return config, nil
+}
+
+// textRule masks matches of a regular expression in free text
+type textRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// Redactor masks secrets in resources and text
+type Redactor struct {
+	enabled      bool
+	sensitiveKey *regexp.Regexp
+	textRules    []textRule
+
+	// cache holds redacted copies of indexed resources, which never change
+	cache sync.Map
+}
+
+// NewRedactor compiles a redaction configuration
+func NewRedactor(config RedactionConfig) (*Redactor, error) {
+	r := &Redactor{enabled: config.Enabled}
+
+	keyPatterns := append([]string{defaultSensitiveKeyPattern}, config.SensitiveKeys...)
+	sensitiveKey, err := regexp.Compile(strings.Join(keyPatterns, "|"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sensitive key pattern: %w", err)
+	}
+	r.sensitiveKey = sensitiveKey
+
+	r.textRules = []textRule{
+		{
+			pattern:     regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+			replacement: marker("private-key"),
+		},
+		{
+			pattern:     regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]{8,}=*`),
+			replacement: "${1}" + marker("bearer-token"),
+		},
+		{
+			pattern:     regexp.MustCompile(`eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`),
+			replacement: marker("token"),
+		},
+		{
+			pattern:     regexp.MustCompile(`(?i)((?:password|passwd|client[_-]?secret|secret[_-]?access[_-]?key|api[_-]?key|auth[_-]?token|access[_-]?token)["']?\s*[:=]\s*["']?)([^\s"',;&{}\[\]<]+)`),
+			replacement: "${1}" + marker("password"),
+		},
+		{
+			pattern:     regexp.MustCompile(`(://[^/\s:@]+:)[^/\s@<]+@`),
+			replacement: "${1}" + marker("password") + "@",
+		},
+		{
+			pattern:     regexp.MustCompile(`("auth"\s*:\s*")[^"<]+(")`),
+			replacement: "${1}" + marker("pull-secret") + "${2}",
+		},
+	}
+
+	for _, p := range config.Patterns {
+		pattern, err := regexp.Compile(p.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p.Name, err)
+		}
+		// Names become marker categories, which are restricted to [a-z0-9-]
+		category := strings.Trim(invalidCategoryChars.ReplaceAllString(strings.ToLower(p.Name), "-"), "-")
+		if category == "" {
+			category = "custom"
+		}
+		r.textRules = append(r.textRules, textRule{
+			pattern:     pattern,
+			replacement: marker(category),
+		})
+	}
+
+	return r, nil
+}
+
+// Enabled reports whether redaction is active
+func (r *Redactor) Enabled() bool {
+	return r != nil && r.enabled
+}
+
+// RedactText masks sensitive values in free text such as logs
+func (r *Redactor) RedactText(content string) string {
+	if !r.Enabled() || content == "" {
+		return content
+	}
+
+	for _, rule := range r.textRules {
+		content = rule.pattern.ReplaceAllString(content, rule.replacement)
+	}
+	return content
+}
+
+// RedactObject returns the resource with sensitive values masked. The original
+// is never modified; a copy is made only when something needs masking.
+func (r *Redactor) RedactObject(obj *unstructured.Unstructured) *unstructured.Unstructured {
+	if !r.Enabled() || obj == nil {
+		return obj
+	}
+
+	if cached, ok := r.cache.Load(obj); ok {
+		return cached.(*unstructured.Unstructured)
+	}
+
+	redacted := obj
+	if newObject, changed := r.redactValue(obj.Object, obj.GetKind() == "Secret", 0); changed {
+		redacted = &unstructured.Unstructured{Object: newObject.(map[string]interface{})}
+	}
+
+	r.cache.Store(obj, redacted)
+	return redacted
+}
+
+// redactValue walks a decoded object. Maps and slices are rebuilt only when a
+// nested value changed so unchanged subtrees stay shared with the index.
+func (r *Redactor) redactValue(value interface{}, isSecret bool, depth int) (interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		var result map[string]interface{}
+		for key, child := range v {
+			newChild, changed := r.redactField(v, key, child, isSecret, depth)
+			if !changed {
+				continue
+			}
+			if result == nil {
+				result = make(map[string]interface{}, len(v))
+				for k, c := range v {
+					result[k] = c
+				}
+			}
+			result[key] = newChild
+		}
+		if result == nil {
+			return v, false
+		}
+		return result, true
+
+	case []interface{}:
+		var result []interface{}
+		for i, child := range v {
+			newChild, changed := r.redactValue(child, isSecret, depth)
+			if !changed {
+				continue
+			}
+			if result == nil {
+				result = make([]interface{}, len(v))
+				copy(result, v)
+			}
+			result[i] = newChild
+		}
+		if result == nil {
+			return v, false
+		}
+		return result, true
+
+	case string:
+		redacted := r.RedactText(v)
+		return redacted, redacted != v
+	}
+
+	return value, false
+}
+
+// redactField applies structural rules for a single map entry before falling back to text rules
+func (r *Redactor) redactField(parent map[string]interface{}, key string, value interface{}, isSecret bool, depth int) (interface{}, bool) {
+	// Secret data: mask every value except public certificates
+	if isSecret && depth == 0 && (key == "data" || key == "stringData") {
+		if data, ok := value.(map[string]interface{}); ok {
+			return r.redactSecretData(data, key == "data")
+		}
+	}
+
+	// last-applied-configuration of a Secret repeats its data verbatim
+	if isSecret && key == "kubectl.kubernetes.io/last-applied-configuration" {
+		if s, ok := value.(string); ok && s != "" {
+			return marker("secret-data"), true
+		}
+	}
+
+	// Env vars: {name: PASSWORD, value: ...}
+	if key == "value" {
+		if name, ok := parent["name"].(string); ok && r.isSensitiveKey(name) {
+			if s, ok := value.(string); ok && s != "" && !redactionMarkerPattern.MatchString(s) {
+				return marker("env-value"), true
+			}
+		}
+	}
+
+	return r.redactValue(value, isSecret, depth+1)
+}
+
+// redactSecretData masks Secret values, keeping PEM certificates which are public
+func (r *Redactor) redactSecretData(data map[string]interface{}, base64Encoded bool) (interface{}, bool) {
+	result := make(map[string]interface{}, len(data))
+	changed := false
+
+	for key, value := range data {
+		s, ok := value.(string)
+		if !ok || s == "" || redactionMarkerPattern.MatchString(s) {
+			result[key] = value
+			continue
+		}
+
+		plain := s
+		if base64Encoded {
+			if decoded, err := base64.StdEncoding.DecodeString(s); err == nil {
+				plain = string(decoded)
+			}
+		}
+		if isPublicCertificate(plain) {
+			result[key] = value
+			continue
+		}
+
+		category := "secret-data"
+		switch {
+		case key == ".dockerconfigjson" || key == ".dockercfg":
+			category = "pull-secret"
+		case strings.Contains(plain, "PRIVATE KEY-----"):
+			category = "private-key"
+		case key == "token":
+			category = "token"
+		}
+		result[key] = marker(category)
+		changed = true
+	}
+
+	return result, changed
+}
+
+func (r *Redactor) isSensitiveKey(name string) bool {
+	return r.sensitiveKey.MatchString(name) && !nonSensitiveKeySuffix.MatchString(name)
+}
+
+// isPublicCertificate reports whether the value only holds PEM certificates
+func isPublicCertificate(value string) bool {
+	return strings.Contains(value, "-----BEGIN CERTIFICATE-----") && !strings.Contains(value, "PRIVATE KEY")
+}
+
+func marker(category string) string {
+	return fmt.Sprintf(redactionMarkerFormat, category)
+}
+
+// IsRedacted reports whether a value was masked, so analysis that needs the
+// original can say it was skipped instead of reporting a bogus result
+func IsRedacted(value string) bool {
+	return redactionMarkerPattern.MatchString(value)
+}
+
+// RedactionSummary counts redaction markers by category in output text
+func RedactionSummary(content string) map[string]int {
+	summary := make(map[string]int)
+	for _, match := range redactionMarkerPattern.FindAllStringSubmatch(content, -1) {
+		summary[match[1]]++
+	}
+	return summary
+}
+
+// FormatRedactionNote renders the audit note appended to tool results
+func FormatRedactionNote(summary map[string]int) string {
+	if len(summary) == 0 {
+		return ""
+	}
+
+	categories := make([]string, 0, len(summary))
+	total := 0
+	for category, count := range summary {
+		categories = append(categories, category)
+		total += count
+	}
+	sort.Strings(categories)
+
+	parts := make([]string, 0, len(categories))
+	for _, category := range categories {
+		parts = append(parts, fmt.Sprintf("%s: %d", category, summary[category]))
+	}
+
+	return fmt.Sprintf("[Redaction] %d sensitive value(s) masked (%s). Start the server with --redact=false to disable.",
+		total, strings.Join(parts, ", "))
+}
@@ -0,0 +1,460 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+)
+
+const (
+	// maxAuditLineSize bounds a single event; larger lines (huge request bodies) are
+	// discarded up to the next newline and reading continues with the following event
+	maxAuditLineSize = 16 * 1024 * 1024
+	// maxDistinctKeys bounds counter maps; further keys are counted as "(other)"
+	maxDistinctKeys = 50000
+)
+
+// auditEvent holds the fields of an audit.k8s.io/v1 Event the tools use.
+// Request and response bodies are deliberately not decoded.
+type auditEvent struct {
+	AuditID    string `json:"auditID"`
+	Stage      string `json:"stage"`
+	Verb       string `json:"verb"`
+	RequestURI string `json:"requestURI"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	ImpersonatedUser *struct {
+		Username string `json:"username"`
+	} `json:"impersonatedUser"`
+	SourceIPs []string `json:"sourceIPs"`
+	UserAgent string   `json:"userAgent"`
+	ObjectRef *struct {
+		Resource    string `json:"resource"`
+		Namespace   string `json:"namespace"`
+		Name        string `json:"name"`
+		APIGroup    string `json:"apiGroup"`
+		Subresource string `json:"subresource"`
+	} `json:"objectRef"`
+	ResponseStatus *struct {
+		Code    int    `json:"code"`
+		Reason  string `json:"reason"`
+		Message string `json:"message"`
+	} `json:"responseStatus"`
+	RequestReceivedTimestamp time.Time `json:"requestReceivedTimestamp"`
+	StageTimestamp           time.Time `json:"stageTimestamp"`
+}
+
+// resource returns group/resource[/subresource]
+func (e *auditEvent) resource() string {
+	if e.ObjectRef == nil || e.ObjectRef.Resource == "" {
+		return "(non-resource)"
+	}
+	r := e.ObjectRef.Resource
+	if e.ObjectRef.APIGroup != "" {
+		r = e.ObjectRef.APIGroup + "/" + r
+	}
+	if e.ObjectRef.Subresource != "" {
+		r += "/" + e.ObjectRef.Subresource
+	}
+	return r
+}
+
+func (e *auditEvent) namespace() string {
+	if e.ObjectRef == nil {
+		return ""
+	}
+	return e.ObjectRef.Namespace
+}
+
+func (e *auditEvent) code() int {
+	if e.ResponseStatus == nil {
+		return 0
+	}
+	return e.ResponseStatus.Code
+}
+
+func (e *auditEvent) latency() time.Duration {
+	if e.RequestReceivedTimestamp.IsZero() || e.StageTimestamp.IsZero() {
+		return 0
+	}
+	return e.StageTimestamp.Sub(e.RequestReceivedTimestamp)
+}
+
+func (e *auditEvent) summary() string {
+	target := e.RequestURI
+	if e.ObjectRef != nil && e.ObjectRef.Name != "" {
+		target = e.resource() + " "
+		if e.ObjectRef.Namespace != "" {
+			target += e.ObjectRef.Namespace + "/"
+		}
+		target += e.ObjectRef.Name
+	}
+	return fmt.Sprintf("%s %s %s by %s -> %d", e.StageTimestamp.UTC().Format(time.RFC3339), e.Verb, target, e.User.Username, e.code())
+}
+
+// auditLogFile is an audit log collected by gather_audit_logs
+type auditLogFile struct {
+	APIServer string // kube-apiserver, openshift-apiserver, oauth-apiserver, oauth-server
+	Node      string
+	Path      string
+	Size      int64
+}
+
+// listAuditLogFiles finds audit logs under audit_logs/<apiserver>/
+func listAuditLogFiles(params api.ToolHandlerParams) ([]auditLogFile, error) {
+	fsys := params.MustGatherProvider.Files()
+
+	auditDir := "audit_logs"
+	if _, err := fsys.Stat(auditDir); err != nil {
+		return nil, fmt.Errorf("audit logs not found in must-gather (audit_logs/ is collected by gather_audit_logs)")
+	}
+
+	files := make([]auditLogFile, 0)
+	err := fs.WalkDir(fsys, auditDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		name := d.Name()
+		if !strings.HasSuffix(name, ".log") && !strings.HasSuffix(name, ".log.gz") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		apiServer := path.Base(path.Dir(filePath))
+		if path.Dir(filePath) == auditDir {
+			apiServer = "unknown"
+		}
+
+		files = append(files, auditLogFile{
+			APIServer: apiServer,
+			Node:      nodeFromAuditFileName(name),
+			Path:      filePath,
+			Size:      info.Size(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit_logs directory: %w", err)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].APIServer != files[j].APIServer {
+			return files[i].APIServer < files[j].APIServer
+		}
+		return files[i].Path < files[j].Path
+	})
+
+	return files, nil
+}
+
+// nodeFromAuditFileName extracts the node from names like
+// master-0-audit-2025-01-10T10-00-00.000.log.gz or master-0-audit.log.gz
+func nodeFromAuditFileName(name string) string {
+	for _, marker := range []string{"-audit", "-termination"} {
+		if i := strings.Index(name, marker); i > 0 {
+			return name[:i]
+		}
+	}
+	return ""
+}
+
+// auditFilter selects the files and events to scan
+type auditFilter struct {
+	APIServer string
+	Node      string
+	Namespace string
+	User      string
+	Verb      string
+	Since     time.Time
+	Until     time.Time
+	// Contains is a cheap substring prefilter applied before JSON decoding
+	Contains string
+}
+
+func parseAuditFilter(params api.ToolHandlerParams) (auditFilter, error) {
+	filter := auditFilter{
+		APIServer: params.GetString("apiserver", ""),
+		Node:      params.GetString("node", ""),
+		Namespace: params.GetString("namespace", ""),
+		User:      params.GetString("user", ""),
+		Verb:      params.GetString("verb", ""),
+	}
+
+	for _, p := range []struct {
+		key    string
+		target *time.Time
+	}{{"since", &filter.Since}, {"until", &filter.Until}} {
+		value := params.GetString(p.key, "")
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("invalid %s time %q, expected RFC3339 (e.g. 2025-01-10T10:00:00Z): %w", p.key, value, err)
+		}
+		*p.target = t.UTC()
+	}
+
+	return filter, nil
+}
+
+func (f auditFilter) matchFile(file auditLogFile) bool {
+	if f.APIServer != "" && file.APIServer != f.APIServer {
+		return false
+	}
+	if f.Node != "" && file.Node != f.Node {
+		return false
+	}
+	return true
+}
+
+func (f auditFilter) matchEvent(e *auditEvent) bool {
+	if f.Namespace != "" && e.namespace() != f.Namespace {
+		return false
+	}
+	if f.User != "" && e.User.Username != f.User {
+		return false
+	}
+	if f.Verb != "" && e.Verb != f.Verb {
+		return false
+	}
+	if !f.Since.IsZero() && e.StageTimestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && e.StageTimestamp.After(f.Until) {
+		return false
+	}
+	return true
+}
+
+func (f auditFilter) String() string {
+	parts := make([]string, 0)
+	for _, p := range []struct{ key, value string }{
+		{"apiserver", f.APIServer}, {"node", f.Node}, {"namespace", f.Namespace}, {"user", f.User}, {"verb", f.Verb},
+	} {
+		if p.value != "" {
+			parts = append(parts, fmt.Sprintf("%s=%s", p.key, p.value))
+		}
+	}
+	if !f.Since.IsZero() {
+		parts = append(parts, "since="+f.Since.Format(time.RFC3339))
+	}
+	if !f.Until.IsZero() {
+		parts = append(parts, "until="+f.Until.Format(time.RFC3339))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// scanStats reports how much data a scan read
+type scanStats struct {
+	Files     int
+	Bytes     int64
+	Lines     int
+	Events    int // completed events passing the filter
+	Malformed int
+	Oversized int
+	// ReadErrors lists files that could not be read to the end, e.g. truncated .gz files
+	ReadErrors []string
+}
+
+func (s scanStats) String() string {
+	out := fmt.Sprintf("Scanned %d files (%s), %d lines, %d matching requests", s.Files, formatBytes(s.Bytes), s.Lines, s.Events)
+	if s.Malformed > 0 {
+		out += fmt.Sprintf(", %d malformed lines skipped", s.Malformed)
+	}
+	if s.Oversized > 0 {
+		out += fmt.Sprintf(", %d oversized lines skipped", s.Oversized)
+	}
+	if len(s.ReadErrors) > 0 {
+		out += fmt.Sprintf("\n⚠ %d files could not be read to the end, later events in them are missing:", len(s.ReadErrors))
+		for _, readErr := range s.ReadErrors {
+			out += "\n  - " + readErr
+		}
+	}
+	return out
+}
+
+// scanAuditLogs streams every completed request matching the filter to fn.
+// Files are read line by line so memory stays bounded regardless of log size.
+// Only ResponseComplete and Panic stages are passed so each request counts once.
+func scanAuditLogs(params api.ToolHandlerParams, filter auditFilter, fn func(file auditLogFile, e *auditEvent)) (scanStats, error) {
+	var stats scanStats
+
+	files, err := listAuditLogFiles(params)
+	if err != nil {
+		return stats, err
+	}
+
+	var contains []byte
+	if filter.Contains != "" {
+		contains = []byte(filter.Contains)
+	}
+
+	for _, file := range files {
+		if !filter.matchFile(file) {
+			continue
+		}
+		stats.Files++
+		stats.Bytes += file.Size
+
+		oversized, err := streamLines(params.MustGatherProvider.Files(), file.Path, func(line []byte) {
+			stats.Lines++
+			if contains != nil && !bytes.Contains(line, contains) {
+				return
+			}
+
+			var event auditEvent
+			if err := json.Unmarshal(line, &event); err != nil {
+				stats.Malformed++
+				return
+			}
+			if event.Stage != "ResponseComplete" && event.Stage != "Panic" {
+				return
+			}
+			if !filter.matchEvent(&event) {
+				return
+			}
+
+			stats.Events++
+			fn(file, &event)
+		})
+		stats.Oversized += oversized
+		if err != nil {
+			// Keep what was read and go on with the other files
+			stats.ReadErrors = append(stats.ReadErrors, fmt.Sprintf("%s: %v", file.Path, err))
+		}
+	}
+
+	if stats.Files == 0 {
+		return stats, fmt.Errorf("no audit log files match %s", filter.String())
+	}
+
+	return stats, nil
+}
+
+// streamLines calls fn for each non-empty line of a plain or gzipped file and
+// returns the number of lines skipped for exceeding maxAuditLineSize
+func streamLines(fsys api.GatherFS, name string, fn func(line []byte)) (int, error) {
+	file, err := fsys.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReaderSize(file, 64*1024)
+	oversized := 0
+	skipping := false
+	var long []byte
+	for {
+		chunk, err := reader.ReadSlice('\n')
+		if err == bufio.ErrBufferFull {
+			// The line continues past the buffer; collect it unless it is too large
+			if !skipping {
+				if len(long)+len(chunk) > maxAuditLineSize {
+					oversized++
+					skipping = true
+					long = long[:0]
+				} else {
+					long = append(long, chunk...)
+				}
+			}
+			continue
+		}
+		if err != nil && err != io.EOF {
+			return oversized, err
+		}
+
+		line := chunk
+		if len(long) > 0 {
+			long = append(long, chunk...)
+			line = long
+		}
+		if !skipping {
+			line = bytes.TrimRight(line, "\r\n")
+			if len(line) > 0 {
+				fn(line)
+			}
+		}
+		skipping = false
+		long = long[:0]
+
+		if err == io.EOF {
+			return oversized, nil
+		}
+	}
+}
+
+// boundedCounter counts keys, folding new keys into "(other)" once full
+type boundedCounter struct {
+	counts map[string]int
+}
+
+func newBoundedCounter() *boundedCounter {
+	return &boundedCounter{counts: make(map[string]int)}
+}
+
+func (c *boundedCounter) add(key string) {
+	if _, ok := c.counts[key]; !ok && len(c.counts) >= maxDistinctKeys {
+		key = "(other)"
+	}
+	c.counts[key]++
+}
+
+type keyCount struct {
+	Key   string
+	Count int
+}
+
+func (c *boundedCounter) top(limit int) []keyCount {
+	result := make([]keyCount, 0, len(c.counts))
+	for k, v := range c.counts {
+		result = append(result, keyCount{k, v})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Key < result[j].Key
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+func (c *boundedCounter) len() int {
+	return len(c.counts)
+}
+
+func formatBytes(size int64) string {
+	switch {
+	case size >= 1024*1024*1024:
+		return fmt.Sprintf("%.1f GB", float64(size)/(1024*1024*1024))
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
@@ -0,0 +1,183 @@
+package audit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+)
+
+// maxObjectEvents bounds how many matching events are kept for one object
+const maxObjectEvents = 10000
+
+var mutatingVerbs = map[string]bool{
+	"create": true, "update": true, "patch": true, "delete": true, "deletecollection": true,
+}
+
+func objectTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "audit_object_history",
+				Description: "Show who created, modified or deleted a specific object according to the audit logs, in time order",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: withFilterProperties(map[string]*jsonschema.Schema{
+						"resource": {
+							Type:        "string",
+							Description: "Resource (plural, e.g. deployments, configmaps, nodes)",
+						},
+						"name": {
+							Type:        "string",
+							Description: "Object name",
+						},
+						"includeReads": {
+							Type:        "boolean",
+							Description: "Also include get/list/watch requests (default: false)",
+						},
+						"limit": {
+							Type:        "integer",
+							Description: "Number of most recent events to show (default: 100)",
+						},
+					}),
+					Required: []string{"resource", "name"},
+				},
+			},
+			Handler: auditObjectHistory,
+		},
+	}
+}
+
+// objectEvent is one request against the object
+type objectEvent struct {
+	Time      time.Time
+	Verb      string
+	User      string
+	Actor     string
+	Code      int
+	UserAgent string
+	Source    string
+	AuditID   string
+}
+
+func auditObjectHistory(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	resource := params.GetString("resource", "")
+	name := params.GetString("name", "")
+	includeReads := params.GetBool("includeReads", false)
+	limit := params.GetInt("limit", 100)
+
+	if resource == "" || name == "" {
+		return api.NewToolCallResult("", fmt.Errorf("resource and name are required")), nil
+	}
+
+	filter, err := parseAuditFilter(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	// The object name must appear in the line, which skips decoding most events
+	filter.Contains = `"` + name + `"`
+
+	events := make([]objectEvent, 0)
+	dropped := 0
+	stats, err := scanAuditLogs(params, filter, func(file auditLogFile, e *auditEvent) {
+		if e.ObjectRef == nil || e.ObjectRef.Name != name {
+			return
+		}
+		if e.ObjectRef.Resource != resource && e.resource() != resource {
+			return
+		}
+		if !includeReads && !mutatingVerbs[e.Verb] {
+			return
+		}
+
+		event := objectEvent{
+			Time:      e.StageTimestamp,
+			Verb:      e.Verb,
+			User:      e.User.Username,
+			Code:      e.code(),
+			UserAgent: e.UserAgent,
+			Source:    file.APIServer,
+			AuditID:   e.AuditID,
+		}
+		if e.ObjectRef.Subresource != "" {
+			event.Verb += " " + e.ObjectRef.Subresource
+		}
+		if e.ImpersonatedUser != nil {
+			event.Actor = e.ImpersonatedUser.Username
+		}
+
+		if len(events) >= maxObjectEvents {
+			// Keep the newest events
+			sort.Slice(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+			events = events[maxObjectEvents/2:]
+			dropped += maxObjectEvents / 2
+		}
+		events = append(events, event)
+	})
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	sort.Slice(events, func(i, j int) bool { return events[i].Time.Before(events[j].Time) })
+
+	target := resource + " " + name
+	if filter.Namespace != "" {
+		target = fmt.Sprintf("%s %s/%s", resource, filter.Namespace, name)
+	}
+
+	output := fmt.Sprintf("Audit History: %s\n", target)
+	output += strings.Repeat("=", 80) + "\n"
+	if f := filter.String(); f != "" {
+		output += fmt.Sprintf("Filter: %s\n", f)
+	}
+	output += stats.String() + "\n\n"
+
+	if len(events) == 0 {
+		kind := "modifications"
+		if includeReads {
+			kind = "requests"
+		}
+		output += fmt.Sprintf("No %s of %s found in the audit logs\n", kind, target)
+		return api.NewToolCallResult(output, nil), nil
+	}
+
+	// Summarize who touched the object before listing events
+	byUser := newBoundedCounter()
+	for _, event := range events {
+		byUser.add(fmt.Sprintf("%s %s", event.User, event.Verb))
+	}
+	output += "By user and verb:\n"
+	for _, kc := range byUser.top(10) {
+		output += fmt.Sprintf("  %6d  %s\n", kc.Count, kc.Key)
+	}
+
+	shown := events
+	if limit > 0 && len(shown) > limit {
+		shown = shown[len(shown)-limit:]
+	}
+
+	output += fmt.Sprintf("\nEvents (showing %d of %d", len(shown), len(events)+dropped)
+	if dropped > 0 {
+		output += fmt.Sprintf(", %d oldest dropped", dropped)
+	}
+	output += "):\n"
+	for _, event := range shown {
+		status := "✓"
+		if event.Code >= 400 {
+			status = "✗"
+		}
+		line := fmt.Sprintf("  %s %s %-8s %s -> %d", status, event.Time.UTC().Format(time.RFC3339), event.Verb, event.User, event.Code)
+		if event.Actor != "" {
+			line += fmt.Sprintf(" (as %s)", event.Actor)
+		}
+		output += line + "\n"
+		if event.UserAgent != "" {
+			output += fmt.Sprintf("      agent: %s [%s] %s\n", truncate(event.UserAgent, 100), event.Source, event.AuditID)
+		}
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
@@ -0,0 +1,451 @@
+package audit
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+)
+
+// longRunningSubresources are streaming requests whose latency is not meaningful
+var longRunningSubresources = map[string]bool{
+	"exec": true, "attach": true, "portforward": true, "log": true, "proxy": true,
+}
+
+// filterProperties are the event filters shared by all scanning tools
+func filterProperties() map[string]*jsonschema.Schema {
+	return map[string]*jsonschema.Schema{
+		"apiserver": {
+			Type:        "string",
+			Description: "Filter by API server (kube-apiserver, openshift-apiserver, oauth-apiserver, oauth-server) (optional)",
+		},
+		"node": {
+			Type:        "string",
+			Description: "Filter by control plane node the log came from (optional)",
+		},
+		"namespace": {
+			Type:        "string",
+			Description: "Filter by object namespace (optional)",
+		},
+		"user": {
+			Type:        "string",
+			Description: "Filter by username, e.g. system:serviceaccount:ns:name (optional)",
+		},
+		"verb": {
+			Type:        "string",
+			Description: "Filter by verb (get, list, watch, create, update, patch, delete) (optional)",
+		},
+		"since": {
+			Type:        "string",
+			Description: "Only include requests completed at or after this RFC3339 time (optional)",
+		},
+		"until": {
+			Type:        "string",
+			Description: "Only include requests completed at or before this RFC3339 time (optional)",
+		},
+	}
+}
+
+func withFilterProperties(extra map[string]*jsonschema.Schema) map[string]*jsonschema.Schema {
+	properties := filterProperties()
+	for k, v := range extra {
+		properties[k] = v
+	}
+	return properties
+}
+
+func summaryTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "audit_logs_list",
+				Description: "List collected API server audit log files (audit_logs/) per API server and node with sizes",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+				},
+			},
+			Handler: auditLogsList,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "audit_top_requests",
+				Description: "Top users/service accounts, verbs, resources, namespaces or user agents by request count from audit logs (streamed, bounded memory)",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: withFilterProperties(map[string]*jsonschema.Schema{
+						"groupBy": {
+							Type:        "string",
+							Description: "Group by: user, verb, resource, verb-resource, namespace, userAgent, sourceIP (default: user)",
+						},
+						"limit": {
+							Type:        "integer",
+							Description: "Number of entries to show (0 for all, default: 20)",
+						},
+					}),
+				},
+			},
+			Handler: auditTopRequests,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "audit_errors",
+				Description: "Summarize 4xx/5xx responses in audit logs by status code, user and resource with sample messages",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: withFilterProperties(map[string]*jsonschema.Schema{
+						"minCode": {
+							Type:        "integer",
+							Description: "Minimum HTTP status code to include (default: 400, use 500 for server errors only)",
+						},
+						"limit": {
+							Type:        "integer",
+							Description: "Number of user/resource combinations per status code (0 for all, default: 10)",
+						},
+					}),
+				},
+			},
+			Handler: auditErrors,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "audit_slow_requests",
+				Description: "Find the slowest API requests in audit logs (excluding watches and streaming subresources) above a latency threshold",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: withFilterProperties(map[string]*jsonschema.Schema{
+						"thresholdMs": {
+							Type:        "integer",
+							Description: "Minimum latency in milliseconds (default: 1000)",
+						},
+						"limit": {
+							Type:        "integer",
+							Description: "Number of slowest requests to show (default: 25)",
+						},
+					}),
+				},
+			},
+			Handler: auditSlowRequests,
+		},
+	}
+}
+
+func auditLogsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	files, err := listAuditLogFiles(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if len(files) == 0 {
+		return api.NewToolCallResult("No audit log files found in audit_logs/", nil), nil
+	}
+
+	output := "Audit Log Files\n"
+	output += strings.Repeat("=", 80) + "\n"
+
+	var total int64
+	current := ""
+	for _, file := range files {
+		if file.APIServer != current {
+			current = file.APIServer
+			output += fmt.Sprintf("\n%s\n", current)
+			output += strings.Repeat("-", 80) + "\n"
+		}
+		node := file.Node
+		if node == "" {
+			node = "-"
+		}
+		output += fmt.Sprintf("  %-30s %10s  %s\n", node, formatBytes(file.Size), file.Path[strings.LastIndex(file.Path, "/")+1:])
+		total += file.Size
+	}
+
+	output += fmt.Sprintf("\nTotal: %d files, %s\n", len(files), formatBytes(total))
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+func auditTopRequests(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	groupBy := params.GetString("groupBy", "user")
+	limit := params.GetInt("limit", 20)
+
+	keyFunc, ok := map[string]func(e *auditEvent) string{
+		"user":          func(e *auditEvent) string { return e.User.Username },
+		"verb":          func(e *auditEvent) string { return e.Verb },
+		"resource":      func(e *auditEvent) string { return e.resource() },
+		"verb-resource": func(e *auditEvent) string { return e.Verb + " " + e.resource() },
+		"namespace": func(e *auditEvent) string {
+			if ns := e.namespace(); ns != "" {
+				return ns
+			}
+			return "(cluster-scoped)"
+		},
+		"userAgent": func(e *auditEvent) string { return truncate(e.UserAgent, 120) },
+		"sourceIP": func(e *auditEvent) string {
+			if len(e.SourceIPs) == 0 {
+				return ""
+			}
+			return e.SourceIPs[0]
+		},
+	}[groupBy]
+	if !ok {
+		return api.NewToolCallResult("", fmt.Errorf("invalid groupBy %q (user, verb, resource, verb-resource, namespace, userAgent, sourceIP)", groupBy)), nil
+	}
+
+	filter, err := parseAuditFilter(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	counter := newBoundedCounter()
+	var first, last time.Time
+	stats, err := scanAuditLogs(params, filter, func(_ auditLogFile, e *auditEvent) {
+		counter.add(keyFunc(e))
+		if first.IsZero() || e.StageTimestamp.Before(first) {
+			first = e.StageTimestamp
+		}
+		if e.StageTimestamp.After(last) {
+			last = e.StageTimestamp
+		}
+	})
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	output := fmt.Sprintf("Top Requests by %s\n", groupBy)
+	output += strings.Repeat("=", 80) + "\n"
+	if f := filter.String(); f != "" {
+		output += fmt.Sprintf("Filter: %s\n", f)
+	}
+	output += stats.String() + "\n"
+	if !first.IsZero() {
+		output += fmt.Sprintf("Time Range: %s -> %s\n", first.UTC().Format(time.RFC3339), last.UTC().Format(time.RFC3339))
+	}
+	output += "\n"
+
+	if stats.Events == 0 {
+		output += "No matching requests\n"
+		return api.NewToolCallResult(output, nil), nil
+	}
+
+	output += fmt.Sprintf("%-10s %-7s %s\n", "COUNT", "SHARE", strings.ToUpper(groupBy))
+	for _, kc := range counter.top(limit) {
+		output += fmt.Sprintf("%-10d %5.1f%%  %s\n", kc.Count, float64(kc.Count)*100/float64(stats.Events), kc.Key)
+	}
+	if limit > 0 && counter.len() > limit {
+		output += fmt.Sprintf("... and %d more\n", counter.len()-limit)
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// errorGroup aggregates failed requests for one status code
+type errorGroup struct {
+	Code    int
+	Count   int
+	Callers *boundedCounter
+	Samples map[string]string // caller -> sample message
+}
+
+func auditErrors(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	minCode := params.GetInt("minCode", 400)
+	limit := params.GetInt("limit", 10)
+
+	filter, err := parseAuditFilter(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	groups := make(map[int]*errorGroup)
+	stats, err := scanAuditLogs(params, filter, func(_ auditLogFile, e *auditEvent) {
+		code := e.code()
+		if code < minCode {
+			return
+		}
+
+		group, ok := groups[code]
+		if !ok {
+			group = &errorGroup{Code: code, Callers: newBoundedCounter(), Samples: make(map[string]string)}
+			groups[code] = group
+		}
+		group.Count++
+
+		caller := fmt.Sprintf("%s %s by %s", e.Verb, e.resource(), e.User.Username)
+		group.Callers.add(caller)
+		if _, ok := group.Samples[caller]; !ok && len(group.Samples) < maxDistinctKeys && e.ResponseStatus != nil {
+			sample := e.ResponseStatus.Message
+			if sample == "" {
+				sample = e.ResponseStatus.Reason
+			}
+			group.Samples[caller] = sample
+		}
+	})
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	output := fmt.Sprintf("Audit Errors (status >= %d)\n", minCode)
+	output += strings.Repeat("=", 80) + "\n"
+	if f := filter.String(); f != "" {
+		output += fmt.Sprintf("Filter: %s\n", f)
+	}
+	output += stats.String() + "\n\n"
+
+	if len(groups) == 0 {
+		output += fmt.Sprintf("✓ No responses with status >= %d\n", minCode)
+		return api.NewToolCallResult(output, nil), nil
+	}
+
+	codes := make([]int, 0, len(groups))
+	for code := range groups {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return groups[codes[i]].Count > groups[codes[j]].Count })
+
+	for _, code := range codes {
+		group := groups[code]
+		output += fmt.Sprintf("HTTP %d %s - %d request(s)\n", code, statusText(code), group.Count)
+		output += strings.Repeat("-", 80) + "\n"
+		for _, kc := range group.Callers.top(limit) {
+			output += fmt.Sprintf("  %6d  %s\n", kc.Count, kc.Key)
+			if sample := group.Samples[kc.Key]; sample != "" {
+				output += fmt.Sprintf("          %s\n", truncate(sample, 200))
+			}
+		}
+		if limit > 0 && group.Callers.len() > limit {
+			output += fmt.Sprintf("  ... and %d more\n", group.Callers.len()-limit)
+		}
+		output += "\n"
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+func statusText(code int) string {
+	switch code {
+	case 400:
+		return "Bad Request"
+	case 401:
+		return "Unauthorized"
+	case 403:
+		return "Forbidden"
+	case 404:
+		return "Not Found"
+	case 409:
+		return "Conflict"
+	case 410:
+		return "Gone"
+	case 422:
+		return "Unprocessable Entity"
+	case 429:
+		return "Too Many Requests"
+	case 500:
+		return "Internal Server Error"
+	case 503:
+		return "Service Unavailable"
+	case 504:
+		return "Gateway Timeout"
+	default:
+		return ""
+	}
+}
+
+// slowRequest is a request with its measured latency
+type slowRequest struct {
+	Latency time.Duration
+	Source  string
+	Summary string
+}
+
+// slowRequestHeap is a min-heap so the fastest of the kept requests is evicted first
+type slowRequestHeap []slowRequest
+
+func (h slowRequestHeap) Len() int            { return len(h) }
+func (h slowRequestHeap) Less(i, j int) bool  { return h[i].Latency < h[j].Latency }
+func (h slowRequestHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *slowRequestHeap) Push(x interface{}) { *h = append(*h, x.(slowRequest)) }
+func (h *slowRequestHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	*h = old[:n-1]
+	return item
+}
+
+func auditSlowRequests(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	threshold := time.Duration(params.GetInt("thresholdMs", 1000)) * time.Millisecond
+	limit := params.GetInt("limit", 25)
+	if limit <= 0 {
+		limit = 25
+	}
+
+	filter, err := parseAuditFilter(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	slowest := &slowRequestHeap{}
+	aboveThreshold := 0
+	byVerbResource := newBoundedCounter()
+	stats, err := scanAuditLogs(params, filter, func(file auditLogFile, e *auditEvent) {
+		if e.Verb == "watch" || strings.Contains(e.RequestURI, "watch=true") {
+			return
+		}
+		if e.ObjectRef != nil && longRunningSubresources[e.ObjectRef.Subresource] {
+			return
+		}
+
+		latency := e.latency()
+		if latency < threshold {
+			return
+		}
+		aboveThreshold++
+		byVerbResource.add(e.Verb + " " + e.resource())
+
+		if slowest.Len() >= limit && (*slowest)[0].Latency >= latency {
+			return
+		}
+		heap.Push(slowest, slowRequest{
+			Latency: latency,
+			Source:  file.APIServer,
+			Summary: e.summary(),
+		})
+		if slowest.Len() > limit {
+			heap.Pop(slowest)
+		}
+	})
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	output := fmt.Sprintf("Slow Requests (>= %s)\n", threshold)
+	output += strings.Repeat("=", 80) + "\n"
+	if f := filter.String(); f != "" {
+		output += fmt.Sprintf("Filter: %s\n", f)
+	}
+	output += stats.String() + "\n\n"
+
+	if aboveThreshold == 0 {
+		output += fmt.Sprintf("✓ No non-watch requests took %s or longer\n", threshold)
+		return api.NewToolCallResult(output, nil), nil
+	}
+
+	output += fmt.Sprintf("%d request(s) at or above threshold\n\n", aboveThreshold)
+
+	output += "By verb and resource:\n"
+	for _, kc := range byVerbResource.top(10) {
+		output += fmt.Sprintf("  %6d  %s\n", kc.Count, kc.Key)
+	}
+
+	requests := make([]slowRequest, slowest.Len())
+	copy(requests, *slowest)
+	sort.Slice(requests, func(i, j int) bool { return requests[i].Latency > requests[j].Latency })
+
+	output += fmt.Sprintf("\nSlowest %d:\n", len(requests))
+	for _, r := range requests {
+		output += fmt.Sprintf("  %8s  [%s] %s\n", r.Latency.Round(time.Millisecond), r.Source, r.Summary)
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
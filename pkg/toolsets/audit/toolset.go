@@ -0,0 +1,26 @@
+package audit
+
+import (
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"github.com/openshift/must-gather-mcp-server/pkg/toolsets"
+)
+
+// Toolset represents the audit log toolset
+type Toolset struct{}
+
+// Name returns the toolset name
+func (t *Toolset) Name() string {
+	return "audit"
+}
+
+// GetTools returns all tools in this toolset
+func (t *Toolset) GetTools() []api.ServerTool {
+	tools := make([]api.ServerTool, 0)
+	tools = append(tools, summaryTools()...)
+	tools = append(tools, objectTools()...)
+	return tools
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
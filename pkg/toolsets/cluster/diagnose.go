@@ -0,0 +1,602 @@
+package cluster
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func diagnoseTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "cluster_operator_diagnose",
+				Description: "Diagnose a cluster operator: follows related objects into the must-gather, checks operator namespaces for unhealthy pods and warning events, extracts error lines from operator pod logs, and maps condition reasons to known issues to produce a ranked list of likely causes",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"name": {
+							Type:        "string",
+							Description: "Cluster operator name (e.g., kube-apiserver, etcd, ingress)",
+						},
+						"logLines": {
+							Type:        "integer",
+							Description: "Maximum error lines to show per operator container log (default: 5, 0 to skip logs)",
+						},
+					},
+					Required: []string{"name"},
+				},
+			},
+			Handler: clusterOperatorDiagnose,
+		},
+	}
+}
+
+// knownIssue maps a condition reason or message pattern to a likely cause
+type knownIssue struct {
+	Pattern *regexp.Regexp
+	Cause   string
+	Hint    string
+}
+
+// knownIssues is a catalogue of common ClusterOperator condition reasons
+var knownIssues = []knownIssue{
+	{
+		Pattern: regexp.MustCompile(`(?i)InstallerPodFailed|NodeInstaller.*Degraded`),
+		Cause:   "Static pod installer failed on a control-plane node",
+		Hint:    "Check installer-* pod logs in the operator namespace and the node's kubelet logs",
+	},
+	{
+		Pattern: regexp.MustCompile(`(?i)StaticPods.*Error|StaticPodsDegraded|MissingStaticPod`),
+		Cause:   "A static pod is failing or missing on a control-plane node",
+		Hint:    "Compare static pod revisions across masters and check the failing container's logs",
+	},
+	{
+		Pattern: regexp.MustCompile(`(?i)EtcdMembers|EtcdEndpoints|ClusterMemberController|EtcdCertSigner`),
+		Cause:   "One or more etcd members are unhealthy",
+		Hint:    "Run etcd_health and etcd_endpoint_status to check member health and quota",
+	},
+	{
+		Pattern: regexp.MustCompile(`(?i)NodeController_MasterNodesReady|MasterNodesReady|NodeNotReady`),
+		Cause:   "A control-plane node is NotReady",
+		Hint:    "Check cluster_nodes_list and node_kubelet_logs_grep for the affected node",
+	},
+	{
+		Pattern: regexp.MustCompile(`(?i)RouteHealth|OAuthServerRoute|EndpointAccessible|IngressStateEndpoints`),
+		Cause:   "A route or endpoint served through the ingress controller is not reachable",
+		Hint:    "Check the ingress operator, router pods in openshift-ingress and DNS",
+	},
+	{
+		Pattern: regexp.MustCompile(`(?i)IngressController.*(Unavailable|Degraded)|IngressDegraded|IngressUnavailable`),
+		Cause:   "The default IngressController is degraded or unavailable",
+		Hint:    "Check router pods in openshift-ingress and the IngressController status conditions",
+	},
+	{
+		Pattern: regexp.MustCompile(`(?i)RequiredPoolsFailed|MachineConfigDaemonFailed|MachineConfigPool.*Degraded|RenderConfigFailed`),
+		Cause:   "A MachineConfigPool is degraded or failed to render",
+		Hint:    "Check MachineConfigPool conditions and machine-config-daemon logs on the affected nodes",
+	},
+	{
+		Pattern: regexp.MustCompile(`(?i)(Deployment|DaemonSet|Workload).*(Degraded|UnavailablePod|InsufficientReplicas)|UnavailablePod|RolloutHung`),
+		Cause:   "Operand pods managed by the operator are not available",
+		Hint:    "Inspect unhealthy pods in the operand namespace and their logs",
+	},
+	{
+		Pattern: regexp.MustCompile(`(?i)ImagePull|ErrImagePull|ImagePullBackOff`),
+		Cause:   "Images cannot be pulled",
+		Hint:    "Check pull secret, registry reachability and image mirror configuration",
+	},
+	{
+		Pattern: regexp.MustCompile(`(?i)x509:|certificate has expired|certificate is not yet valid|certificate signed by unknown authority|tls: failed to verify certificate|CertificateExpired`),
+		Cause:   "A certificate is expired, invalid or not yet rotated",
+		Hint:    "Check the referenced secrets and the cert rotation controllers in the operator logs",
+	},
+	{
+		Pattern: regexp.MustCompile(`(?i)StorageNotConfigured|PVC.*(Pending|Failed)|StorageError`),
+		Cause:   "Operand storage is not configured or not bound",
+		Hint:    "Check PVCs and storage configuration for the operator",
+	},
+	{
+		Pattern: regexp.MustCompile(`(?i)UpdatingPrometheus|UpdatingAlertmanager|UpdatingThanos|MultipleTasksFailed`),
+		Cause:   "The monitoring stack failed to reconcile one of its components",
+		Hint:    "Check pods in openshift-monitoring and the cluster-monitoring-operator logs",
+	},
+	{
+		Pattern: regexp.MustCompile(`(?i)APIServices?.*(Unavailable|Degraded)|APIServicesAvailable`),
+		Cause:   "An aggregated API service is unavailable",
+		Hint:    "Check the APIService objects and the backing service endpoints",
+	},
+	{
+		Pattern: regexp.MustCompile(`(?i)Webhook`),
+		Cause:   "An admission webhook is failing",
+		Hint:    "Check the webhook configuration and the pods behind its service",
+	},
+}
+
+// likelyCause is a single ranked finding
+type likelyCause struct {
+	Score    int
+	Summary  string
+	Evidence []string
+}
+
+// logErrorPattern matches typical error lines in operator logs (klog E-lines and error keywords)
+var logErrorPattern = regexp.MustCompile(`(?i)((^|\s)E\d{4} |\berror\b|\bfailed\b|\bpanic\b)`)
+
+func clusterOperatorDiagnose(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	name := params.GetString("name", "")
+	logLines := params.GetInt("logLines", 5)
+
+	if name == "" {
+		return api.NewToolCallResult("", fmt.Errorf("operator name is required")), nil
+	}
+
+	gvk := parseGVK("config.openshift.io/v1", "ClusterOperator")
+	op, err := params.MustGatherProvider.GetResource(params.Context, gvk, "", name)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("cluster operator '%s' not found: %w", name, err)), nil
+	}
+
+	output := fmt.Sprintf("Cluster Operator Diagnosis: %s\n", name)
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	available := getConditionStatus(op, "Available")
+	progressing := getConditionStatus(op, "Progressing")
+	degraded := getConditionStatus(op, "Degraded")
+	output += fmt.Sprintf("Available: %s | Progressing: %s | Degraded: %s\n\n",
+		formatStatus(available), formatStatus(progressing), formatStatus(degraded))
+
+	causes := make([]*likelyCause, 0)
+
+	// 1. Conditions mapped against the known-issue catalogue
+	output += "## Problem Conditions\n\n"
+	problemConditions := 0
+	conditions, _, _ := unstructured.NestedSlice(op.Object, "status", "conditions")
+	for _, cond := range conditions {
+		condMap, ok := cond.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		condType, _ := condMap["type"].(string)
+		status, _ := condMap["status"].(string)
+		reason, _ := condMap["reason"].(string)
+		message, _ := condMap["message"].(string)
+
+		if !isProblemCondition(condType, status) {
+			continue
+		}
+		problemConditions++
+
+		output += fmt.Sprintf("✗ %s=%s", condType, status)
+		if reason != "" {
+			output += fmt.Sprintf(" (%s)", reason)
+		}
+		output += "\n"
+		if message != "" {
+			output += fmt.Sprintf("  Message: %s\n", truncate(strings.ReplaceAll(message, "\n", " "), 300))
+		}
+
+		score := 60
+		if condType == "Degraded" || condType == "Available" {
+			score = 100
+		}
+
+		matched := false
+		for _, issue := range knownIssues {
+			if issue.Pattern.MatchString(reason) || issue.Pattern.MatchString(message) {
+				causes = append(causes, &likelyCause{
+					Score:   score,
+					Summary: issue.Cause,
+					Evidence: []string{
+						fmt.Sprintf("%s=%s reason %s", condType, status, reason),
+						"Next step: " + issue.Hint,
+					},
+				})
+				matched = true
+				break
+			}
+		}
+
+		if !matched {
+			causes = append(causes, &likelyCause{
+				Score:    score / 2,
+				Summary:  fmt.Sprintf("Operator reports %s=%s (%s) with no catalogued cause", condType, status, reason),
+				Evidence: []string{truncate(strings.ReplaceAll(message, "\n", " "), 200)},
+			})
+		}
+	}
+	if problemConditions == 0 {
+		output += "✓ No problem conditions reported\n"
+	}
+	output += "\n"
+
+	// 2. Related objects resolved against the index
+	output += "## Related Objects\n\n"
+	namespaces := make(map[string]bool)
+	gvks := params.MustGatherProvider.ListGVKs()
+	missing := make([]string, 0)
+
+	relatedObjs, _, _ := unstructured.NestedSlice(op.Object, "status", "relatedObjects")
+	for _, obj := range relatedObjs {
+		objMap, ok := obj.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		group, _ := objMap["group"].(string)
+		resource, _ := objMap["resource"].(string)
+		objName, _ := objMap["name"].(string)
+		objNamespace, _ := objMap["namespace"].(string)
+
+		ref := resource
+		if group != "" {
+			ref = group + "/" + resource
+		}
+		if objNamespace != "" {
+			ref += fmt.Sprintf(" %s/%s", objNamespace, objName)
+			namespaces[objNamespace] = true
+		} else {
+			ref += " " + objName
+		}
+
+		if resource == "namespaces" {
+			namespaces[objName] = true
+		}
+
+		relatedGVK, found := resolveResourceGVK(gvks, group, resource)
+		if !found {
+			output += fmt.Sprintf("  ? %s (type not collected)\n", ref)
+			continue
+		}
+
+		related, err := params.MustGatherProvider.GetResource(params.Context, relatedGVK, objNamespace, objName)
+		if err != nil {
+			output += fmt.Sprintf("  ✗ %s (not found in must-gather)\n", ref)
+			missing = append(missing, ref)
+			continue
+		}
+
+		output += fmt.Sprintf("  ✓ %s\n", ref)
+		for _, problem := range relatedObjectProblems(related) {
+			output += fmt.Sprintf("      ⚠ %s\n", problem)
+			causes = append(causes, &likelyCause{
+				Score:    70,
+				Summary:  fmt.Sprintf("Related %s %s is unhealthy", related.GetKind(), related.GetName()),
+				Evidence: []string{problem},
+			})
+		}
+	}
+	if len(relatedObjs) == 0 {
+		output += "  (no related objects reported)\n"
+	}
+	output += "\n"
+
+	if len(missing) > 0 {
+		causes = append(causes, &likelyCause{
+			Score:    10,
+			Summary:  fmt.Sprintf("%d related object(s) are missing from the must-gather", len(missing)),
+			Evidence: missing,
+		})
+	}
+
+	// Include the conventional operator namespace even if not listed
+	for _, candidate := range []string{"openshift-" + name + "-operator", "openshift-" + name} {
+		if _, err := params.MustGatherProvider.GetResource(params.Context, parseGVK("v1", "Namespace"), "", candidate); err == nil {
+			namespaces[candidate] = true
+		}
+	}
+
+	nsList := make([]string, 0, len(namespaces))
+	for ns := range namespaces {
+		nsList = append(nsList, ns)
+	}
+	sort.Strings(nsList)
+
+	// 3. Unhealthy pods and warning events in operator namespaces
+	output += "## Operator Namespaces\n\n"
+	if len(nsList) == 0 {
+		output += "  (no namespaces associated with this operator)\n\n"
+	}
+
+	operatorPods := make(map[string][]string) // namespace -> operator pod names
+
+	for _, ns := range nsList {
+		output += fmt.Sprintf("Namespace: %s\n", ns)
+		output += strings.Repeat("-", 80) + "\n"
+
+		podList, err := params.MustGatherProvider.ListResources(params.Context, parseGVK("v1", "Pod"), ns, api.ListOptions{})
+		if err == nil {
+			pods := podList.Items
+			sort.Slice(pods, func(i, j int) bool { return pods[i].GetName() < pods[j].GetName() })
+
+			unhealthyCount := 0
+			for i := range pods {
+				pod := &pods[i]
+				if strings.Contains(pod.GetName(), "operator") {
+					operatorPods[ns] = append(operatorPods[ns], pod.GetName())
+				}
+
+				problems := podProblems(pod)
+				if len(problems) == 0 {
+					continue
+				}
+				unhealthyCount++
+				output += fmt.Sprintf("  ✗ Pod %s: %s\n", pod.GetName(), strings.Join(problems, "; "))
+				causes = append(causes, &likelyCause{
+					Score:    50 + len(problems)*5,
+					Summary:  fmt.Sprintf("Pod %s/%s is unhealthy", ns, pod.GetName()),
+					Evidence: problems,
+				})
+			}
+			output += fmt.Sprintf("  Pods: %d total, %d unhealthy\n", len(pods), unhealthyCount)
+		}
+
+		events := warningEvents(params, ns)
+		if len(events) > 0 {
+			output += fmt.Sprintf("  Warning Events (%d):\n", len(events))
+			displayCount := len(events)
+			if displayCount > 5 {
+				displayCount = 5
+			}
+			for _, ev := range events[:displayCount] {
+				output += fmt.Sprintf("    • [%s x%d] %s: %s\n", ev.Reason, ev.Count, ev.Object, truncate(ev.Message, 120))
+			}
+
+			top := events[0]
+			causes = append(causes, &likelyCause{
+				Score:    20 + min(int(top.Count), 30),
+				Summary:  fmt.Sprintf("Repeated warning events in %s (%s)", ns, top.Reason),
+				Evidence: []string{fmt.Sprintf("%s x%d: %s", top.Object, top.Count, truncate(top.Message, 150))},
+			})
+		}
+		output += "\n"
+	}
+
+	// 4. Error lines from operator pod logs
+	if logLines > 0 && len(operatorPods) > 0 {
+		output += "## Operator Log Errors\n\n"
+		for _, ns := range nsList {
+			for _, pod := range operatorPods[ns] {
+				containers, err := params.MustGatherProvider.ListPodContainers(ns, pod)
+				if err != nil {
+					continue
+				}
+
+				for _, container := range containers {
+					logs, err := params.MustGatherProvider.GetPodLog(api.PodLogOptions{
+						Namespace: ns,
+						Pod:       pod,
+						Container: container,
+						LogType:   api.LogTypeCurrent,
+						TailLines: 2000,
+					})
+					if err != nil {
+						continue
+					}
+
+					errorLines := make([]string, 0)
+					for _, line := range strings.Split(logs, "\n") {
+						if logErrorPattern.MatchString(line) {
+							errorLines = append(errorLines, line)
+						}
+					}
+					if len(errorLines) == 0 {
+						continue
+					}
+
+					output += fmt.Sprintf("%s/%s [%s] - %d error line(s), last %d:\n",
+						ns, pod, container, len(errorLines), min(len(errorLines), logLines))
+					if len(errorLines) > logLines {
+						errorLines = errorLines[len(errorLines)-logLines:]
+					}
+					for _, line := range errorLines {
+						output += fmt.Sprintf("  %s\n", truncate(line, 200))
+					}
+					output += "\n"
+
+					causes = append(causes, &likelyCause{
+						Score:    30,
+						Summary:  fmt.Sprintf("Errors logged by %s/%s (%s)", ns, pod, container),
+						Evidence: []string{truncate(errorLines[len(errorLines)-1], 200)},
+					})
+				}
+			}
+		}
+	}
+
+	// 5. Ranked list of likely causes
+	sort.SliceStable(causes, func(i, j int) bool {
+		return causes[i].Score > causes[j].Score
+	})
+
+	output += "## Likely Causes (ranked)\n\n"
+	if len(causes) == 0 {
+		output += "✓ No problems found for this operator\n"
+	}
+	for i, cause := range causes {
+		output += fmt.Sprintf("%d. %s (score %d)\n", i+1, cause.Summary, cause.Score)
+		for _, ev := range cause.Evidence {
+			if ev != "" {
+				output += fmt.Sprintf("   - %s\n", ev)
+			}
+		}
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// isProblemCondition returns true for ClusterOperator conditions that indicate a problem
+func isProblemCondition(condType, status string) bool {
+	switch condType {
+	case "Degraded":
+		return status == "True"
+	case "Available", "Upgradeable":
+		return status == "False"
+	}
+	return false
+}
+
+// resolveResourceGVK maps an API group and plural resource name to a GVK present in the must-gather
+func resolveResourceGVK(gvks []schema.GroupVersionKind, group, resource string) (schema.GroupVersionKind, bool) {
+	resource = strings.ToLower(resource)
+
+	candidates := make([]schema.GroupVersionKind, 0)
+	for _, gvk := range gvks {
+		if gvk.Group != group {
+			continue
+		}
+		kind := strings.ToLower(gvk.Kind)
+		if kind+"s" == resource || kind+"es" == resource ||
+			(strings.HasSuffix(kind, "y") && strings.TrimSuffix(kind, "y")+"ies" == resource) {
+			candidates = append(candidates, gvk)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return schema.GroupVersionKind{}, false
+	}
+
+	// Prefer the shortest (most stable) version, e.g. v1 over v1beta1
+	sort.Slice(candidates, func(i, j int) bool {
+		if len(candidates[i].Version) != len(candidates[j].Version) {
+			return len(candidates[i].Version) < len(candidates[j].Version)
+		}
+		return candidates[i].Version < candidates[j].Version
+	})
+
+	return candidates[0], true
+}
+
+// relatedObjectProblems reports health problems for workload-like related objects
+func relatedObjectProblems(obj *unstructured.Unstructured) []string {
+	problems := make([]string, 0)
+
+	switch obj.GetKind() {
+	case "Deployment", "StatefulSet":
+		desired, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		if !found {
+			desired = 1
+		}
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+		if ready < desired {
+			problems = append(problems, fmt.Sprintf("%d of %d replicas ready", ready, desired))
+		}
+	case "DaemonSet":
+		desired, _, _ := unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "numberReady")
+		if ready < desired {
+			problems = append(problems, fmt.Sprintf("%d of %d daemon pods ready", ready, desired))
+		}
+	case "Namespace":
+		if phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase"); phase == "Terminating" {
+			problems = append(problems, "namespace is Terminating")
+		}
+	}
+
+	return problems
+}
+
+// podProblems returns human-readable problems for a pod, or nothing if it is healthy
+func podProblems(pod *unstructured.Unstructured) []string {
+	problems := make([]string, 0)
+
+	phase, _, _ := unstructured.NestedString(pod.Object, "status", "phase")
+	if phase == "Succeeded" {
+		return problems
+	}
+	if phase != "Running" {
+		reason, _, _ := unstructured.NestedString(pod.Object, "status", "reason")
+		if reason != "" {
+			problems = append(problems, fmt.Sprintf("phase %s (%s)", phase, reason))
+		} else {
+			problems = append(problems, fmt.Sprintf("phase %s", phase))
+		}
+	}
+
+	statuses, _, _ := unstructured.NestedSlice(pod.Object, "status", "containerStatuses")
+	for _, cs := range statuses {
+		csMap, ok := cs.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		containerName, _ := csMap["name"].(string)
+		ready, _ := csMap["ready"].(bool)
+
+		if reason, _, _ := unstructured.NestedString(csMap, "state", "waiting", "reason"); reason != "" {
+			problems = append(problems, fmt.Sprintf("container %s waiting: %s", containerName, reason))
+		} else if !ready && phase == "Running" {
+			problems = append(problems, fmt.Sprintf("container %s not ready", containerName))
+		}
+
+		if restarts, _, _ := unstructured.NestedInt64(csMap, "restartCount"); restarts >= 5 {
+			lastReason, _, _ := unstructured.NestedString(csMap, "lastState", "terminated", "reason")
+			if lastReason != "" {
+				problems = append(problems, fmt.Sprintf("container %s restarted %d times (last: %s)", containerName, restarts, lastReason))
+			} else {
+				problems = append(problems, fmt.Sprintf("container %s restarted %d times", containerName, restarts))
+			}
+		}
+	}
+
+	return problems
+}
+
+// eventSummary is a condensed Warning event
+type eventSummary struct {
+	Reason  string
+	Message string
+	Object  string
+	Count   int64
+	Last    string
+}
+
+// warningEvents returns Warning events in a namespace, most frequent first
+func warningEvents(params api.ToolHandlerParams, namespace string) []eventSummary {
+	eventList, err := params.MustGatherProvider.ListResources(params.Context, parseGVK("v1", "Event"), namespace, api.ListOptions{})
+	if err != nil {
+		return nil
+	}
+
+	events := make([]eventSummary, 0)
+	for i := range eventList.Items {
+		ev := &eventList.Items[i]
+		if evType, _, _ := unstructured.NestedString(ev.Object, "type"); evType != "Warning" {
+			continue
+		}
+
+		reason, _, _ := unstructured.NestedString(ev.Object, "reason")
+		message, _, _ := unstructured.NestedString(ev.Object, "message")
+		kind, _, _ := unstructured.NestedString(ev.Object, "involvedObject", "kind")
+		objName, _, _ := unstructured.NestedString(ev.Object, "involvedObject", "name")
+		count, found, _ := unstructured.NestedInt64(ev.Object, "count")
+		if !found || count == 0 {
+			count = 1
+		}
+		last, _, _ := unstructured.NestedString(ev.Object, "lastTimestamp")
+
+		events = append(events, eventSummary{
+			Reason:  reason,
+			Message: strings.ReplaceAll(message, "\n", " "),
+			Object:  kind + "/" + objName,
+			Count:   count,
+			Last:    last,
+		})
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Count != events[j].Count {
+			return events[i].Count > events[j].Count
+		}
+		return events[i].Last > events[j].Last
+	})
+
+	return events
+}
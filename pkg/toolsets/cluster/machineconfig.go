@@ -0,0 +1,717 @@
+package cluster
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	mcoCurrentConfigAnnotation = "machineconfiguration.openshift.io/currentConfig"
+	mcoDesiredConfigAnnotation = "machineconfiguration.openshift.io/desiredConfig"
+	mcoStateAnnotation         = "machineconfiguration.openshift.io/state"
+	mcoReasonAnnotation        = "machineconfiguration.openshift.io/reason"
+)
+
+// maxDiffLines bounds the size of files compared line by line
+const maxDiffLines = 2000
+
+func machineConfigTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "machineconfigpools_list",
+				Description: "List MachineConfigPools with machine counts, rendered configuration, pause state and degraded reasons",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"name": {
+							Type:        "string",
+							Description: "Show only this pool, including its source MachineConfigs (optional)",
+						},
+					},
+				},
+			},
+			Handler: machineConfigPoolsList,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "machineconfig_node_status",
+				Description: "Show which rendered MachineConfig each node is on versus its desired config, with machine-config-daemon state and reason from node annotations",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"pool": {
+							Type:        "string",
+							Description: "Filter by MachineConfigPool name (optional)",
+						},
+						"mismatchedOnly": {
+							Type:        "boolean",
+							Description: "Only show nodes whose current config differs from desired or that are not Done (default: false)",
+						},
+					},
+				},
+			},
+			Handler: machineConfigNodeStatus,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "machineconfig_diff",
+				Description: "Diff two MachineConfigs (typically rendered configs) showing changed files, systemd units, kernel arguments and OS image",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"from": {
+							Type:        "string",
+							Description: "Name of the old MachineConfig (e.g., rendered-worker-abc)",
+						},
+						"to": {
+							Type:        "string",
+							Description: "Name of the new MachineConfig (e.g., rendered-worker-def)",
+						},
+						"contextLines": {
+							Type:        "integer",
+							Description: "Maximum diff lines to show per changed file or unit (default: 40, 0 to list changes only)",
+						},
+					},
+					Required: []string{"from", "to"},
+				},
+			},
+			Handler: machineConfigDiff,
+		},
+	}
+}
+
+func machineConfigPoolsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	nameFilter := params.GetString("name", "")
+
+	pools := listMachineConfigPools(params)
+	if len(pools) == 0 {
+		return api.NewToolCallResult("No MachineConfigPools found", nil), nil
+	}
+
+	output := "MachineConfigPools\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	shown := 0
+	for _, pool := range pools {
+		if nameFilter != "" && pool.GetName() != nameFilter {
+			continue
+		}
+		shown++
+
+		machineCount, _, _ := unstructured.NestedInt64(pool.Object, "status", "machineCount")
+		updated, _, _ := unstructured.NestedInt64(pool.Object, "status", "updatedMachineCount")
+		ready, _, _ := unstructured.NestedInt64(pool.Object, "status", "readyMachineCount")
+		unavailable, _, _ := unstructured.NestedInt64(pool.Object, "status", "unavailableMachineCount")
+		degraded, _, _ := unstructured.NestedInt64(pool.Object, "status", "degradedMachineCount")
+		paused, _, _ := unstructured.NestedBool(pool.Object, "spec", "paused")
+		rendered, _, _ := unstructured.NestedString(pool.Object, "status", "configuration", "name")
+		specRendered, _, _ := unstructured.NestedString(pool.Object, "spec", "configuration", "name")
+
+		output += fmt.Sprintf("Pool: %s\n", pool.GetName())
+		output += strings.Repeat("-", 80) + "\n"
+		output += fmt.Sprintf("Machines: %d total, %d updated, %d ready, %d unavailable, %d degraded\n",
+			machineCount, updated, ready, unavailable, degraded)
+		output += fmt.Sprintf("Paused: %t\n", paused)
+		output += fmt.Sprintf("Current Rendered Config: %s\n", rendered)
+		if specRendered != "" && specRendered != rendered {
+			output += fmt.Sprintf("Target Rendered Config: %s\n", specRendered)
+		}
+		if maxUnavailable, found, _ := unstructured.NestedFieldNoCopy(pool.Object, "spec", "maxUnavailable"); found {
+			output += fmt.Sprintf("Max Unavailable: %v\n", maxUnavailable)
+		}
+
+		output += "Conditions:\n"
+		for _, condType := range []string{"Updated", "Updating", "Degraded", "NodeDegraded", "RenderDegraded"} {
+			cond := getCondition(pool, condType)
+			if cond == nil {
+				continue
+			}
+			status, _ := cond["status"].(string)
+			reason, _ := cond["reason"].(string)
+			message, _ := cond["message"].(string)
+
+			symbol := getStatusSymbol(status)
+			if strings.HasSuffix(condType, "Degraded") || condType == "Updating" {
+				// For these conditions True means trouble or work in progress
+				if status == "True" {
+					symbol = "✗"
+				} else if status == "False" {
+					symbol = "✓"
+				}
+			}
+
+			output += fmt.Sprintf("  %s %s: %s", symbol, condType, status)
+			if reason != "" {
+				output += fmt.Sprintf(" (%s)", reason)
+			}
+			output += "\n"
+			if status == "True" && strings.HasSuffix(condType, "Degraded") && message != "" {
+				output += fmt.Sprintf("    Message: %s\n", truncate(strings.ReplaceAll(message, "\n", " "), 300))
+			}
+		}
+
+		if nameFilter != "" {
+			sources, _, _ := unstructured.NestedSlice(pool.Object, "status", "configuration", "source")
+			if len(sources) > 0 {
+				output += fmt.Sprintf("Source MachineConfigs (%d):\n", len(sources))
+				for _, src := range sources {
+					if srcMap, ok := src.(map[string]interface{}); ok {
+						output += fmt.Sprintf("  - %v\n", srcMap["name"])
+					}
+				}
+			}
+		}
+
+		output += "\n"
+	}
+
+	if shown == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("MachineConfigPool '%s' not found", nameFilter)), nil
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+func machineConfigNodeStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	poolFilter := params.GetString("pool", "")
+	mismatchedOnly := params.GetBool("mismatchedOnly", false)
+
+	nodeList, err := params.MustGatherProvider.ListResources(params.Context, parseGVK("v1", "Node"), "", api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list nodes: %w", err)), nil
+	}
+
+	nodes := nodeList.Items
+	if len(nodes) == 0 {
+		return api.NewToolCallResult("No nodes found", nil), nil
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].GetName() < nodes[j].GetName() })
+
+	pools := listMachineConfigPools(params)
+
+	output := "Node MachineConfig Status\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	output += fmt.Sprintf("%-35s %-10s %-10s %s\n", "NODE", "POOL", "STATE", "CURRENT -> DESIRED")
+	output += strings.Repeat("-", 100) + "\n"
+
+	shown := 0
+	mismatched := 0
+	details := ""
+	for i := range nodes {
+		node := &nodes[i]
+		annotations := node.GetAnnotations()
+		current := annotations[mcoCurrentConfigAnnotation]
+		desired := annotations[mcoDesiredConfigAnnotation]
+		state := annotations[mcoStateAnnotation]
+		reason := annotations[mcoReasonAnnotation]
+
+		poolName := "<none>"
+		if pool := poolForNode(pools, node); pool != nil {
+			poolName = pool.GetName()
+		}
+		if poolFilter != "" && poolName != poolFilter {
+			continue
+		}
+
+		isMismatched := current != desired || (state != "" && state != "Done")
+		if isMismatched {
+			mismatched++
+		}
+		if mismatchedOnly && !isMismatched {
+			continue
+		}
+		shown++
+
+		if state == "" {
+			state = "<unknown>"
+		}
+
+		configs := current
+		if current != desired {
+			configs = fmt.Sprintf("%s -> %s", current, desired)
+		}
+
+		symbol := "✓"
+		if isMismatched {
+			symbol = "✗"
+		}
+		output += fmt.Sprintf("%s %-33s %-10s %-10s %s\n", symbol, truncate(node.GetName(), 33), truncate(poolName, 10), state, configs)
+
+		if reason != "" {
+			details += fmt.Sprintf("%s: %s\n", node.GetName(), truncate(reason, 300))
+		}
+	}
+
+	output += fmt.Sprintf("\nNodes: %d shown, %d not on desired config or not Done\n", shown, mismatched)
+
+	if details != "" {
+		output += "\nMachine Config Daemon Reasons:\n"
+		output += strings.Repeat("-", 80) + "\n"
+		output += details
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// machineConfigSpec is the comparable content of a MachineConfig
+type machineConfigSpec struct {
+	Files      map[string]machineConfigFile
+	Units      map[string]string // unit or unit/dropin name -> rendered description and contents
+	KernelArgs []string
+	OSImageURL string
+	KernelType string
+	Extensions []string
+	FIPS       bool
+}
+
+// machineConfigFile is a decoded ignition storage file
+type machineConfigFile struct {
+	Mode     string
+	Contents string
+	Decoded  bool
+}
+
+func machineConfigDiff(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	fromName := params.GetString("from", "")
+	toName := params.GetString("to", "")
+	contextLines := params.GetInt("contextLines", 40)
+
+	if fromName == "" || toName == "" {
+		return api.NewToolCallResult("", fmt.Errorf("from and to are required")), nil
+	}
+
+	gvk := parseGVK("machineconfiguration.openshift.io/v1", "MachineConfig")
+	fromMC, err := params.MustGatherProvider.GetResource(params.Context, gvk, "", fromName)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("MachineConfig '%s' not found: %w", fromName, err)), nil
+	}
+	toMC, err := params.MustGatherProvider.GetResource(params.Context, gvk, "", toName)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("MachineConfig '%s' not found: %w", toName, err)), nil
+	}
+
+	from := parseMachineConfigSpec(fromMC)
+	to := parseMachineConfigSpec(toMC)
+
+	output := fmt.Sprintf("MachineConfig Diff: %s -> %s\n", fromName, toName)
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	changes := 0
+
+	// OS image and kernel settings
+	output += "## OS and Kernel\n\n"
+	if from.OSImageURL != to.OSImageURL {
+		output += fmt.Sprintf("~ osImageURL:\n    - %s\n    + %s\n", from.OSImageURL, to.OSImageURL)
+		changes++
+	}
+	if from.KernelType != to.KernelType {
+		output += fmt.Sprintf("~ kernelType: %s -> %s\n", valueOrDefault(from.KernelType), valueOrDefault(to.KernelType))
+		changes++
+	}
+	if from.FIPS != to.FIPS {
+		output += fmt.Sprintf("~ fips: %t -> %t\n", from.FIPS, to.FIPS)
+		changes++
+	}
+	removedArgs, addedArgs := diffStringSets(from.KernelArgs, to.KernelArgs)
+	for _, arg := range removedArgs {
+		output += fmt.Sprintf("- kernelArgument: %s\n", arg)
+		changes++
+	}
+	for _, arg := range addedArgs {
+		output += fmt.Sprintf("+ kernelArgument: %s\n", arg)
+		changes++
+	}
+	removedExt, addedExt := diffStringSets(from.Extensions, to.Extensions)
+	for _, ext := range removedExt {
+		output += fmt.Sprintf("- extension: %s\n", ext)
+		changes++
+	}
+	for _, ext := range addedExt {
+		output += fmt.Sprintf("+ extension: %s\n", ext)
+		changes++
+	}
+	output += "\n"
+
+	// Files
+	output += "## Files\n\n"
+	fileChanges := 0
+	for _, path := range sortedKeys(from.Files, to.Files) {
+		oldFile, inOld := from.Files[path]
+		newFile, inNew := to.Files[path]
+
+		switch {
+		case inOld && !inNew:
+			output += fmt.Sprintf("- %s (removed)\n", path)
+		case !inOld && inNew:
+			output += fmt.Sprintf("+ %s (added, mode %s)\n", path, newFile.Mode)
+			if contextLines > 0 && newFile.Decoded {
+				output += indentLines(limitLines(newFile.Contents, contextLines), "    + ")
+			}
+		case oldFile.Contents != newFile.Contents || oldFile.Mode != newFile.Mode:
+			output += fmt.Sprintf("~ %s", path)
+			if oldFile.Mode != newFile.Mode {
+				output += fmt.Sprintf(" (mode %s -> %s)", oldFile.Mode, newFile.Mode)
+			}
+			output += "\n"
+			if contextLines > 0 && oldFile.Contents != newFile.Contents {
+				if oldFile.Decoded && newFile.Decoded {
+					output += lineDiff(oldFile.Contents, newFile.Contents, contextLines)
+				} else {
+					output += "    (contents changed; encoded data not shown)\n"
+				}
+			}
+		default:
+			continue
+		}
+		fileChanges++
+	}
+	if fileChanges == 0 {
+		output += "(no file changes)\n"
+	}
+	changes += fileChanges
+	output += "\n"
+
+	// Systemd units
+	output += "## Systemd Units\n\n"
+	unitChanges := 0
+	for _, name := range sortedKeys(from.Units, to.Units) {
+		oldUnit, inOld := from.Units[name]
+		newUnit, inNew := to.Units[name]
+
+		switch {
+		case inOld && !inNew:
+			output += fmt.Sprintf("- %s (removed)\n", name)
+		case !inOld && inNew:
+			output += fmt.Sprintf("+ %s (added)\n", name)
+			if contextLines > 0 {
+				output += indentLines(limitLines(newUnit, contextLines), "    + ")
+			}
+		case oldUnit != newUnit:
+			output += fmt.Sprintf("~ %s\n", name)
+			if contextLines > 0 {
+				output += lineDiff(oldUnit, newUnit, contextLines)
+			}
+		default:
+			continue
+		}
+		unitChanges++
+	}
+	if unitChanges == 0 {
+		output += "(no systemd unit changes)\n"
+	}
+	changes += unitChanges
+	output += "\n"
+
+	output += fmt.Sprintf("Total changes: %d (files: %d, units: %d)\n", changes, fileChanges, unitChanges)
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// parseMachineConfigSpec extracts the comparable parts of a MachineConfig
+func parseMachineConfigSpec(mc *unstructured.Unstructured) *machineConfigSpec {
+	spec := &machineConfigSpec{
+		Files: make(map[string]machineConfigFile),
+		Units: make(map[string]string),
+	}
+
+	spec.OSImageURL, _, _ = unstructured.NestedString(mc.Object, "spec", "osImageURL")
+	spec.KernelType, _, _ = unstructured.NestedString(mc.Object, "spec", "kernelType")
+	spec.FIPS, _, _ = unstructured.NestedBool(mc.Object, "spec", "fips")
+	spec.KernelArgs, _, _ = unstructured.NestedStringSlice(mc.Object, "spec", "kernelArguments")
+	spec.Extensions, _, _ = unstructured.NestedStringSlice(mc.Object, "spec", "extensions")
+
+	files, _, _ := unstructured.NestedSlice(mc.Object, "spec", "config", "storage", "files")
+	for _, f := range files {
+		fileMap, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		path, _ := fileMap["path"].(string)
+		source, _, _ := unstructured.NestedString(fileMap, "contents", "source")
+		compression, _, _ := unstructured.NestedString(fileMap, "contents", "compression")
+
+		file := machineConfigFile{Mode: fmt.Sprintf("%v", fileMap["mode"])}
+		if fileMap["mode"] != nil {
+			if mode, ok := fileMap["mode"].(int64); ok {
+				file.Mode = fmt.Sprintf("%#o", mode)
+			}
+		}
+
+		if contents, err := decodeDataURL(source, compression); err == nil {
+			file.Contents = contents
+			file.Decoded = true
+		} else {
+			file.Contents = source
+		}
+		spec.Files[path] = file
+	}
+
+	units, _, _ := unstructured.NestedSlice(mc.Object, "spec", "config", "systemd", "units")
+	for _, u := range units {
+		unitMap, ok := u.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := unitMap["name"].(string)
+
+		header := ""
+		if enabled, ok := unitMap["enabled"].(bool); ok {
+			header += fmt.Sprintf("# enabled: %t\n", enabled)
+		}
+		if mask, ok := unitMap["mask"].(bool); ok && mask {
+			header += "# masked: true\n"
+		}
+		contents, _ := unitMap["contents"].(string)
+		spec.Units[name] = header + contents
+
+		dropins, _ := unitMap["dropins"].([]interface{})
+		for _, d := range dropins {
+			dropinMap, ok := d.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			dropinName, _ := dropinMap["name"].(string)
+			dropinContents, _ := dropinMap["contents"].(string)
+			spec.Units[name+"/"+dropinName] = dropinContents
+		}
+	}
+
+	return spec
+}
+
+// decodeDataURL decodes an ignition data URL (RFC 2397), optionally gzip-compressed
+func decodeDataURL(source, compression string) (string, error) {
+	if !strings.HasPrefix(source, "data:") {
+		return "", fmt.Errorf("not a data URL")
+	}
+
+	comma := strings.Index(source, ",")
+	if comma < 0 {
+		return "", fmt.Errorf("malformed data URL")
+	}
+	mediaType := source[len("data:"):comma]
+	payload := source[comma+1:]
+
+	var data []byte
+	if strings.HasSuffix(mediaType, ";base64") {
+		decoded, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			return "", err
+		}
+		data = decoded
+	} else {
+		decoded, err := url.PathUnescape(payload)
+		if err != nil {
+			return "", err
+		}
+		data = []byte(decoded)
+	}
+
+	if compression == "gzip" {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return "", err
+		}
+		defer gz.Close()
+
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, gz); err != nil {
+			return "", err
+		}
+		data = buf.Bytes()
+	}
+
+	return string(data), nil
+}
+
+// lineDiff renders a line-based diff of two texts, showing at most maxLines changed lines
+func lineDiff(oldText, newText string, maxLines int) string {
+	oldLines := strings.Split(oldText, "\n")
+	newLines := strings.Split(newText, "\n")
+
+	if len(oldLines) > maxDiffLines || len(newLines) > maxDiffLines {
+		return fmt.Sprintf("    (contents changed: %d -> %d lines, too large to diff)\n", len(oldLines), len(newLines))
+	}
+
+	// Longest common subsequence table
+	lcs := make([][]int, len(oldLines)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newLines)+1)
+	}
+	for i := len(oldLines) - 1; i >= 0; i-- {
+		for j := len(newLines) - 1; j >= 0; j-- {
+			if oldLines[i] == newLines[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	output := ""
+	shown := 0
+	total := 0
+	i, j := 0, 0
+	for i < len(oldLines) || j < len(newLines) {
+		switch {
+		case i < len(oldLines) && j < len(newLines) && oldLines[i] == newLines[j]:
+			i++
+			j++
+			continue
+		case i < len(oldLines) && (j == len(newLines) || lcs[i+1][j] >= lcs[i][j+1]):
+			total++
+			if shown < maxLines {
+				output += fmt.Sprintf("    - %s\n", oldLines[i])
+				shown++
+			}
+			i++
+		default:
+			total++
+			if shown < maxLines {
+				output += fmt.Sprintf("    + %s\n", newLines[j])
+				shown++
+			}
+			j++
+		}
+	}
+
+	if total > shown {
+		output += fmt.Sprintf("    ... %d more changed lines\n", total-shown)
+	}
+
+	return output
+}
+
+// diffStringSets returns the values only in a (removed) and only in b (added)
+func diffStringSets(a, b []string) ([]string, []string) {
+	inA := make(map[string]bool)
+	for _, v := range a {
+		inA[v] = true
+	}
+	inB := make(map[string]bool)
+	for _, v := range b {
+		inB[v] = true
+	}
+
+	removed := make([]string, 0)
+	for _, v := range a {
+		if !inB[v] {
+			removed = append(removed, v)
+		}
+	}
+	added := make([]string, 0)
+	for _, v := range b {
+		if !inA[v] {
+			added = append(added, v)
+		}
+	}
+
+	return removed, added
+}
+
+// sortedKeys returns the sorted union of keys of two maps
+func sortedKeys[V any](a, b map[string]V) []string {
+	seen := make(map[string]bool)
+	keys := make([]string, 0, len(a)+len(b))
+	for _, m := range []map[string]V{a, b} {
+		for k := range m {
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func limitLines(text string, maxLines int) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	if len(lines) <= maxLines {
+		return strings.Join(lines, "\n")
+	}
+	return strings.Join(lines[:maxLines], "\n") + fmt.Sprintf("\n... %d more lines", len(lines)-maxLines)
+}
+
+func indentLines(text, prefix string) string {
+	output := ""
+	for _, line := range strings.Split(text, "\n") {
+		output += prefix + line + "\n"
+	}
+	return output
+}
+
+func valueOrDefault(s string) string {
+	if s == "" {
+		return "(default)"
+	}
+	return s
+}
+
+// listMachineConfigPools returns all MachineConfigPools sorted by name
+func listMachineConfigPools(params api.ToolHandlerParams) []*unstructured.Unstructured {
+	list, err := params.MustGatherProvider.ListResources(params.Context, parseGVK("machineconfiguration.openshift.io/v1", "MachineConfigPool"), "", api.ListOptions{})
+	if err != nil {
+		return nil
+	}
+
+	pools := make([]*unstructured.Unstructured, 0, len(list.Items))
+	for i := range list.Items {
+		pools = append(pools, &list.Items[i])
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].GetName() < pools[j].GetName() })
+
+	return pools
+}
+
+// poolForNode returns the MachineConfigPool whose node selector matches the node.
+// Custom pools also match the worker selector, so the most specific match wins.
+func poolForNode(pools []*unstructured.Unstructured, node *unstructured.Unstructured) *unstructured.Unstructured {
+	labels := node.GetLabels()
+
+	var best *unstructured.Unstructured
+	bestCount := -1
+	for _, pool := range pools {
+		matchLabels, found, _ := unstructured.NestedStringMap(pool.Object, "spec", "nodeSelector", "matchLabels")
+		if !found || len(matchLabels) == 0 {
+			continue
+		}
+
+		matches := true
+		for key, value := range matchLabels {
+			if nodeValue, ok := labels[key]; !ok || nodeValue != value {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
+
+		// Prefer custom pools over the generic worker pool
+		count := len(matchLabels)
+		if pool.GetName() != "worker" {
+			count++
+		}
+		if count > bestCount {
+			best = pool
+			bestCount = count
+		}
+	}
+
+	return best
+}
@@ -22,8 +22,11 @@ func (t *ClusterToolset) Description() string {
 func (t *ClusterToolset) GetTools() []api.ServerTool {
 	tools := []api.ServerTool{}
 	tools = append(tools, versionTools()...)
+	tools = append(tools, upgradeTools()...)
 	tools = append(tools, infoTools()...)
 	tools = append(tools, operatorTools()...)
+	tools = append(tools, diagnoseTools()...)
 	tools = append(tools, nodeTools()...)
+	tools = append(tools, machineConfigTools()...)
 	return tools
 }
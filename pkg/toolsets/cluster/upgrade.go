@@ -0,0 +1,419 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func upgradeTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "upgrade_status",
+				Description: "Analyze a stuck or failed cluster upgrade by correlating ClusterVersion Failing/Progressing conditions, operators not yet at the target version, MachineConfigPool update/degraded counts and nodes still on an old rendered config, and report blockers in order",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+				},
+			},
+			Handler: upgradeStatus,
+		},
+	}
+}
+
+// upgradeBlocker is a single finding that prevents the upgrade from completing
+type upgradeBlocker struct {
+	Priority int
+	Summary  string
+	Details  []string
+}
+
+func upgradeStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	cvList, err := params.MustGatherProvider.ListResources(params.Context, parseGVK("config.openshift.io/v1", "ClusterVersion"), "", api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to get cluster version: %w", err)), nil
+	}
+	if len(cvList.Items) == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("cluster version not found")), nil
+	}
+	cv := &cvList.Items[0]
+
+	targetVersion, _ := getNestedString(cv, "status", "desired", "version")
+	blockers := make([]*upgradeBlocker, 0)
+
+	output := "Cluster Upgrade Status\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	// ClusterVersion state
+	output += "## ClusterVersion\n\n"
+	if targetVersion == "" {
+		output += "Target Version: unknown (status.desired.version is not set)\n"
+	} else {
+		output += fmt.Sprintf("Target Version: %s\n", targetVersion)
+	}
+
+	history, _, _ := unstructured.NestedSlice(cv.Object, "status", "history")
+	if len(history) > 0 {
+		if latest, ok := history[0].(map[string]interface{}); ok {
+			state, _ := latest["state"].(string)
+			started, _ := latest["startedTime"].(string)
+			output += fmt.Sprintf("Latest Update: %v (State: %s, Started: %s)\n", latest["version"], state, started)
+
+			if len(history) > 1 {
+				if previous, ok := history[1].(map[string]interface{}); ok {
+					output += fmt.Sprintf("Previous Version: %v (State: %v)\n", previous["version"], previous["state"])
+				}
+			}
+
+			if state == "Partial" {
+				if startedAt, err := time.Parse(time.RFC3339, started); err == nil {
+					reference := params.MustGatherProvider.GetMetadata().EndTime
+					if reference.IsZero() {
+						reference = time.Now()
+					}
+					output += fmt.Sprintf("Upgrade running for: %s (at gather time)\n", reference.Sub(startedAt).Round(time.Minute))
+				}
+			}
+		}
+	}
+	output += "\n"
+
+	for _, condType := range []string{"Failing", "Progressing", "Available", "Upgradeable", "ReleaseAccepted"} {
+		cond := getCondition(cv, condType)
+		if cond == nil {
+			continue
+		}
+		status, _ := cond["status"].(string)
+		reason, _ := cond["reason"].(string)
+		message, _ := cond["message"].(string)
+
+		// Failing is the only condition where True is bad
+		symbol := getStatusSymbol(status)
+		if condType == "Failing" {
+			switch status {
+			case "True":
+				symbol = "✗"
+			case "False":
+				symbol = "✓"
+			}
+		}
+		output += fmt.Sprintf("%s %s: %s", symbol, condType, status)
+		if reason != "" {
+			output += fmt.Sprintf(" (%s)", reason)
+		}
+		output += "\n"
+		if message != "" {
+			output += fmt.Sprintf("  Message: %s\n", truncate(strings.ReplaceAll(message, "\n", " "), 300))
+		}
+
+		switch {
+		case condType == "Failing" && status == "True":
+			blockers = append(blockers, &upgradeBlocker{
+				Priority: 0,
+				Summary:  fmt.Sprintf("ClusterVersion is Failing (%s)", reason),
+				Details:  []string{truncate(strings.ReplaceAll(message, "\n", " "), 300)},
+			})
+		case condType == "ReleaseAccepted" && status == "False":
+			blockers = append(blockers, &upgradeBlocker{
+				Priority: 0,
+				Summary:  fmt.Sprintf("Target release was not accepted (%s)", reason),
+				Details:  []string{truncate(strings.ReplaceAll(message, "\n", " "), 300)},
+			})
+		case condType == "Upgradeable" && status == "False":
+			blockers = append(blockers, &upgradeBlocker{
+				Priority: 5,
+				Summary:  fmt.Sprintf("Cluster is not Upgradeable (%s) - blocks minor version upgrades", reason),
+				Details:  []string{truncate(strings.ReplaceAll(message, "\n", " "), 300)},
+			})
+		}
+	}
+	output += "\n"
+
+	// Operators not at target version
+	output += "## Cluster Operators\n\n"
+	opList, err := params.MustGatherProvider.ListResources(params.Context, parseGVK("config.openshift.io/v1", "ClusterOperator"), "", api.ListOptions{})
+	if err == nil {
+		operators := opList.Items
+		sort.Slice(operators, func(i, j int) bool { return operators[i].GetName() < operators[j].GetName() })
+
+		atTarget := 0
+		unknownVersion := 0
+		for i := range operators {
+			op := &operators[i]
+			name := op.GetName()
+			version := operatorVersion(op)
+
+			degraded := getConditionStatus(op, "Degraded") == "True"
+			unavailable := getConditionStatus(op, "Available") == "False"
+			progressing := getConditionStatus(op, "Progressing") == "True"
+
+			switch {
+			case version == "":
+				// An operator that never reported a version can't be assumed to be at target
+				unknownVersion++
+				output += fmt.Sprintf("⚠ %-40s version unknown (no operator version reported)\n", name)
+			case targetVersion == "":
+				output += fmt.Sprintf("⚠ %-40s at %s (target unknown)\n", name, version)
+			case version == targetVersion:
+				atTarget++
+			default:
+				output += fmt.Sprintf("✗ %-40s at %s (target %s)", name, version, targetVersion)
+				if progressing {
+					output += " [Progressing]"
+				}
+				output += "\n"
+				blockers = append(blockers, &upgradeBlocker{
+					Priority: 2,
+					Summary:  fmt.Sprintf("Operator %s has not reached %s (at %s)", name, targetVersion, version),
+					Details:  operatorProblemMessages(op),
+				})
+			}
+
+			if degraded || unavailable {
+				state := "Degraded"
+				if unavailable {
+					state = "Unavailable"
+				}
+				output += fmt.Sprintf("⚠ %-40s is %s\n", name, state)
+				blockers = append(blockers, &upgradeBlocker{
+					Priority: 1,
+					Summary:  fmt.Sprintf("Operator %s is %s", name, state),
+					Details:  operatorProblemMessages(op),
+				})
+			}
+		}
+		if targetVersion == "" {
+			output += fmt.Sprintf("\nTarget version unknown; versions of %d operators not compared\n\n", len(operators))
+		} else {
+			output += fmt.Sprintf("\n%d of %d operators at target version", atTarget, len(operators))
+			if unknownVersion > 0 {
+				output += fmt.Sprintf(", %d with unknown version", unknownVersion)
+			}
+			output += "\n\n"
+		}
+	} else {
+		output += "  (cluster operators not available)\n\n"
+	}
+
+	// MachineConfigPools
+	output += "## MachineConfigPools\n\n"
+	pools := listMachineConfigPools(params)
+	if len(pools) == 0 {
+		output += "  (no MachineConfigPools found in must-gather)\n\n"
+	} else {
+		output += fmt.Sprintf("%-20s %-8s %-8s %-8s %-8s %-8s %s\n", "POOL", "MACHINES", "UPDATED", "READY", "UNAVAIL", "DEGRADED", "STATE")
+		output += strings.Repeat("-", 80) + "\n"
+
+		for _, pool := range pools {
+			machineCount, _, _ := unstructured.NestedInt64(pool.Object, "status", "machineCount")
+			updated, _, _ := unstructured.NestedInt64(pool.Object, "status", "updatedMachineCount")
+			ready, _, _ := unstructured.NestedInt64(pool.Object, "status", "readyMachineCount")
+			unavailable, _, _ := unstructured.NestedInt64(pool.Object, "status", "unavailableMachineCount")
+			degraded, _, _ := unstructured.NestedInt64(pool.Object, "status", "degradedMachineCount")
+			paused, _, _ := unstructured.NestedBool(pool.Object, "spec", "paused")
+
+			state := "Updated"
+			if getConditionStatus(pool, "Degraded") == "True" {
+				state = "Degraded"
+			} else if getConditionStatus(pool, "Updating") == "True" {
+				state = "Updating"
+			}
+			if paused {
+				state += ",Paused"
+			}
+
+			output += fmt.Sprintf("%-20s %-8d %-8d %-8d %-8d %-8d %s\n",
+				truncate(pool.GetName(), 20), machineCount, updated, ready, unavailable, degraded, state)
+
+			if getConditionStatus(pool, "Degraded") == "True" {
+				details := make([]string, 0)
+				for _, condType := range []string{"NodeDegraded", "RenderDegraded", "Degraded"} {
+					if cond := getCondition(pool, condType); cond != nil {
+						if status, _ := cond["status"].(string); status == "True" {
+							if message, _ := cond["message"].(string); message != "" {
+								details = append(details, fmt.Sprintf("%s: %s", condType, truncate(message, 250)))
+							}
+						}
+					}
+				}
+				blockers = append(blockers, &upgradeBlocker{
+					Priority: 3,
+					Summary:  fmt.Sprintf("MachineConfigPool %s is degraded (%d degraded machines)", pool.GetName(), degraded),
+					Details:  details,
+				})
+			}
+
+			if paused && updated < machineCount {
+				blockers = append(blockers, &upgradeBlocker{
+					Priority: 3,
+					Summary:  fmt.Sprintf("MachineConfigPool %s is paused with %d of %d machines updated", pool.GetName(), updated, machineCount),
+				})
+			}
+		}
+		output += "\n"
+	}
+
+	// Nodes still on an old rendered config
+	output += "## Node Machine Configs\n\n"
+	nodeList, err := params.MustGatherProvider.ListResources(params.Context, parseGVK("v1", "Node"), "", api.ListOptions{})
+	if err == nil {
+		nodes := nodeList.Items
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].GetName() < nodes[j].GetName() })
+
+		behind := 0
+		for i := range nodes {
+			node := &nodes[i]
+			annotations := node.GetAnnotations()
+			current := annotations[mcoCurrentConfigAnnotation]
+			desired := annotations[mcoDesiredConfigAnnotation]
+			state := annotations[mcoStateAnnotation]
+			reason := annotations[mcoReasonAnnotation]
+
+			pool := poolForNode(pools, node)
+			poolTarget := ""
+			if pool != nil {
+				poolTarget, _, _ = unstructured.NestedString(pool.Object, "status", "configuration", "name")
+			}
+
+			onOldConfig := (desired != "" && current != desired) || (poolTarget != "" && current != poolTarget)
+			nodeStatus := getNodeStatus(node)
+			unschedulable, _, _ := unstructured.NestedBool(node.Object, "spec", "unschedulable")
+
+			if !onOldConfig && state != "Degraded" && nodeStatus == "Ready" {
+				continue
+			}
+			behind++
+
+			output += fmt.Sprintf("✗ %s (%s)\n", node.GetName(), nodeStatus)
+			output += fmt.Sprintf("    Current: %s\n", current)
+			output += fmt.Sprintf("    Desired: %s\n", desired)
+			if poolTarget != "" && poolTarget != desired {
+				output += fmt.Sprintf("    Pool Target: %s\n", poolTarget)
+			}
+			if state != "" {
+				output += fmt.Sprintf("    MCD State: %s\n", state)
+			}
+			if reason != "" {
+				output += fmt.Sprintf("    Reason: %s\n", truncate(reason, 250))
+			}
+			if unschedulable {
+				output += "    Cordoned: yes (drain may be in progress)\n"
+			}
+
+			details := make([]string, 0)
+			if reason != "" {
+				details = append(details, truncate(reason, 250))
+			}
+			if unschedulable {
+				details = append(details, "node is cordoned")
+			}
+
+			switch {
+			case state == "Degraded":
+				blockers = append(blockers, &upgradeBlocker{
+					Priority: 3,
+					Summary:  fmt.Sprintf("Node %s machine-config-daemon is Degraded", node.GetName()),
+					Details:  details,
+				})
+			case nodeStatus != "Ready":
+				blockers = append(blockers, &upgradeBlocker{
+					Priority: 3,
+					Summary:  fmt.Sprintf("Node %s is %s", node.GetName(), nodeStatus),
+					Details:  details,
+				})
+			default:
+				blockers = append(blockers, &upgradeBlocker{
+					Priority: 4,
+					Summary:  fmt.Sprintf("Node %s still on %s (state: %s)", node.GetName(), current, state),
+					Details:  details,
+				})
+			}
+		}
+
+		if behind == 0 {
+			output += fmt.Sprintf("✓ All %d nodes are Ready and on their desired config\n", len(nodes))
+		}
+		output += "\n"
+	}
+
+	// Blockers in order
+	sort.SliceStable(blockers, func(i, j int) bool {
+		return blockers[i].Priority < blockers[j].Priority
+	})
+
+	output += "## Blockers (in order)\n\n"
+	if len(blockers) == 0 {
+		output += "✓ No upgrade blockers found\n"
+	}
+	for i, blocker := range blockers {
+		output += fmt.Sprintf("%d. %s\n", i+1, blocker.Summary)
+		for _, detail := range blocker.Details {
+			if detail != "" {
+				output += fmt.Sprintf("   - %s\n", detail)
+			}
+		}
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// getCondition returns the status condition with the given type
+func getCondition(obj *unstructured.Unstructured, conditionType string) map[string]interface{} {
+	conditions, found, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if !found {
+		return nil
+	}
+
+	for _, cond := range conditions {
+		condMap, ok := cond.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condType, _ := condMap["type"].(string); condType == conditionType {
+			return condMap
+		}
+	}
+
+	return nil
+}
+
+// operatorVersion returns the "operator" entry from a ClusterOperator's status.versions
+func operatorVersion(op *unstructured.Unstructured) string {
+	versions, _, _ := unstructured.NestedSlice(op.Object, "status", "versions")
+	for _, ver := range versions {
+		verMap, ok := ver.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, _ := verMap["name"].(string); name == "operator" {
+			version, _ := verMap["version"].(string)
+			return version
+		}
+	}
+	return ""
+}
+
+// operatorProblemMessages returns messages from an operator's problem conditions
+func operatorProblemMessages(op *unstructured.Unstructured) []string {
+	messages := make([]string, 0)
+	for _, condType := range []string{"Degraded", "Available", "Progressing"} {
+		cond := getCondition(op, condType)
+		if cond == nil {
+			continue
+		}
+		status, _ := cond["status"].(string)
+		if (condType == "Available" && status != "False") || (condType != "Available" && status != "True") {
+			continue
+		}
+		message, _ := cond["message"].(string)
+		if message != "" {
+			messages = append(messages, fmt.Sprintf("%s: %s", condType, truncate(strings.ReplaceAll(message, "\n", " "), 250)))
+		}
+	}
+	return messages
+}
@@ -0,0 +1,34 @@
+package cnv
+
+import (
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"github.com/openshift/must-gather-mcp-server/pkg/toolsets"
+)
+
+// Toolset represents the OpenShift Virtualization toolset, enabled when the
+// must-gather includes KubeVirt resources (CNV must-gather image)
+type Toolset struct{}
+
+// Name returns the toolset name
+func (t *Toolset) Name() string {
+	return "cnv"
+}
+
+// GetTools returns all tools in this toolset
+func (t *Toolset) GetTools() []api.ServerTool {
+	tools := make([]api.ServerTool, 0)
+	tools = append(tools, vmTools()...)
+	return tools
+}
+
+// Detect reports whether OpenShift Virtualization resources were gathered
+func (t *Toolset) Detect(provider api.MustGatherProvider) bool {
+	return provider.HasGroupKind("hco.kubevirt.io", "HyperConverged") ||
+		provider.HasGroupKind("kubevirt.io", "KubeVirt") ||
+		provider.HasGroupKind("kubevirt.io", "VirtualMachine") ||
+		provider.HasGroupKind("kubevirt.io", "VirtualMachineInstance")
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
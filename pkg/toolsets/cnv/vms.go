@@ -0,0 +1,644 @@
+package cnv
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"github.com/openshift/must-gather-mcp-server/pkg/toolsets/internal/toolutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// cnvNamespace is where OpenShift Virtualization components run
+const cnvNamespace = "openshift-cnv"
+
+// vmErrorStatuses are printable VM statuses reporting a failure
+var vmErrorStatuses = map[string]bool{
+	"CrashLoopBackOff":         true,
+	"ErrImagePull":             true,
+	"ImagePullBackOff":         true,
+	"ErrorUnschedulable":       true,
+	"ErrorPvcNotFound":         true,
+	"ErrorDataVolumeNotFound":  true,
+	"DataVolumeError":          true,
+	"ErrorUnschedulableVolume": true,
+	"Unknown":                  true,
+}
+
+// vmTransitionalStatuses are printable VM statuses a VM should not stay in
+var vmTransitionalStatuses = map[string]bool{
+	"Provisioning":            true,
+	"WaitingForVolumeBinding": true,
+	"WaitingForReceiver":      true,
+	"Starting":                true,
+	"Migrating":               true,
+	"Paused":                  true,
+	"Stopping":                true,
+	"Terminating":             true,
+}
+
+func vmTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "cnv_health",
+				Description: "Show OpenShift Virtualization health: HyperConverged, KubeVirt and CDI status and conditions, unhealthy pods in openshift-cnv, and a summary of VirtualMachine statuses",
+				InputSchema: &jsonschema.Schema{
+					Type:       "object",
+					Properties: map[string]*jsonschema.Schema{},
+				},
+			},
+			Handler: cnvHealth,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "cnv_vms_list",
+				Description: "List VirtualMachines with their status, run strategy, node and IP, joined with their running VirtualMachineInstances",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"namespace": {
+							Type:        "string",
+							Description: "Only list VMs in this namespace (default: all namespaces)",
+						},
+						"problemsOnly": {
+							Type:        "boolean",
+							Description: "Only list VMs in an error or transitional status (default: false)",
+						},
+					},
+				},
+			},
+			Handler: cnvVMsList,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "cnv_vm_status",
+				Description: "Show the full status of a VirtualMachine: VM and VMI conditions, node, interfaces, live migrations, virt-launcher pod and DataVolumes",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"namespace": {
+							Type:        "string",
+							Description: "VirtualMachine namespace",
+						},
+						"name": {
+							Type:        "string",
+							Description: "VirtualMachine name",
+						},
+					},
+					Required: []string{"namespace", "name"},
+				},
+			},
+			Handler: cnvVMStatus,
+		},
+	}
+}
+
+func cnvHealth(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	provider := params.MustGatherProvider
+
+	output := "OpenShift Virtualization Health\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	problems := 0
+
+	// Operator custom resources
+	operands := []struct {
+		Title string
+		Group string
+		Kind  string
+	}{
+		{"HyperConverged", "hco.kubevirt.io", "HyperConverged"},
+		{"KubeVirt", "kubevirt.io", "KubeVirt"},
+		{"CDI", "cdi.kubevirt.io", "CDI"},
+		{"Network Addons", "networkaddonsoperator.network.kubevirt.io", "NetworkAddonsConfig"},
+		{"SSP", "ssp.kubevirt.io", "SSP"},
+	}
+	for _, operand := range operands {
+		list, err := provider.ListResourcesByGroupKind(params.Context, operand.Group, operand.Kind, "", api.ListOptions{})
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to list %s: %w", operand.Kind, err)), nil
+		}
+		items := list.Items
+		if len(items) == 0 {
+			continue
+		}
+		output += fmt.Sprintf("%s:\n", operand.Title)
+		output += strings.Repeat("-", 80) + "\n"
+		for i := range items {
+			item := &items[i]
+			phase, _, _ := unstructured.NestedString(item.Object, "status", "phase")
+			conditions := toolutil.ProblemConditions(item)
+
+			symbol := "✓"
+			if len(conditions) > 0 || (phase != "" && phase != "Deployed") {
+				symbol = "✗"
+				problems++
+			}
+			output += fmt.Sprintf("  %s %s", symbol, qualifiedName(item))
+			if phase != "" {
+				output += fmt.Sprintf("  phase=%s", phase)
+			}
+			if version, _, _ := unstructured.NestedString(item.Object, "status", "observedKubeVirtVersion"); version != "" {
+				output += fmt.Sprintf("  version=%s", version)
+			}
+			output += "\n"
+			for _, condition := range conditions {
+				output += fmt.Sprintf("      ✗ %s\n", condition)
+			}
+		}
+		output += "\n"
+	}
+
+	// Component pods
+	pods, err := provider.ListResources(params.Context, schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, cnvNamespace, api.ListOptions{})
+	if err == nil && len(pods.Items) > 0 {
+		unhealthy := make([]string, 0)
+		for i := range pods.Items {
+			if problem := podProblem(&pods.Items[i]); problem != "" {
+				unhealthy = append(unhealthy, fmt.Sprintf("%s: %s", pods.Items[i].GetName(), problem))
+			}
+		}
+		sort.Strings(unhealthy)
+
+		output += fmt.Sprintf("Pods in %s: %d total, %d unhealthy\n", cnvNamespace, len(pods.Items), len(unhealthy))
+		output += strings.Repeat("-", 80) + "\n"
+		if len(unhealthy) == 0 {
+			output += "  ✓ All pods running and ready\n"
+		}
+		for _, pod := range unhealthy {
+			output += fmt.Sprintf("  ✗ %s\n", pod)
+		}
+		problems += len(unhealthy)
+		output += "\n"
+	}
+
+	// VirtualMachines
+	vmList, err := provider.ListResourcesByGroupKind(params.Context, "kubevirt.io", "VirtualMachine", "", api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list VirtualMachines: %w", err)), nil
+	}
+	vms := vmList.Items
+	if len(vms) > 0 {
+		byStatus := make(map[string]int)
+		for i := range vms {
+			byStatus[vmStatus(&vms[i])]++
+		}
+		statuses := make([]string, 0, len(byStatus))
+		for status := range byStatus {
+			statuses = append(statuses, status)
+		}
+		sort.Slice(statuses, func(i, j int) bool { return byStatus[statuses[i]] > byStatus[statuses[j]] })
+
+		output += fmt.Sprintf("Virtual Machines: %d\n", len(vms))
+		output += strings.Repeat("-", 80) + "\n"
+		for _, status := range statuses {
+			output += fmt.Sprintf("%s %-30s %d\n", statusSymbol(status), status, byStatus[status])
+			if vmErrorStatuses[status] {
+				problems += byStatus[status]
+			}
+		}
+		output += "\n"
+	}
+
+	output += strings.Repeat("=", 80) + "\n"
+	if problems == 0 {
+		output += "✓ No OpenShift Virtualization problems found\n"
+	} else {
+		output += fmt.Sprintf("⚠ %d problem(s) found; use cnv_vms_list and cnv_vm_status for VM details\n", problems)
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+func cnvVMsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace := params.GetString("namespace", "")
+	problemsOnly := params.GetBool("problemsOnly", false)
+	provider := params.MustGatherProvider
+
+	vmList, err := provider.ListResourcesByGroupKind(params.Context, "kubevirt.io", "VirtualMachine", namespace, api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list VirtualMachines: %w", err)), nil
+	}
+	vmiList, err := provider.ListResourcesByGroupKind(params.Context, "kubevirt.io", "VirtualMachineInstance", namespace, api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list VirtualMachineInstances: %w", err)), nil
+	}
+	vms, vmis := vmList.Items, vmiList.Items
+	if len(vms) == 0 && len(vmis) == 0 {
+		return api.NewToolCallResult("No VirtualMachines found", nil), nil
+	}
+
+	vmiByKey := make(map[string]*unstructured.Unstructured, len(vmis))
+	for i := range vmis {
+		vmiByKey[qualifiedName(&vmis[i])] = &vmis[i]
+	}
+
+	output := "Virtual Machines\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+	output += fmt.Sprintf("    %-45s %-22s %-10s %-20s %s\n", "NAMESPACE/NAME", "STATUS", "STRATEGY", "NODE", "IP")
+	output += strings.Repeat("-", 80) + "\n"
+
+	shown := 0
+	for i := range vms {
+		vm := &vms[i]
+		key := qualifiedName(vm)
+		vmi := vmiByKey[key]
+		delete(vmiByKey, key)
+
+		status := vmStatus(vm)
+		if problemsOnly && !isProblemStatus(status, runStrategy(vm)) {
+			continue
+		}
+		shown++
+		line := fmt.Sprintf("%s %-45s %-22s %-10s %-20s %s", vmSymbol(status, runStrategy(vm)), toolutil.Truncate(key, 45), status, runStrategy(vm), vmiNode(vmi), vmiIP(vmi))
+		output += strings.TrimRight(line, " ") + "\n"
+	}
+
+	// VMIs created without a VirtualMachine
+	standalone := make([]string, 0, len(vmiByKey))
+	for key := range vmiByKey {
+		standalone = append(standalone, key)
+	}
+	sort.Strings(standalone)
+	for _, key := range standalone {
+		vmi := vmiByKey[key]
+		phase, _, _ := unstructured.NestedString(vmi.Object, "status", "phase")
+		if problemsOnly && phase == "Running" {
+			continue
+		}
+		shown++
+		line := fmt.Sprintf("%s %-45s %-22s %-10s %-20s %s", statusSymbol(phase), toolutil.Truncate(key, 45), phase, "(vmi)", vmiNode(vmi), vmiIP(vmi))
+		output += strings.TrimRight(line, " ") + "\n"
+	}
+
+	output += "\n"
+	output += fmt.Sprintf("Shown: %d of %d VMs", shown, len(vms))
+	if len(standalone) > 0 {
+		output += fmt.Sprintf(", %d standalone VMIs", len(standalone))
+	}
+	output += "\n"
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+func cnvVMStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace := params.GetString("namespace", "")
+	name := params.GetString("name", "")
+	if namespace == "" || name == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace and name parameters are required")), nil
+	}
+	provider := params.MustGatherProvider
+
+	vmList, err := provider.ListResourcesByGroupKind(params.Context, "kubevirt.io", "VirtualMachine", namespace, api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list VirtualMachines: %w", err)), nil
+	}
+	vmiList, err := provider.ListResourcesByGroupKind(params.Context, "kubevirt.io", "VirtualMachineInstance", namespace, api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list VirtualMachineInstances: %w", err)), nil
+	}
+	vm := findByName(vmList.Items, name)
+	vmi := findByName(vmiList.Items, name)
+	if vm == nil && vmi == nil {
+		return api.NewToolCallResult("", fmt.Errorf("VirtualMachine %s/%s not found", namespace, name)), nil
+	}
+
+	output := fmt.Sprintf("Virtual Machine: %s/%s\n", namespace, name)
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	if vm != nil {
+		status := vmStatus(vm)
+		strategy := runStrategy(vm)
+		ready, _, _ := unstructured.NestedBool(vm.Object, "status", "ready")
+		output += fmt.Sprintf("Status: %s %s\n", strings.TrimSpace(vmSymbol(status, strategy)), status)
+		output += fmt.Sprintf("Run Strategy: %s\n", strategy)
+		output += fmt.Sprintf("Ready: %t\n", ready)
+		output += formatConditions("VM Conditions", vm)
+
+		if requests, found, _ := unstructured.NestedSlice(vm.Object, "status", "stateChangeRequests"); found && len(requests) > 0 {
+			output += fmt.Sprintf("Pending State Change Requests: %d\n", len(requests))
+		}
+		output += "\n"
+	} else {
+		output += "⚠ No VirtualMachine object, this is a standalone VirtualMachineInstance\n\n"
+	}
+
+	// Instance
+	if vmi != nil {
+		phase, _, _ := unstructured.NestedString(vmi.Object, "status", "phase")
+		output += "Instance:\n"
+		output += strings.Repeat("-", 80) + "\n"
+		output += fmt.Sprintf("  Phase: %s %s\n", strings.TrimSpace(statusSymbol(phase)), phase)
+		if node := vmiNode(vmi); node != "" {
+			output += fmt.Sprintf("  Node: %s\n", node)
+		}
+		if guestOS, _, _ := unstructured.NestedString(vmi.Object, "status", "guestOSInfo", "prettyName"); guestOS != "" {
+			output += fmt.Sprintf("  Guest OS: %s\n", guestOS)
+		}
+		interfaces, _, _ := unstructured.NestedSlice(vmi.Object, "status", "interfaces")
+		for _, i := range interfaces {
+			iface, ok := i.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			ifName, _ := iface["name"].(string)
+			ip, _ := iface["ipAddress"].(string)
+			mac, _ := iface["mac"].(string)
+			output += fmt.Sprintf("  Interface %s: ip=%s mac=%s\n", ifName, ip, mac)
+		}
+		if migration, found, _ := unstructured.NestedMap(vmi.Object, "status", "migrationState"); found {
+			output += formatMigrationState(migration)
+		}
+		output += formatConditions("Instance Conditions", vmi)
+		output += "\n"
+	} else if vm != nil {
+		output += "Instance: none (VM is not running)\n\n"
+	}
+
+	// Live migrations
+	migrationList, err := provider.ListResourcesByGroupKind(params.Context, "kubevirt.io", "VirtualMachineInstanceMigration", namespace, api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list VirtualMachineInstanceMigrations: %w", err)), nil
+	}
+	migrations := migrationList.Items
+	related := make([]string, 0)
+	for i := range migrations {
+		vmiName, _, _ := unstructured.NestedString(migrations[i].Object, "spec", "vmiName")
+		if vmiName != name {
+			continue
+		}
+		phase, _, _ := unstructured.NestedString(migrations[i].Object, "status", "phase")
+		related = append(related, fmt.Sprintf("%s %s  phase=%s  created=%s", statusSymbol(phase), migrations[i].GetName(), phase, migrations[i].GetCreationTimestamp().UTC().Format("2006-01-02T15:04:05Z")))
+	}
+	if len(related) > 0 {
+		output += "Migrations:\n"
+		output += strings.Repeat("-", 80) + "\n"
+		output += strings.Join(related, "\n") + "\n\n"
+	}
+
+	// virt-launcher pods
+	pods, err := provider.ListResources(params.Context, schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, namespace, api.ListOptions{LabelSelector: "vm.kubevirt.io/name=" + name})
+	if err == nil && len(pods.Items) > 0 {
+		output += "virt-launcher Pods:\n"
+		output += strings.Repeat("-", 80) + "\n"
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			phase, _, _ := unstructured.NestedString(pod.Object, "status", "phase")
+			node, _, _ := unstructured.NestedString(pod.Object, "spec", "nodeName")
+			symbol := "✓"
+			problem := podProblem(pod)
+			if problem != "" {
+				symbol = "✗"
+			}
+			output += fmt.Sprintf("  %s %s  phase=%s  node=%s\n", symbol, pod.GetName(), phase, node)
+			if problem != "" {
+				output += fmt.Sprintf("      %s\n", problem)
+			}
+		}
+		output += "\n"
+	}
+
+	// DataVolumes
+	if vm != nil {
+		dvList, err := provider.ListResourcesByGroupKind(params.Context, "cdi.kubevirt.io", "DataVolume", namespace, api.ListOptions{})
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to list DataVolumes: %w", err)), nil
+		}
+		dataVolumes := dvList.Items
+		names := vmDataVolumeNames(vm)
+		lines := make([]string, 0)
+		for _, dvName := range names {
+			dv := findByName(dataVolumes, dvName)
+			if dv == nil {
+				lines = append(lines, fmt.Sprintf("  ⚠ %s  not found in the must-gather", dvName))
+				continue
+			}
+			phase, _, _ := unstructured.NestedString(dv.Object, "status", "phase")
+			progress, _, _ := unstructured.NestedString(dv.Object, "status", "progress")
+			symbol := "✓"
+			if phase != "Succeeded" {
+				symbol = "⚠"
+			}
+			if strings.Contains(phase, "Failed") || strings.Contains(phase, "Error") {
+				symbol = "✗"
+			}
+			line := fmt.Sprintf("  %s %s  phase=%s", symbol, dvName, phase)
+			if progress != "" && phase != "Succeeded" {
+				line += fmt.Sprintf("  progress=%s", progress)
+			}
+			lines = append(lines, line)
+			for _, condition := range toolutil.ProblemConditions(dv) {
+				lines = append(lines, fmt.Sprintf("      ✗ %s", condition))
+			}
+		}
+		if len(lines) > 0 {
+			output += "DataVolumes:\n"
+			output += strings.Repeat("-", 80) + "\n"
+			output += strings.Join(lines, "\n") + "\n"
+		}
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// vmStatus returns the printable status shown by oc get vm
+func vmStatus(vm *unstructured.Unstructured) string {
+	if status, _, _ := unstructured.NestedString(vm.Object, "status", "printableStatus"); status != "" {
+		return status
+	}
+	return "Unknown"
+}
+
+// runStrategy returns spec.runStrategy, or its equivalent for the deprecated spec.running
+func runStrategy(vm *unstructured.Unstructured) string {
+	if strategy, _, _ := unstructured.NestedString(vm.Object, "spec", "runStrategy"); strategy != "" {
+		return strategy
+	}
+	if running, found, _ := unstructured.NestedBool(vm.Object, "spec", "running"); found {
+		if running {
+			return "Always"
+		}
+		return "Halted"
+	}
+	return ""
+}
+
+// isProblemStatus reports statuses in error, transitional, or stopped although expected to run.
+// RerunOnFailure leaves a VM Stopped after the guest shuts down cleanly, so only Always counts.
+func isProblemStatus(status, strategy string) bool {
+	if vmErrorStatuses[status] || vmTransitionalStatuses[status] {
+		return true
+	}
+	return status == "Stopped" && strategy == "Always"
+}
+
+func vmSymbol(status, strategy string) string {
+	switch {
+	case vmErrorStatuses[status]:
+		return "  ✗"
+	case isProblemStatus(status, strategy):
+		return "  ⚠"
+	default:
+		return "  ✓"
+	}
+}
+
+// statusSymbol returns a symbol for a VM status or VMI/migration phase
+func statusSymbol(status string) string {
+	switch status {
+	case "Running", "Succeeded", "Stopped":
+		return "  ✓"
+	case "Failed", "Unknown":
+		return "  ✗"
+	}
+	if vmErrorStatuses[status] {
+		return "  ✗"
+	}
+	return "  ⚠"
+}
+
+func vmiNode(vmi *unstructured.Unstructured) string {
+	if vmi == nil {
+		return ""
+	}
+	node, _, _ := unstructured.NestedString(vmi.Object, "status", "nodeName")
+	return node
+}
+
+func vmiIP(vmi *unstructured.Unstructured) string {
+	if vmi == nil {
+		return ""
+	}
+	interfaces, _, _ := unstructured.NestedSlice(vmi.Object, "status", "interfaces")
+	for _, i := range interfaces {
+		if iface, ok := i.(map[string]interface{}); ok {
+			if ip, _ := iface["ipAddress"].(string); ip != "" {
+				return ip
+			}
+		}
+	}
+	return ""
+}
+
+// vmDataVolumeNames returns the DataVolumes a VM uses, from its templates and volumes
+func vmDataVolumeNames(vm *unstructured.Unstructured) []string {
+	seen := make(map[string]bool)
+	names := make([]string, 0)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	templates, _, _ := unstructured.NestedSlice(vm.Object, "spec", "dataVolumeTemplates")
+	for _, t := range templates {
+		if template, ok := t.(map[string]interface{}); ok {
+			name, _, _ := unstructured.NestedString(template, "metadata", "name")
+			add(name)
+		}
+	}
+	volumes, _, _ := unstructured.NestedSlice(vm.Object, "spec", "template", "spec", "volumes")
+	for _, v := range volumes {
+		if volume, ok := v.(map[string]interface{}); ok {
+			name, _, _ := unstructured.NestedString(volume, "dataVolume", "name")
+			add(name)
+		}
+	}
+	return names
+}
+
+func formatMigrationState(migration map[string]interface{}) string {
+	sourceNode, _ := migration["sourceNode"].(string)
+	targetNode, _ := migration["targetNode"].(string)
+	completed, _ := migration["completed"].(bool)
+	failed, _ := migration["failed"].(bool)
+
+	state := "in progress"
+	symbol := "⚠"
+	switch {
+	case failed:
+		state, symbol = "failed", "✗"
+	case completed:
+		state, symbol = "completed", "✓"
+	}
+	output := fmt.Sprintf("  Last Migration: %s %s (%s -> %s)\n", symbol, state, sourceNode, targetNode)
+	if reason, _ := migration["failureReason"].(string); reason != "" {
+		output += fmt.Sprintf("      %s\n", reason)
+	}
+	return output
+}
+
+func formatConditions(title string, obj *unstructured.Unstructured) string {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if len(conditions) == 0 {
+		return ""
+	}
+	output := fmt.Sprintf("%s:\n", title)
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condType, _ := cond["type"].(string)
+		status, _ := cond["status"].(string)
+		reason, _ := cond["reason"].(string)
+		message, _ := cond["message"].(string)
+		line := fmt.Sprintf("    %-20s %-6s %s", condType, status, reason)
+		if message != "" {
+			line += fmt.Sprintf(": %s", toolutil.Truncate(message, 150))
+		}
+		output += strings.TrimRight(line, " ") + "\n"
+	}
+	return output
+}
+
+// podProblem returns why a pod is unhealthy, or "" when it is running and ready
+func podProblem(pod *unstructured.Unstructured) string {
+	phase, _, _ := unstructured.NestedString(pod.Object, "status", "phase")
+	if phase == "Succeeded" {
+		return ""
+	}
+	statuses, _, _ := unstructured.NestedSlice(pod.Object, "status", "containerStatuses")
+	for _, s := range statuses {
+		status, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ready, _ := status["ready"].(bool); ready {
+			continue
+		}
+		containerName, _ := status["name"].(string)
+		if reason, _, _ := unstructured.NestedString(status, "state", "waiting", "reason"); reason != "" {
+			return fmt.Sprintf("container %s %s", containerName, reason)
+		}
+		if reason, _, _ := unstructured.NestedString(status, "state", "terminated", "reason"); reason != "" {
+			return fmt.Sprintf("container %s terminated (%s)", containerName, reason)
+		}
+		return fmt.Sprintf("container %s not ready", containerName)
+	}
+	if phase != "Running" {
+		return fmt.Sprintf("phase %s", phase)
+	}
+	return ""
+}
+
+func findByName(items []unstructured.Unstructured, name string) *unstructured.Unstructured {
+	for i := range items {
+		if items[i].GetName() == name {
+			return &items[i]
+		}
+	}
+	return nil
+}
+
+func qualifiedName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
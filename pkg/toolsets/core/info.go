@@ -0,0 +1,298 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+)
+
+// expectedDirectories are collected by the default gather scripts; their
+// absence usually means a gather script failed or timed out
+var expectedDirectories = []struct {
+	Name        string
+	Description string
+	Optional    bool
+}{
+	{"cluster-scoped-resources", "cluster-wide resources", false},
+	{"namespaces", "namespaced resources and pod logs", false},
+	{"etcd_info", "etcd health and object counts", false},
+	{"monitoring", "Prometheus and Alertmanager data", false},
+	{"network_logs", "network scale and OVN data", false},
+	{"nodes", "node diagnostics and kubelet logs", false},
+	{"host_service_logs", "host service journals", false},
+	{"pod_network_connectivity_check", "connectivity check results", true},
+	{"static-pods", "control plane static pods", true},
+	{"audit_logs", "API server audit logs (gather_audit_logs)", true},
+}
+
+// expectedNamespaces are inspected by the default gather and used by most tools
+var expectedNamespaces = []string{
+	"openshift-etcd",
+	"openshift-kube-apiserver",
+	"openshift-kube-controller-manager",
+	"openshift-kube-scheduler",
+	"openshift-machine-config-operator",
+	"openshift-monitoring",
+	"openshift-ingress",
+	"openshift-dns",
+}
+
+// collectionLogNames are the logs written by oc adm must-gather and the gather scripts
+var collectionLogNames = []string{"must-gather.log", "must-gather.logs", "gather-debug.log"}
+
+// collectionErrorPattern matches failures reported while gathering
+var collectionErrorPattern = regexp.MustCompile(`(?i)(\berror\b|\bfailed\b|timed out|timeout|context deadline exceeded|\bunable to\b|no such file|forbidden)`)
+
+// volatilePattern strips timestamps and numbers so repeated errors group together
+var volatilePattern = regexp.MustCompile(`^\[[^\]]*\]\s*|\d{4}-\d{2}-\d{2}[T ][\d:.]+Z?|\b\d+(\.\d+)?(ms|s|m)?\b`)
+
+func infoTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "mustgather_info",
+				Description: "Report must-gather collection health: version, collection time, resource counts, expected directories present or missing, errors from the collection log, and resource files the loader skipped",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"maxErrors": {
+							Type:        "integer",
+							Description: "Maximum distinct collection log errors and skipped files to list (default: 30)",
+						},
+					},
+				},
+			},
+			Handler: mustgatherInfo,
+		},
+	}
+}
+
+func mustgatherInfo(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	maxErrors := params.GetInt("maxErrors", 30)
+
+	metadata := params.MustGatherProvider.GetMetadata()
+	primaryName, containerDir := ".", metadata.Path
+	for _, dir := range metadata.GatherDirs {
+		if dir.Primary {
+			primaryName, containerDir = dir.Name, dir.Path
+		}
+	}
+
+	problems := 0
+
+	output := "Must-Gather Collection Report\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	output += fmt.Sprintf("Path: %s\n", metadata.Path)
+	if len(metadata.GatherDirs) > 1 {
+		output += "Gather Directories:\n"
+		for _, dir := range metadata.GatherDirs {
+			primary := ""
+			if dir.Primary {
+				primary = " (primary)"
+			}
+			output += fmt.Sprintf("  - %s%s\n", dir.Name, primary)
+		}
+	} else if primaryName != "." {
+		output += fmt.Sprintf("Container Directory: %s\n", primaryName)
+	}
+	if metadata.Version != "" {
+		output += fmt.Sprintf("Version: %s\n", metadata.Version)
+	}
+	if !metadata.StartTime.IsZero() {
+		output += fmt.Sprintf("Started: %s\n", metadata.StartTime.Format(time.RFC3339))
+	}
+	if !metadata.EndTime.IsZero() {
+		output += fmt.Sprintf("Ended: %s\n", metadata.EndTime.Format(time.RFC3339))
+		if !metadata.StartTime.IsZero() {
+			output += fmt.Sprintf("Duration: %s\n", metadata.EndTime.Sub(metadata.StartTime))
+		}
+	} else {
+		output += "Ended: unknown (timestamp file has no end time, the gather may not have completed)\n"
+		problems++
+	}
+	output += fmt.Sprintf("Resources: %d across %d namespaces, %d resource types\n", metadata.ResourceCount, metadata.NamespaceCount, len(params.MustGatherProvider.ListGVKs()))
+
+	// Directories
+	output += "\nDirectories:\n"
+	output += strings.Repeat("-", 80) + "\n"
+	for _, dir := range expectedDirectories {
+		entries, err := params.MustGatherProvider.Files().ReadDir(dir.Name)
+		switch {
+		case err == nil && len(entries) > 0:
+			output += fmt.Sprintf("  ✓ %-32s %d entries\n", dir.Name, len(entries))
+		case err == nil:
+			output += fmt.Sprintf("  ⚠ %-32s empty (%s)\n", dir.Name, dir.Description)
+			problems++
+		case dir.Optional:
+			output += fmt.Sprintf("  - %-32s not collected (optional: %s)\n", dir.Name, dir.Description)
+		default:
+			output += fmt.Sprintf("  ✗ %-32s missing (%s)\n", dir.Name, dir.Description)
+			problems++
+		}
+	}
+
+	// Namespaces
+	namespaces, err := params.MustGatherProvider.ListNamespaces(params.Context)
+	if err == nil {
+		present := make(map[string]bool, len(namespaces))
+		for _, ns := range namespaces {
+			present[ns] = true
+		}
+		missing := make([]string, 0)
+		for _, ns := range expectedNamespaces {
+			if !present[ns] {
+				missing = append(missing, ns)
+			}
+		}
+		if len(missing) > 0 {
+			output += "\nMissing Namespaces:\n"
+			output += strings.Repeat("-", 80) + "\n"
+			for _, ns := range missing {
+				output += fmt.Sprintf("  ✗ %s\n", ns)
+			}
+			problems += len(missing)
+		}
+	}
+
+	// Collection log errors
+	output += "\nCollection Log:\n"
+	output += strings.Repeat("-", 80) + "\n"
+	logsFound := 0
+	for _, dir := range uniqueDirs(".", primaryName) {
+		for _, name := range collectionLogNames {
+			errors, lines, err := scanCollectionLog(params.MustGatherProvider.RootFiles(), path.Join(dir, name))
+			if err != nil {
+				continue
+			}
+			logsFound++
+
+			output += fmt.Sprintf("  %s (%d lines, %d error lines)\n", name, lines, totalCount(errors))
+			for i, e := range errors {
+				if i >= maxErrors {
+					output += fmt.Sprintf("    ... and %d more distinct errors\n", len(errors)-maxErrors)
+					break
+				}
+				count := ""
+				if e.Count > 1 {
+					count = fmt.Sprintf(" (x%d)", e.Count)
+				}
+				output += fmt.Sprintf("    ✗ %s%s\n", truncate(e.Line, 200), count)
+			}
+			problems += len(errors)
+		}
+	}
+	if logsFound == 0 {
+		output += "  No collection log found (must-gather.log is written next to the container directory)\n"
+	}
+
+	// Skipped files
+	output += "\nSkipped Resource Files:\n"
+	output += strings.Repeat("-", 80) + "\n"
+	if len(metadata.LoadErrors) == 0 {
+		output += "  ✓ All resource files loaded\n"
+	} else {
+		for i, loadErr := range metadata.LoadErrors {
+			if i >= maxErrors {
+				output += fmt.Sprintf("  ... and %d more\n", len(metadata.LoadErrors)-maxErrors)
+				break
+			}
+			rel, err := filepath.Rel(containerDir, loadErr.Path)
+			if err != nil {
+				rel = loadErr.Path
+			}
+			output += fmt.Sprintf("  ✗ %s [%s error", rel, loadErr.Type)
+			if loadErr.GVK != "" {
+				output += fmt.Sprintf(", likely %s", loadErr.GVK)
+			}
+			output += "]\n"
+			output += fmt.Sprintf("      %s\n", truncate(loadErr.Error, 200))
+		}
+		problems += len(metadata.LoadErrors)
+	}
+
+	output += "\n" + strings.Repeat("=", 80) + "\n"
+	if problems == 0 {
+		output += "✓ Collection looks complete\n"
+	} else {
+		output += fmt.Sprintf("⚠ %d collection issue(s) found; results from tools relying on missing data will be incomplete\n", problems)
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// collectionError is a distinct error line from a collection log
+type collectionError struct {
+	Line  string
+	Count int
+}
+
+// scanCollectionLog returns distinct error lines, most frequent first
+func scanCollectionLog(files api.GatherFS, name string) ([]collectionError, int, error) {
+	file, err := files.Open(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	byKey := make(map[string]*collectionError)
+	order := make([]string, 0)
+	lines := 0
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || !collectionErrorPattern.MatchString(line) {
+			continue
+		}
+
+		key := volatilePattern.ReplaceAllString(line, "")
+		if e, ok := byKey[key]; ok {
+			e.Count++
+			continue
+		}
+		byKey[key] = &collectionError{Line: line, Count: 1}
+		order = append(order, key)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, lines, err
+	}
+
+	errors := make([]collectionError, 0, len(order))
+	for _, key := range order {
+		errors = append(errors, *byKey[key])
+	}
+	sort.SliceStable(errors, func(i, j int) bool { return errors[i].Count > errors[j].Count })
+
+	return errors, lines, nil
+}
+
+func totalCount(errors []collectionError) int {
+	total := 0
+	for _, e := range errors {
+		total += e.Count
+	}
+	return total
+}
+
+func uniqueDirs(dirs ...string) []string {
+	seen := make(map[string]bool)
+	result := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if !seen[dir] {
+			seen[dir] = true
+			result = append(result, dir)
+		}
+	}
+	return result
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/openshift/must-gather-mcp-server/pkg/api"
@@ -75,6 +76,17 @@ func resourcesGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 		return api.NewToolCallResult("", fmt.Errorf("failed to marshal resource: %w", err)), nil
 	}
 
+	// With several gather directories (plugin images), note where the object came from
+	if len(params.MustGatherProvider.GetMetadata().GatherDirs) > 1 {
+		if sources := params.MustGatherProvider.GetResourceSources(gvk, namespace, name); len(sources) > 0 {
+			header := fmt.Sprintf("# Source: %s\n", sources[0])
+			if len(sources) > 1 {
+				header += fmt.Sprintf("# Also gathered in: %s\n", strings.Join(sources[1:], ", "))
+			}
+			output = append([]byte(header), output...)
+		}
+	}
+
 	return api.NewToolCallResult(string(output), nil), nil
 }
 
@@ -16,8 +16,10 @@ func (t *Toolset) Name() string {
 // GetTools returns all tools in this toolset
 func (t *Toolset) GetTools() []api.ServerTool {
 	tools := make([]api.ServerTool, 0)
+	tools = append(tools, infoTools()...)
 	tools = append(tools, resourcesTools()...)
 	tools = append(tools, namespacesTools()...)
+	tools = append(tools, workloadsTools()...)
 	return tools
 }
 
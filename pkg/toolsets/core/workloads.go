@@ -0,0 +1,424 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func workloadsTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "workloads_status",
+				Description: "Evaluate health of Deployments, StatefulSets and DaemonSets: desired vs ready/available/updated replicas, stalled rollouts, misscheduled daemon pods and StatefulSet revision mismatches, with a summary of the worst offenders",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"namespace": {
+							Type:        "string",
+							Description: "Namespace to evaluate (empty for all namespaces)",
+						},
+						"kind": {
+							Type:        "string",
+							Description: "Filter by workload kind: all, deployment, statefulset, daemonset (default: all)",
+							Enum:        []interface{}{"all", "deployment", "statefulset", "daemonset"},
+						},
+						"showHealthy": {
+							Type:        "boolean",
+							Description: "Also list healthy workloads (default: false)",
+						},
+						"top": {
+							Type:        "integer",
+							Description: "Number of worst offenders to show in the summary (default: 10)",
+						},
+					},
+				},
+			},
+			Handler: workloadsStatus,
+		},
+	}
+}
+
+// workloadStatus holds the evaluated health of a single workload
+type workloadStatus struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Desired   int64
+	Ready     int64
+	Available int64
+	Updated   int64
+	Paused    bool
+	Issues    []string
+	Notes     []string
+	Score     int64
+}
+
+func (w *workloadStatus) healthy() bool {
+	return len(w.Issues) == 0
+}
+
+func workloadsStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace := params.GetString("namespace", "")
+	kindFilter := params.GetString("kind", "all")
+	showHealthy := params.GetBool("showHealthy", false)
+	top := params.GetInt("top", 10)
+
+	evaluators := []struct {
+		kind     string
+		filter   string
+		evaluate func(*unstructured.Unstructured) *workloadStatus
+	}{
+		{"Deployment", "deployment", evaluateDeployment},
+		{"StatefulSet", "statefulset", evaluateStatefulSet},
+		{"DaemonSet", "daemonset", evaluateDaemonSet},
+	}
+
+	var statuses []*workloadStatus
+	kindCounts := make(map[string]int)
+
+	for _, e := range evaluators {
+		if kindFilter != "all" && kindFilter != e.filter {
+			continue
+		}
+
+		gvk := parseGVK("apps/v1", e.kind)
+		list, err := params.MustGatherProvider.ListResources(params.Context, gvk, namespace, api.ListOptions{})
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to list %s resources: %w", e.kind, err)), nil
+		}
+
+		for i := range list.Items {
+			statuses = append(statuses, e.evaluate(&list.Items[i]))
+			kindCounts[e.kind]++
+		}
+	}
+
+	output := "Workload Health Status\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	if namespace != "" {
+		output += fmt.Sprintf("Namespace: %s\n", namespace)
+	}
+
+	if len(statuses) == 0 {
+		output += "No workloads found matching the criteria.\n"
+		return api.NewToolCallResult(output, nil), nil
+	}
+
+	// Sort worst first, then by kind/namespace/name for stable output
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Score != statuses[j].Score {
+			return statuses[i].Score > statuses[j].Score
+		}
+		if statuses[i].Kind != statuses[j].Kind {
+			return statuses[i].Kind < statuses[j].Kind
+		}
+		if statuses[i].Namespace != statuses[j].Namespace {
+			return statuses[i].Namespace < statuses[j].Namespace
+		}
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	unhealthy := make([]*workloadStatus, 0)
+	for _, s := range statuses {
+		if !s.healthy() {
+			unhealthy = append(unhealthy, s)
+		}
+	}
+
+	output += fmt.Sprintf("Evaluated: %d workloads (Deployments: %d, StatefulSets: %d, DaemonSets: %d)\n",
+		len(statuses), kindCounts["Deployment"], kindCounts["StatefulSet"], kindCounts["DaemonSet"])
+	output += fmt.Sprintf("Healthy: %d | Unhealthy: %d\n\n", len(statuses)-len(unhealthy), len(unhealthy))
+
+	if len(unhealthy) == 0 {
+		output += "✓ All workloads have their desired replicas ready and up to date.\n\n"
+	} else {
+		displayTop := top
+		if displayTop <= 0 || displayTop > len(unhealthy) {
+			displayTop = len(unhealthy)
+		}
+
+		output += fmt.Sprintf("Worst Offenders (top %d):\n", displayTop)
+		output += fmt.Sprintf("%-12s %-50s %-7s %-7s %-7s %s\n", "KIND", "NAMESPACE/NAME", "READY", "AVAIL", "UPDATED", "ISSUES")
+		output += strings.Repeat("-", 100) + "\n"
+
+		for _, s := range unhealthy[:displayTop] {
+			output += fmt.Sprintf("%-12s %-50s %-7s %-7s %-7s %d\n",
+				s.Kind,
+				truncate(s.Namespace+"/"+s.Name, 50),
+				fmt.Sprintf("%d/%d", s.Ready, s.Desired),
+				fmt.Sprintf("%d/%d", s.Available, s.Desired),
+				fmt.Sprintf("%d/%d", s.Updated, s.Desired),
+				len(s.Issues))
+		}
+		output += "\n"
+
+		output += "Unhealthy Workloads:\n"
+		output += strings.Repeat("-", 80) + "\n"
+		for _, s := range unhealthy {
+			output += formatWorkloadStatus(s)
+		}
+	}
+
+	if showHealthy {
+		output += "Healthy Workloads:\n"
+		output += strings.Repeat("-", 80) + "\n"
+		for _, s := range statuses {
+			if s.healthy() {
+				output += formatWorkloadStatus(s)
+			}
+		}
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+func formatWorkloadStatus(s *workloadStatus) string {
+	symbol := "✓"
+	if !s.healthy() {
+		symbol = "✗"
+	}
+
+	output := fmt.Sprintf("%s %s %s/%s\n", symbol, s.Kind, s.Namespace, s.Name)
+	output += fmt.Sprintf("  Replicas: desired %d, ready %d, available %d, updated %d\n",
+		s.Desired, s.Ready, s.Available, s.Updated)
+	for _, issue := range s.Issues {
+		output += fmt.Sprintf("  - %s\n", issue)
+	}
+	for _, note := range s.Notes {
+		output += fmt.Sprintf("  • %s\n", note)
+	}
+	output += "\n"
+	return output
+}
+
+func evaluateDeployment(obj *unstructured.Unstructured) *workloadStatus {
+	s := &workloadStatus{
+		Kind:      "Deployment",
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+
+	// spec.replicas defaults to 1 when unset
+	s.Desired = 1
+	if replicas, ok := getInt64(obj, "spec", "replicas"); ok {
+		s.Desired = replicas
+	}
+	s.Ready, _ = getInt64(obj, "status", "readyReplicas")
+	s.Available, _ = getInt64(obj, "status", "availableReplicas")
+	s.Updated, _ = getInt64(obj, "status", "updatedReplicas")
+	s.Paused, _, _ = unstructured.NestedBool(obj.Object, "spec", "paused")
+
+	checkReplicaCounts(s)
+	checkObservedGeneration(obj, s)
+
+	if s.Paused && s.Updated >= s.Desired {
+		s.Notes = append(s.Notes, "Rollout is paused")
+	}
+
+	if cond := findCondition(obj, "Progressing"); cond != nil {
+		status, _ := cond["status"].(string)
+		reason, _ := cond["reason"].(string)
+		message, _ := cond["message"].(string)
+		if status == "False" || reason == "ProgressDeadlineExceeded" {
+			s.Issues = append(s.Issues, fmt.Sprintf("Stalled rollout: Progressing=%s (%s) %s", status, reason, message))
+			s.Score += 5
+		}
+	}
+
+	if cond := findCondition(obj, "Available"); cond != nil {
+		if status, _ := cond["status"].(string); status == "False" {
+			reason, _ := cond["reason"].(string)
+			s.Issues = append(s.Issues, fmt.Sprintf("Available=False (%s)", reason))
+			s.Score += 4
+		}
+	}
+
+	if cond := findCondition(obj, "ReplicaFailure"); cond != nil {
+		if status, _ := cond["status"].(string); status == "True" {
+			message, _ := cond["message"].(string)
+			s.Issues = append(s.Issues, fmt.Sprintf("ReplicaFailure: %s", message))
+			s.Score += 3
+		}
+	}
+
+	return s
+}
+
+func evaluateStatefulSet(obj *unstructured.Unstructured) *workloadStatus {
+	s := &workloadStatus{
+		Kind:      "StatefulSet",
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+
+	s.Desired = 1
+	if replicas, ok := getInt64(obj, "spec", "replicas"); ok {
+		s.Desired = replicas
+	}
+	s.Ready, _ = getInt64(obj, "status", "readyReplicas")
+	s.Updated, _ = getInt64(obj, "status", "updatedReplicas")
+	// availableReplicas is not reported by older clusters; fall back to ready
+	if available, ok := getInt64(obj, "status", "availableReplicas"); ok {
+		s.Available = available
+	} else {
+		s.Available = s.Ready
+	}
+
+	partition, _ := getInt64(obj, "spec", "updateStrategy", "rollingUpdate", "partition")
+	currentRevision, _, _ := unstructured.NestedString(obj.Object, "status", "currentRevision")
+	updateRevision, _, _ := unstructured.NestedString(obj.Object, "status", "updateRevision")
+
+	// With a partition only ordinals >= partition are expected to be updated
+	expectedUpdated := s.Desired
+	if partition > 0 {
+		expectedUpdated = s.Desired - partition
+		if expectedUpdated < 0 {
+			expectedUpdated = 0
+		}
+	}
+
+	if s.Ready < s.Desired {
+		s.Issues = append(s.Issues, fmt.Sprintf("Only %d of %d replicas ready", s.Ready, s.Desired))
+		s.Score += 2 * (s.Desired - s.Ready)
+	}
+	if s.Available < s.Desired && s.Available != s.Ready {
+		s.Issues = append(s.Issues, fmt.Sprintf("Only %d of %d replicas available", s.Available, s.Desired))
+		s.Score += s.Desired - s.Available
+	}
+
+	if currentRevision != "" && updateRevision != "" && currentRevision != updateRevision {
+		if partition > 0 && s.Updated >= expectedUpdated {
+			s.Notes = append(s.Notes, fmt.Sprintf("Rollout held by partition %d: %d replicas on update revision %s, remaining on %s",
+				partition, s.Updated, updateRevision, currentRevision))
+		} else {
+			s.Issues = append(s.Issues, fmt.Sprintf("Revision mismatch: current %s, update %s (%d of %d replicas updated)",
+				currentRevision, updateRevision, s.Updated, expectedUpdated))
+			s.Score += 3
+		}
+	} else if s.Updated < expectedUpdated {
+		s.Issues = append(s.Issues, fmt.Sprintf("Only %d of %d replicas updated", s.Updated, expectedUpdated))
+		s.Score += expectedUpdated - s.Updated
+	}
+
+	if partition > s.Desired {
+		s.Notes = append(s.Notes, fmt.Sprintf("Partition %d is greater than replicas %d; no pods will be updated", partition, s.Desired))
+	}
+
+	checkObservedGeneration(obj, s)
+
+	return s
+}
+
+func evaluateDaemonSet(obj *unstructured.Unstructured) *workloadStatus {
+	s := &workloadStatus{
+		Kind:      "DaemonSet",
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+	}
+
+	s.Desired, _ = getInt64(obj, "status", "desiredNumberScheduled")
+	s.Ready, _ = getInt64(obj, "status", "numberReady")
+	s.Available, _ = getInt64(obj, "status", "numberAvailable")
+	s.Updated, _ = getInt64(obj, "status", "updatedNumberScheduled")
+
+	current, _ := getInt64(obj, "status", "currentNumberScheduled")
+	misscheduled, _ := getInt64(obj, "status", "numberMisscheduled")
+
+	if current < s.Desired {
+		s.Issues = append(s.Issues, fmt.Sprintf("Daemon pod scheduled on %d of %d nodes", current, s.Desired))
+		s.Score += 2 * (s.Desired - current)
+	}
+	if misscheduled > 0 {
+		s.Issues = append(s.Issues, fmt.Sprintf("%d daemon pod(s) running on nodes that should not run them (misscheduled)", misscheduled))
+		s.Score += misscheduled
+	}
+
+	checkReplicaCounts(s)
+	checkObservedGeneration(obj, s)
+
+	return s
+}
+
+// checkReplicaCounts compares ready/available/updated counts against desired
+func checkReplicaCounts(s *workloadStatus) {
+	if s.Ready < s.Desired {
+		s.Issues = append(s.Issues, fmt.Sprintf("Only %d of %d replicas ready", s.Ready, s.Desired))
+		s.Score += 2 * (s.Desired - s.Ready)
+	}
+	if s.Available < s.Desired && s.Available != s.Ready {
+		s.Issues = append(s.Issues, fmt.Sprintf("Only %d of %d replicas available", s.Available, s.Desired))
+		s.Score += s.Desired - s.Available
+	}
+	if s.Updated < s.Desired {
+		// A paused rollout is held on purpose, so the outstanding replicas are not a failure
+		if s.Paused {
+			s.Notes = append(s.Notes, fmt.Sprintf("Rollout is paused: %d of %d replicas updated", s.Updated, s.Desired))
+		} else {
+			s.Issues = append(s.Issues, fmt.Sprintf("Rollout incomplete: %d of %d replicas updated", s.Updated, s.Desired))
+			s.Score += s.Desired - s.Updated
+		}
+	}
+}
+
+// checkObservedGeneration flags workloads whose latest spec has not been observed by their controller
+func checkObservedGeneration(obj *unstructured.Unstructured, s *workloadStatus) {
+	observed, found := getInt64(obj, "status", "observedGeneration")
+	if found && observed < obj.GetGeneration() {
+		s.Issues = append(s.Issues, fmt.Sprintf("Controller has not observed latest spec (generation %d, observed %d)",
+			obj.GetGeneration(), observed))
+		s.Score++
+	}
+}
+
+// findCondition returns the status condition with the given type
+func findCondition(obj *unstructured.Unstructured, conditionType string) map[string]interface{} {
+	conditions, found, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if !found {
+		return nil
+	}
+
+	for _, cond := range conditions {
+		condMap, ok := cond.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condType, _ := condMap["type"].(string); condType == conditionType {
+			return condMap
+		}
+	}
+
+	return nil
+}
+
+// getInt64 reads a numeric field which may have been decoded as int64 or float64
+func getInt64(obj *unstructured.Unstructured, fields ...string) (int64, bool) {
+	val, found, err := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+	if err != nil || !found {
+		return 0, false
+	}
+
+	switch v := val.(type) {
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case float64:
+		return int64(v), true
+	}
+
+	return 0, false
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
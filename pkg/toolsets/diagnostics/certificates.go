@@ -0,0 +1,438 @@
+package diagnostics
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"github.com/openshift/must-gather-mcp-server/pkg/mustgather"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func certificateTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "certificates_scan",
+				Description: "Scan TLS secrets and CA bundle configmaps in the must-gather for expired, near-expiry and mismatched certificates, relative to the time the must-gather was collected",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"namespace": {
+							Type:        "string",
+							Description: "Only scan this namespace (optional)",
+						},
+						"warningDays": {
+							Type:        "integer",
+							Description: "Flag certificates expiring within this many days of collection (default: 30)",
+						},
+						"showValid": {
+							Type:        "boolean",
+							Description: "Also list certificates without problems (default: false)",
+						},
+					},
+				},
+			},
+			Handler: certificatesScan,
+		},
+	}
+}
+
+// certificateFinding describes one certificate found in a secret or configmap
+type certificateFinding struct {
+	Source   string // kind/namespace/name[key]
+	Position int    // index within the PEM bundle
+	Cert     *x509.Certificate
+	Problems []string
+	Severity int // 0 ok, 1 warning, 2 critical
+}
+
+// certificateSource is a PEM bundle to inspect
+type certificateSource struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Key       string
+	PEM       []byte
+	IsChain   bool   // tls.crt: leaf followed by intermediates
+	KeyPEM    []byte // tls.key, when present
+	KeyMasked bool   // tls.key was masked, the key cannot be checked
+	CAPEM     []byte // ca.crt, when present
+}
+
+func (s certificateSource) String() string {
+	return fmt.Sprintf("%s %s/%s [%s]", s.Kind, s.Namespace, s.Name, s.Key)
+}
+
+func certificatesScan(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace := params.GetString("namespace", "")
+	warningDays := params.GetInt("warningDays", 30)
+	showValid := params.GetBool("showValid", false)
+
+	// Evaluate expiry at collection time, not now: the must-gather may be old
+	metadata := params.MustGatherProvider.GetMetadata()
+	refTime := metadata.EndTime
+	refLabel := "must-gather end time"
+	if refTime.IsZero() {
+		refTime = metadata.StartTime
+		refLabel = "must-gather start time"
+	}
+	if refTime.IsZero() {
+		refTime = time.Now()
+		refLabel = "current time (collection time unknown)"
+	}
+	warnBefore := refTime.Add(time.Duration(warningDays) * 24 * time.Hour)
+
+	sources, err := collectCertificateSources(params, namespace)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	findings := make([]*certificateFinding, 0)
+	sourceProblems := make([]string, 0)
+	keysSkipped := make([]string, 0)
+	for _, src := range sources {
+		certs, parseErrors := parsePEMCertificates(src.PEM)
+		for _, perr := range parseErrors {
+			sourceProblems = append(sourceProblems, fmt.Sprintf("%s: %s", src, perr))
+		}
+
+		for i, cert := range certs {
+			finding := &certificateFinding{Source: src.String(), Position: i, Cert: cert}
+
+			switch {
+			case refTime.After(cert.NotAfter):
+				finding.Problems = append(finding.Problems, fmt.Sprintf("EXPIRED %s before collection", formatCertDuration(refTime.Sub(cert.NotAfter))))
+				finding.Severity = 2
+			case refTime.Before(cert.NotBefore):
+				finding.Problems = append(finding.Problems, fmt.Sprintf("NOT YET VALID until %s after collection", formatCertDuration(cert.NotBefore.Sub(refTime))))
+				finding.Severity = 2
+			case warnBefore.After(cert.NotAfter):
+				finding.Problems = append(finding.Problems, fmt.Sprintf("expires %s after collection", formatCertDuration(cert.NotAfter.Sub(refTime))))
+				finding.Severity = 1
+			}
+
+			findings = append(findings, finding)
+		}
+
+		if src.IsChain && len(certs) > 0 {
+			for _, problem := range checkCertificateChain(certs, src.CAPEM, src.KeyPEM, refTime) {
+				sourceProblems = append(sourceProblems, fmt.Sprintf("%s: %s", src, problem))
+			}
+			if src.KeyMasked {
+				keysSkipped = append(keysSkipped, src.String())
+			}
+		}
+	}
+
+	if len(findings) == 0 && len(sourceProblems) == 0 {
+		return api.NewToolCallResult("No certificates found in TLS secrets or CA bundle configmaps (secrets are often not collected by must-gather)", nil), nil
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity != findings[j].Severity {
+			return findings[i].Severity > findings[j].Severity
+		}
+		return findings[i].Cert.NotAfter.Before(findings[j].Cert.NotAfter)
+	})
+
+	expired, expiring := 0, 0
+	for _, f := range findings {
+		switch f.Severity {
+		case 2:
+			expired++
+		case 1:
+			expiring++
+		}
+	}
+
+	output := "Certificate Scan\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+	output += fmt.Sprintf("Reference Time: %s (%s)\n", refTime.UTC().Format(time.RFC3339), refLabel)
+	output += fmt.Sprintf("Warning Window: %d days\n", warningDays)
+	output += fmt.Sprintf("Sources Scanned: %d\n", len(sources))
+	output += fmt.Sprintf("Certificates: %d total, %d expired/not yet valid, %d expiring soon\n", len(findings), expired, expiring)
+	output += fmt.Sprintf("Chain/Key Problems: %d\n\n", len(sourceProblems))
+
+	if len(sourceProblems) > 0 {
+		output += "## Chain and Key Problems\n\n"
+		for _, problem := range sourceProblems {
+			output += fmt.Sprintf("✗ %s\n", problem)
+		}
+		output += "\n"
+	}
+
+	if len(keysSkipped) > 0 {
+		output += fmt.Sprintf("⚠ Key check skipped for %d secret(s): tls.key redacted (start the server with --redact=false to compare keys with certificates)\n", len(keysSkipped))
+		for _, src := range keysSkipped {
+			output += fmt.Sprintf("  %s\n", src)
+		}
+		output += "\n"
+	}
+
+	if expired+expiring > 0 {
+		output += "## Certificates With Problems\n\n"
+		for _, f := range findings {
+			if f.Severity == 0 {
+				continue
+			}
+			output += formatCertificateFinding(f)
+		}
+	}
+
+	if showValid && len(findings) > expired+expiring {
+		output += "## Valid Certificates\n\n"
+		for _, f := range findings {
+			if f.Severity != 0 {
+				continue
+			}
+			output += formatCertificateFinding(f)
+		}
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// collectCertificateSources gathers PEM data from TLS secrets and CA bundle configmaps
+func collectCertificateSources(params api.ToolHandlerParams, namespace string) ([]certificateSource, error) {
+	sources := make([]certificateSource, 0)
+
+	secrets, err := params.MustGatherProvider.ListResources(params.Context, schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, namespace, api.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secrets: %w", err)
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		secretType, _, _ := unstructured.NestedString(secret.Object, "type")
+		data, _, _ := unstructured.NestedStringMap(secret.Object, "data")
+
+		if secretType == "kubernetes.io/tls" {
+			crt := decodeSecretValue(data["tls.crt"])
+			if len(crt) == 0 {
+				continue
+			}
+			sources = append(sources, certificateSource{
+				Kind:      "Secret",
+				Namespace: secret.GetNamespace(),
+				Name:      secret.GetName(),
+				Key:       "tls.crt",
+				PEM:       crt,
+				IsChain:   true,
+				KeyPEM:    decodeSecretValue(data["tls.key"]),
+				KeyMasked: mustgather.IsRedacted(data["tls.key"]),
+				CAPEM:     decodeSecretValue(data["ca.crt"]),
+			})
+			continue
+		}
+
+		// Service account tokens and similar secrets carry a ca.crt bundle
+		for _, key := range sortedMapKeys(data) {
+			if !isCertificateKey(key) {
+				continue
+			}
+			value := decodeSecretValue(data[key])
+			if bytes.Contains(value, []byte("-----BEGIN CERTIFICATE-----")) {
+				sources = append(sources, certificateSource{
+					Kind:      "Secret",
+					Namespace: secret.GetNamespace(),
+					Name:      secret.GetName(),
+					Key:       key,
+					PEM:       value,
+				})
+			}
+		}
+	}
+
+	configMaps, err := params.MustGatherProvider.ListResources(params.Context, schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, namespace, api.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list configmaps: %w", err)
+	}
+	for i := range configMaps.Items {
+		cm := &configMaps.Items[i]
+		data, _, _ := unstructured.NestedStringMap(cm.Object, "data")
+		for _, key := range sortedMapKeys(data) {
+			if !strings.Contains(data[key], "-----BEGIN CERTIFICATE-----") {
+				continue
+			}
+			sources = append(sources, certificateSource{
+				Kind:      "ConfigMap",
+				Namespace: cm.GetNamespace(),
+				Name:      cm.GetName(),
+				Key:       key,
+				PEM:       []byte(data[key]),
+			})
+		}
+	}
+
+	sort.SliceStable(sources, func(i, j int) bool { return sources[i].String() < sources[j].String() })
+
+	return sources, nil
+}
+
+// parsePEMCertificates parses every CERTIFICATE block in a PEM bundle
+func parsePEMCertificates(data []byte) ([]*x509.Certificate, []string) {
+	certs := make([]*x509.Certificate, 0)
+	problems := make([]string, 0)
+
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("unparseable certificate #%d: %v", len(certs)+len(problems)+1, err))
+			continue
+		}
+		certs = append(certs, cert)
+	}
+
+	return certs, problems
+}
+
+// checkCertificateChain verifies a leaf-first chain, its optional CA bundle and private key
+func checkCertificateChain(certs []*x509.Certificate, caPEM, keyPEM []byte, refTime time.Time) []string {
+	problems := make([]string, 0)
+
+	// Each certificate should be signed by the next one in the bundle
+	for i := 0; i < len(certs)-1; i++ {
+		child, parent := certs[i], certs[i+1]
+		if !bytes.Equal(child.RawIssuer, parent.RawSubject) {
+			problems = append(problems, fmt.Sprintf("chain order mismatch: certificate #%d issuer %q does not match certificate #%d subject %q",
+				i+1, child.Issuer.String(), i+2, parent.Subject.String()))
+			continue
+		}
+		if err := child.CheckSignatureFrom(parent); err != nil {
+			problems = append(problems, fmt.Sprintf("certificate #%d is not signed by certificate #%d: %v", i+1, i+2, err))
+		}
+	}
+
+	// Verify against the bundled CA when one was collected
+	if len(caPEM) > 0 {
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(caPEM) {
+			problems = append(problems, "ca.crt contains no parseable certificates")
+		} else {
+			intermediates := x509.NewCertPool()
+			for _, cert := range certs[1:] {
+				intermediates.AddCert(cert)
+			}
+			_, err := certs[0].Verify(x509.VerifyOptions{
+				Roots:         roots,
+				Intermediates: intermediates,
+				CurrentTime:   refTime,
+				KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+			})
+			// Validity periods are reported per certificate, only flag trust problems here
+			var invalidErr x509.CertificateInvalidError
+			if err != nil && !(errors.As(err, &invalidErr) && invalidErr.Reason == x509.Expired) {
+				problems = append(problems, fmt.Sprintf("leaf does not verify against ca.crt: %v", err))
+			}
+		}
+	}
+
+	// The private key must match the leaf certificate
+	if len(keyPEM) > 0 && bytes.Contains(keyPEM, []byte("PRIVATE KEY")) {
+		certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certs[0].Raw})
+		if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+			problems = append(problems, fmt.Sprintf("tls.key does not match leaf certificate: %v", err))
+		}
+	}
+
+	return problems
+}
+
+func formatCertificateFinding(f *certificateFinding) string {
+	cert := f.Cert
+
+	symbol := "✓"
+	switch f.Severity {
+	case 2:
+		symbol = "✗"
+	case 1:
+		symbol = "⚠"
+	}
+
+	output := fmt.Sprintf("%s %s", symbol, f.Source)
+	if f.Position > 0 {
+		output += fmt.Sprintf(" #%d", f.Position+1)
+	}
+	output += "\n"
+	output += fmt.Sprintf("  Subject: %s\n", cert.Subject.String())
+	output += fmt.Sprintf("  Issuer: %s\n", cert.Issuer.String())
+
+	sans := make([]string, 0, len(cert.DNSNames)+len(cert.IPAddresses))
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	if len(sans) > 0 {
+		if len(sans) > 10 {
+			sans = append(sans[:10], fmt.Sprintf("... %d more", len(sans)-10))
+		}
+		output += fmt.Sprintf("  SANs: %s\n", strings.Join(sans, ", "))
+	}
+
+	output += fmt.Sprintf("  Valid: %s -> %s\n", cert.NotBefore.UTC().Format(time.RFC3339), cert.NotAfter.UTC().Format(time.RFC3339))
+	if cert.IsCA {
+		output += "  CA: true\n"
+	}
+	for _, problem := range f.Problems {
+		output += fmt.Sprintf("  Problem: %s\n", problem)
+	}
+	output += "\n"
+
+	return output
+}
+
+// decodeSecretValue decodes a base64 secret data value, returning nil if it is not valid base64
+func decodeSecretValue(value string) []byte {
+	if value == "" {
+		return nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil
+	}
+	return decoded
+}
+
+func isCertificateKey(key string) bool {
+	return strings.HasSuffix(key, ".crt") || strings.HasSuffix(key, ".pem")
+}
+
+func sortedMapKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatCertDuration(d time.Duration) string {
+	days := int(d.Hours() / 24)
+	if days >= 1 {
+		return fmt.Sprintf("%dd", days)
+	}
+	hours := int(d.Hours())
+	if hours >= 1 {
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
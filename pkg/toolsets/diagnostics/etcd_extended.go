@@ -3,8 +3,7 @@ package diagnostics
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
+	"path"
 	"strings"
 
 	"github.com/google/jsonschema-go/jsonschema"
@@ -37,21 +36,16 @@ func etcdExtendedTools() []api.ServerTool {
 }
 
 func etcdMembersList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	// Find container directory
-	containerDir, err := findContainerDir(params.MustGatherProvider.GetMetadata().Path)
-	if err != nil {
-		containerDir = params.MustGatherProvider.GetMetadata().Path
-	}
-
-	memberFile := filepath.Join(containerDir, "etcd_info", "member_list.json")
+	files := params.MustGatherProvider.Files()
+	memberFile := path.Join("etcd_info", "member_list.json")
 
 	// Check if file exists
-	if _, err := os.Stat(memberFile); os.IsNotExist(err) {
+	if _, err := files.Stat(memberFile); err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("ETCD member list not found")), nil
 	}
 
 	// Read and parse JSON
-	data, err := os.ReadFile(memberFile)
+	data, err := files.ReadFile(memberFile)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to read ETCD member list: %w", err)), nil
 	}
@@ -96,21 +90,16 @@ func etcdMembersList(params api.ToolHandlerParams) (*api.ToolCallResult, error)
 }
 
 func etcdEndpointStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	// Find container directory
-	containerDir, err := findContainerDir(params.MustGatherProvider.GetMetadata().Path)
-	if err != nil {
-		containerDir = params.MustGatherProvider.GetMetadata().Path
-	}
-
-	statusFile := filepath.Join(containerDir, "etcd_info", "endpoint_status.json")
+	files := params.MustGatherProvider.Files()
+	statusFile := path.Join("etcd_info", "endpoint_status.json")
 
 	// Check if file exists
-	if _, err := os.Stat(statusFile); os.IsNotExist(err) {
+	if _, err := files.Stat(statusFile); err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("ETCD endpoint status not found")), nil
 	}
 
 	// Read and parse JSON
-	data, err := os.ReadFile(statusFile)
+	data, err := files.ReadFile(statusFile)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to read ETCD endpoint status: %w", err)), nil
 	}
@@ -220,22 +209,3 @@ func etcdEndpointStatus(params api.ToolHandlerParams) (*api.ToolCallResult, erro
 
 	return api.NewToolCallResult(output, nil), nil
 }
-
-// Helper function
-func findContainerDir(basePath string) (string, error) {
-	entries, err := os.ReadDir(basePath)
-	if err != nil {
-		return "", err
-	}
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			name := entry.Name()
-			if strings.HasPrefix(name, "quay") || strings.Contains(name, "sha256") {
-				return filepath.Join(basePath, name), nil
-			}
-		}
-	}
-
-	return "", fmt.Errorf("container directory not found")
-}
@@ -0,0 +1,787 @@
+package diagnostics
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"github.com/openshift/must-gather-mcp-server/pkg/mustgather"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/yaml"
+)
+
+// staticPodComponents are the control plane static pods managed by revision
+// installers, with the operator resource that tracks their rollout
+var staticPodComponents = []struct {
+	Name         string
+	OperatorKind string
+}{
+	{"kube-apiserver", "KubeAPIServer"},
+	{"kube-controller-manager", "KubeControllerManager"},
+	{"kube-scheduler", "KubeScheduler"},
+	{"etcd", "Etcd"},
+}
+
+// revisionDirPattern matches static-pod-resources revision directories such as kube-apiserver-pod-12
+var revisionDirPattern = regexp.MustCompile(`^(.+)-pod-(\d+)$`)
+
+func staticPodTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "static_pods_list",
+				Description: "List control plane static pods per node from static-pods/ (kube-apiserver, kube-controller-manager, kube-scheduler, etcd) with installed and retained revisions and available logs",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"node": {
+							Type:        "string",
+							Description: "Filter by node name (optional)",
+						},
+					},
+				},
+			},
+			Handler: staticPodsList,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "static_pod_logs_get",
+				Description: "Get logs of a control plane static pod collected on a node under static-pods/",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"node": {
+							Type:        "string",
+							Description: "Node name",
+						},
+						"component": {
+							Type:        "string",
+							Description: "Static pod component (kube-apiserver, kube-controller-manager, kube-scheduler, etcd)",
+						},
+						"container": {
+							Type:        "string",
+							Description: "Container or log file name (optional, lists available logs if ambiguous)",
+						},
+						"filter": {
+							Type:        "string",
+							Description: "Only return lines containing this string (optional)",
+						},
+						"tail": {
+							Type:        "integer",
+							Description: "Number of lines from the end (0 for all, default: 200)",
+						},
+					},
+					Required: []string{"node", "component"},
+				},
+			},
+			Handler: staticPodLogsGet,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "static_pod_revisions_compare",
+				Description: "Compare installed static pod revisions and manifests across control plane nodes to find a node stuck on an old revision, cross-checked with the operator nodeStatuses",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"component": {
+							Type:        "string",
+							Description: "Only compare this component (optional)",
+						},
+					},
+				},
+			},
+			Handler: staticPodRevisionsCompare,
+		},
+	}
+}
+
+// staticPodManifest is a pod manifest found under static-pods/<node>/
+type staticPodManifest struct {
+	Component string
+	Revision  int
+	Installed bool // from the manifests directory rather than a retained revision directory
+	Path      string
+	Pod       *unstructured.Unstructured
+}
+
+// staticPodLog is a log file found under static-pods/<node>/
+type staticPodLog struct {
+	Component string
+	Container string
+	Path      string
+	Size      int64
+}
+
+// staticPodNode holds everything collected for one node
+type staticPodNode struct {
+	Name      string
+	Manifests []staticPodManifest
+	Logs      []staticPodLog
+}
+
+// installedRevision returns the revision the node is running for a component:
+// the manifest in /etc/kubernetes/manifests if collected, else the newest
+// retained revision
+func (n *staticPodNode) installedRevision(component string) (*staticPodManifest, bool) {
+	var best *staticPodManifest
+	for i := range n.Manifests {
+		m := &n.Manifests[i]
+		if m.Component != component {
+			continue
+		}
+		if m.Installed {
+			return m, true
+		}
+		if best == nil || m.Revision > best.Revision {
+			best = m
+		}
+	}
+	return best, false
+}
+
+// revisions returns the sorted retained revisions for a component
+func (n *staticPodNode) revisions(component string) []int {
+	seen := make(map[int]bool)
+	for _, m := range n.Manifests {
+		if m.Component == component && m.Revision > 0 {
+			seen[m.Revision] = true
+		}
+	}
+	revisions := make([]int, 0, len(seen))
+	for r := range seen {
+		revisions = append(revisions, r)
+	}
+	sort.Ints(revisions)
+	return revisions
+}
+
+func staticPodsList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	nodeFilter := params.GetString("node", "")
+
+	nodes, err := loadStaticPods(params.MustGatherProvider.Files())
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	output := "Static Pods\n"
+	output += strings.Repeat("=", 80) + "\n"
+
+	shown := 0
+	for _, node := range nodes {
+		if nodeFilter != "" && node.Name != nodeFilter {
+			continue
+		}
+		shown++
+
+		output += fmt.Sprintf("\nNode: %s\n", node.Name)
+		output += strings.Repeat("-", 80) + "\n"
+
+		for _, component := range nodeComponents(node) {
+			output += fmt.Sprintf("  %s\n", component)
+
+			if m, installed := node.installedRevision(component); m != nil {
+				source := "newest retained revision"
+				if installed {
+					source = "manifests"
+				}
+				output += fmt.Sprintf("    Installed Revision: %s (%s)\n", formatRevision(m.Revision), source)
+				if images := podImages(m.Pod); len(images) > 0 {
+					output += fmt.Sprintf("    Image: %s\n", images[0])
+				}
+			}
+			if revisions := node.revisions(component); len(revisions) > 0 {
+				parts := make([]string, len(revisions))
+				for i, r := range revisions {
+					parts[i] = strconv.Itoa(r)
+				}
+				output += fmt.Sprintf("    Retained Revisions: %s\n", strings.Join(parts, ", "))
+			}
+
+			logs := make([]string, 0)
+			for _, log := range node.Logs {
+				if log.Component == component {
+					logs = append(logs, fmt.Sprintf("%s (%d bytes)", log.Container, log.Size))
+				}
+			}
+			if len(logs) > 0 {
+				output += fmt.Sprintf("    Logs: %s\n", strings.Join(logs, ", "))
+			}
+		}
+	}
+
+	if shown == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("no static pod data found for node %s", nodeFilter)), nil
+	}
+
+	output += fmt.Sprintf("\nTotal: %d nodes\n", shown)
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+func staticPodLogsGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	nodeName := params.GetString("node", "")
+	component := params.GetString("component", "")
+	container := params.GetString("container", "")
+	filter := params.GetString("filter", "")
+	tail := params.GetInt("tail", 200)
+
+	if nodeName == "" || component == "" {
+		return api.NewToolCallResult("", fmt.Errorf("node and component are required")), nil
+	}
+
+	nodes, err := loadStaticPods(params.MustGatherProvider.Files())
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	var node *staticPodNode
+	for i := range nodes {
+		if nodes[i].Name == nodeName {
+			node = &nodes[i]
+			break
+		}
+	}
+	if node == nil {
+		return api.NewToolCallResult("", fmt.Errorf("no static pod data found for node %s", nodeName)), nil
+	}
+
+	candidates := make([]staticPodLog, 0)
+	for _, log := range node.Logs {
+		if log.Component != component {
+			continue
+		}
+		if container != "" && log.Container != container && !strings.HasPrefix(log.Container, container+"/") {
+			continue
+		}
+		candidates = append(candidates, log)
+	}
+
+	if len(candidates) == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("no %s logs found on node %s", component, nodeName)), nil
+	}
+	if len(candidates) > 1 {
+		output := fmt.Sprintf("Multiple %s logs found on node %s, specify a container:\n", component, nodeName)
+		for _, log := range candidates {
+			output += fmt.Sprintf("  - %s (%d bytes)\n", log.Container, log.Size)
+		}
+		return api.NewToolCallResult(output, nil), nil
+	}
+
+	log := candidates[0]
+	content, err := readStaticPodLog(params.MustGatherProvider.Files(), log.Path)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to read static pod log: %w", err)), nil
+	}
+
+	if filter != "" {
+		matching := make([]string, 0)
+		for _, line := range strings.Split(content, "\n") {
+			if strings.Contains(line, filter) {
+				matching = append(matching, line)
+			}
+		}
+		content = strings.Join(matching, "\n")
+	}
+	if tail > 0 {
+		content = mustgather.TailLines(content, tail)
+	}
+
+	output := fmt.Sprintf("Static pod logs for %s/%s on node %s", component, log.Container, nodeName)
+	if filter != "" {
+		output += fmt.Sprintf(" matching %q", filter)
+	}
+	if tail > 0 {
+		output += fmt.Sprintf(" (last %d lines)", tail)
+	}
+	output += ":\n\n"
+	output += content
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+func staticPodRevisionsCompare(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	componentFilter := params.GetString("component", "")
+
+	nodes, err := loadStaticPods(params.MustGatherProvider.Files())
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	output := "Static Pod Revision Comparison\n"
+	output += strings.Repeat("=", 80) + "\n"
+
+	issues := 0
+	compared := 0
+	for _, component := range staticPodComponents {
+		if componentFilter != "" && component.Name != componentFilter {
+			continue
+		}
+
+		installed := make(map[string]*staticPodManifest)
+		latest := 0
+		for i := range nodes {
+			if m, _ := nodes[i].installedRevision(component.Name); m != nil {
+				installed[nodes[i].Name] = m
+				if m.Revision > latest {
+					latest = m.Revision
+				}
+			}
+		}
+
+		operatorStatus := operatorNodeStatuses(params, component.OperatorKind)
+		if len(installed) == 0 && operatorStatus == nil {
+			continue
+		}
+		compared++
+
+		output += fmt.Sprintf("\n%s\n", component.Name)
+		output += strings.Repeat("-", 80) + "\n"
+
+		if operatorStatus != nil && operatorStatus.LatestAvailable > 0 {
+			output += fmt.Sprintf("  Latest Available Revision (operator): %d\n", operatorStatus.LatestAvailable)
+			if operatorStatus.LatestAvailable > latest {
+				latest = operatorStatus.LatestAvailable
+			}
+		}
+
+		nodeNames := make([]string, 0)
+		for name := range installed {
+			nodeNames = append(nodeNames, name)
+		}
+		if operatorStatus != nil {
+			for name := range operatorStatus.Nodes {
+				if _, ok := installed[name]; !ok {
+					nodeNames = append(nodeNames, name)
+				}
+			}
+		}
+		sort.Strings(nodeNames)
+
+		var reference *staticPodManifest
+		for _, name := range nodeNames {
+			if m := installed[name]; m != nil && m.Revision == latest {
+				reference = m
+				break
+			}
+		}
+
+		for _, name := range nodeNames {
+			m := installed[name]
+			line := fmt.Sprintf("%s: ", name)
+			behind := false
+
+			if m != nil {
+				line += fmt.Sprintf("installed %s", formatRevision(m.Revision))
+				behind = m.Revision > 0 && m.Revision < latest
+			} else {
+				line += "no manifest collected"
+			}
+
+			if operatorStatus != nil {
+				if ns, ok := operatorStatus.Nodes[name]; ok {
+					line += fmt.Sprintf(", operator current %d", ns.CurrentRevision)
+					if ns.TargetRevision > 0 {
+						line += fmt.Sprintf(" target %d", ns.TargetRevision)
+					}
+					if ns.CurrentRevision < latest {
+						behind = true
+					}
+					if ns.LastFailedRevision > 0 {
+						line += fmt.Sprintf(", last failed %d", ns.LastFailedRevision)
+					}
+				}
+			}
+
+			if behind {
+				output += "  ✗ " + line + " - behind latest revision\n"
+				issues++
+			} else {
+				output += "  ✓ " + line + "\n"
+			}
+
+			if operatorStatus != nil {
+				for _, e := range operatorStatus.Nodes[name].LastFailedErrors {
+					output += fmt.Sprintf("      Error: %s\n", truncateText(e, 300))
+				}
+			}
+
+			if behind && m != nil && reference != nil {
+				for _, diff := range diffStaticPodManifests(m.Pod, reference.Pod) {
+					output += fmt.Sprintf("      %s\n", diff)
+				}
+			}
+		}
+	}
+
+	if compared == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("no static pod manifests or operator revision status found")), nil
+	}
+
+	output += "\n" + strings.Repeat("=", 80) + "\n"
+	if issues == 0 {
+		output += "✓ All control plane nodes are on the latest revision\n"
+	} else {
+		output += fmt.Sprintf("Found %d node(s) behind the latest revision. Check the installer and revision-pruner pods in the operand namespace and the static pod logs on those nodes.\n", issues)
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// staticPodOperatorStatus is the revision rollout state reported by a static pod operator
+type staticPodOperatorStatus struct {
+	LatestAvailable int
+	Nodes           map[string]staticPodNodeStatus
+}
+
+type staticPodNodeStatus struct {
+	CurrentRevision    int
+	TargetRevision     int
+	LastFailedRevision int
+	LastFailedErrors   []string
+}
+
+func operatorNodeStatuses(params api.ToolHandlerParams, kind string) *staticPodOperatorStatus {
+	gvk := schema.GroupVersionKind{Group: "operator.openshift.io", Version: "v1", Kind: kind}
+	operator, err := params.MustGatherProvider.GetResource(params.Context, gvk, "", "cluster")
+	if err != nil {
+		return nil
+	}
+
+	status := &staticPodOperatorStatus{Nodes: make(map[string]staticPodNodeStatus)}
+	latest, _, _ := unstructured.NestedInt64(operator.Object, "status", "latestAvailableRevision")
+	status.LatestAvailable = int(latest)
+
+	nodeStatuses, _, _ := unstructured.NestedSlice(operator.Object, "status", "nodeStatuses")
+	for _, item := range nodeStatuses {
+		ns, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(ns, "nodeName")
+		current, _, _ := unstructured.NestedInt64(ns, "currentRevision")
+		target, _, _ := unstructured.NestedInt64(ns, "targetRevision")
+		failed, _, _ := unstructured.NestedInt64(ns, "lastFailedRevision")
+		errs, _, _ := unstructured.NestedStringSlice(ns, "lastFailedRevisionErrors")
+		status.Nodes[name] = staticPodNodeStatus{
+			CurrentRevision:    int(current),
+			TargetRevision:     int(target),
+			LastFailedRevision: int(failed),
+			LastFailedErrors:   errs,
+		}
+	}
+
+	return status
+}
+
+// diffStaticPodManifests summarizes container image and argument differences
+func diffStaticPodManifests(from, to *unstructured.Unstructured) []string {
+	diffs := make([]string, 0)
+
+	fromContainers := podContainers(from)
+	toContainers := podContainers(to)
+
+	names := make([]string, 0)
+	for name := range toContainers {
+		names = append(names, name)
+	}
+	for name := range fromContainers {
+		if _, ok := toContainers[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f, inFrom := fromContainers[name]
+		t, inTo := toContainers[name]
+		switch {
+		case !inFrom:
+			diffs = append(diffs, fmt.Sprintf("+ container %s (only in latest revision)", name))
+			continue
+		case !inTo:
+			diffs = append(diffs, fmt.Sprintf("- container %s (not in latest revision)", name))
+			continue
+		}
+
+		if f.Image != t.Image {
+			diffs = append(diffs, fmt.Sprintf("~ %s image: %s -> %s", name, f.Image, t.Image))
+		}
+
+		fromArgs := make(map[string]bool)
+		for _, a := range f.Args {
+			fromArgs[a] = true
+		}
+		toArgs := make(map[string]bool)
+		for _, a := range t.Args {
+			toArgs[a] = true
+		}
+		for _, a := range f.Args {
+			if !toArgs[a] {
+				diffs = append(diffs, fmt.Sprintf("- %s arg: %s", name, truncateText(a, 200)))
+			}
+		}
+		for _, a := range t.Args {
+			if !fromArgs[a] {
+				diffs = append(diffs, fmt.Sprintf("+ %s arg: %s", name, truncateText(a, 200)))
+			}
+		}
+	}
+
+	return diffs
+}
+
+type staticPodContainer struct {
+	Image string
+	Args  []string
+}
+
+func podContainers(pod *unstructured.Unstructured) map[string]staticPodContainer {
+	containers := make(map[string]staticPodContainer)
+	if pod == nil {
+		return containers
+	}
+
+	for _, field := range []string{"initContainers", "containers"} {
+		list, _, _ := unstructured.NestedSlice(pod.Object, "spec", field)
+		for _, item := range list {
+			c, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(c, "name")
+			image, _, _ := unstructured.NestedString(c, "image")
+			command, _, _ := unstructured.NestedStringSlice(c, "command")
+			args, _, _ := unstructured.NestedStringSlice(c, "args")
+
+			// Installer-generated commands embed flags in a shell script, so split on lines
+			all := make([]string, 0)
+			for _, part := range append(command, args...) {
+				for _, line := range strings.Split(part, "\n") {
+					if line = strings.TrimSpace(line); line != "" {
+						all = append(all, line)
+					}
+				}
+			}
+			containers[name] = staticPodContainer{Image: image, Args: all}
+		}
+	}
+
+	return containers
+}
+
+func podImages(pod *unstructured.Unstructured) []string {
+	images := make([]string, 0)
+	if pod == nil {
+		return images
+	}
+	containers, _, _ := unstructured.NestedSlice(pod.Object, "spec", "containers")
+	for _, item := range containers {
+		if c, ok := item.(map[string]interface{}); ok {
+			if image, _, _ := unstructured.NestedString(c, "image"); image != "" {
+				images = append(images, image)
+			}
+		}
+	}
+	return images
+}
+
+// loadStaticPods walks static-pods/<node>/ and classifies manifests and logs.
+// It accepts both the /etc/kubernetes layout (manifests/ and
+// static-pod-resources/<component>-pod-<revision>/) and flat per-pod directories.
+func loadStaticPods(files api.GatherFS) ([]staticPodNode, error) {
+	staticPodsDir := "static-pods"
+	entries, err := files.ReadDir(staticPodsDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("static pod data not found in must-gather (static-pods/)")
+		}
+		return nil, fmt.Errorf("failed to read static-pods directory: %w", err)
+	}
+
+	nodes := make([]staticPodNode, 0)
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		node := staticPodNode{Name: entry.Name()}
+		nodeDir := path.Join(staticPodsDir, entry.Name())
+
+		_ = fs.WalkDir(files, nodeDir, func(filePath string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+
+			rel := strings.TrimPrefix(filePath, nodeDir+"/")
+			parts := strings.Split(rel, "/")
+			name := d.Name()
+
+			switch {
+			case strings.HasSuffix(name, ".log") || strings.HasSuffix(name, ".log.gz"):
+				info, err := d.Info()
+				if err != nil {
+					return nil
+				}
+				component := staticPodComponent(parts)
+				if component == "" {
+					return nil
+				}
+				node.Logs = append(node.Logs, staticPodLog{
+					Component: component,
+					Container: staticPodLogName(parts),
+					Path:      filePath,
+					Size:      info.Size(),
+				})
+
+			case strings.HasSuffix(name, ".yaml") || strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".json"):
+				if manifest, ok := parseStaticPodManifest(files, filePath, parts); ok {
+					node.Manifests = append(node.Manifests, manifest)
+				}
+			}
+
+			return nil
+		})
+
+		if len(node.Manifests) > 0 || len(node.Logs) > 0 {
+			sort.Slice(node.Logs, func(i, j int) bool {
+				if node.Logs[i].Component != node.Logs[j].Component {
+					return node.Logs[i].Component < node.Logs[j].Component
+				}
+				return node.Logs[i].Container < node.Logs[j].Container
+			})
+			nodes = append(nodes, node)
+		}
+	}
+
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no static pod manifests or logs found under static-pods/")
+	}
+
+	return nodes, nil
+}
+
+func parseStaticPodManifest(files api.GatherFS, filePath string, parts []string) (staticPodManifest, bool) {
+	data, err := files.ReadFile(filePath)
+	if err != nil {
+		return staticPodManifest{}, false
+	}
+
+	obj := make(map[string]interface{})
+	if err := yaml.Unmarshal(data, &obj); err != nil {
+		return staticPodManifest{}, false
+	}
+	pod := &unstructured.Unstructured{Object: obj}
+	if pod.GetKind() != "Pod" {
+		return staticPodManifest{}, false
+	}
+
+	component := staticPodComponent(append([]string{pod.GetName(), pod.GetLabels()["app"]}, parts...))
+	if component == "" {
+		return staticPodManifest{}, false
+	}
+
+	manifest := staticPodManifest{Component: component, Path: filePath, Pod: pod, Installed: true}
+
+	// A revision directory means a retained copy rather than the running manifest
+	for _, part := range parts[:len(parts)-1] {
+		if m := revisionDirPattern.FindStringSubmatch(part); m != nil {
+			manifest.Revision, _ = strconv.Atoi(m[2])
+			manifest.Installed = false
+		}
+	}
+	if revision, err := strconv.Atoi(pod.GetLabels()["revision"]); err == nil {
+		manifest.Revision = revision
+	}
+
+	return manifest, true
+}
+
+// staticPodSidePods are pods named after a component that are not its static pod,
+// e.g. kube-apiserver-startup-monitor-<node>, whose manifest is retained in the
+// kube-apiserver revision directory
+var staticPodSidePods = []string{"-startup-monitor", "-guard", "-operator"}
+
+// staticPodComponent returns the known component named in the first matching
+// string. Strings naming a side pod of a component mark the whole file as not
+// belonging to the component's static pod.
+func staticPodComponent(candidates []string) string {
+	for _, candidate := range candidates {
+		for _, component := range staticPodComponents {
+			for _, side := range staticPodSidePods {
+				if strings.Contains(candidate, component.Name+side) {
+					return ""
+				}
+			}
+		}
+	}
+
+	for _, candidate := range candidates {
+		for _, component := range staticPodComponents {
+			if candidate == component.Name || strings.HasPrefix(candidate, component.Name+"-") || strings.HasPrefix(candidate, "openshift-"+component.Name+"_") {
+				return component.Name
+			}
+		}
+	}
+	return ""
+}
+
+// staticPodLogName derives a container name from a log path. CRI log paths
+// (<namespace>_<pod>_<uid>/<container>/0.log) use the container directory.
+func staticPodLogName(parts []string) string {
+	name := strings.TrimSuffix(strings.TrimSuffix(parts[len(parts)-1], ".gz"), ".log")
+	if len(parts) >= 2 {
+		if _, err := strconv.Atoi(name); err == nil {
+			return parts[len(parts)-2] + "/" + name
+		}
+	}
+	return name
+}
+
+// readStaticPodLog reads a static pod log, decompressing rotated .gz logs
+func readStaticPodLog(files api.GatherFS, logPath string) (string, error) {
+	data, err := files.ReadFile(logPath)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func nodeComponents(node staticPodNode) []string {
+	seen := make(map[string]bool)
+	for _, m := range node.Manifests {
+		seen[m.Component] = true
+	}
+	for _, l := range node.Logs {
+		seen[l.Component] = true
+	}
+
+	components := make([]string, 0, len(seen))
+	for _, component := range staticPodComponents {
+		if seen[component.Name] {
+			components = append(components, component.Name)
+		}
+	}
+	return components
+}
+
+func formatRevision(revision int) string {
+	if revision == 0 {
+		return "unknown"
+	}
+	return strconv.Itoa(revision)
+}
+
+func truncateText(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
@@ -20,6 +20,8 @@ func (t *Toolset) GetTools() []api.ServerTool {
 	tools = append(tools, nodeTools()...)
 	tools = append(tools, etcdTools()...)
 	tools = append(tools, etcdExtendedTools()...)
+	tools = append(tools, certificateTools()...)
+	tools = append(tools, staticPodTools()...)
 	return tools
 }
 
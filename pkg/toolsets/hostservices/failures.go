@@ -0,0 +1,263 @@
+package hostservices
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+)
+
+// failureSignature is a known failure pattern in a host service journal
+type failureSignature struct {
+	Name     string
+	Services *regexp.Regexp // services the signature applies to, nil for all
+	Pattern  *regexp.Regexp
+	Hint     string
+}
+
+// failureSignatures are checked in order and a line counts for the first match,
+// so specific signatures come before generic ones
+var failureSignatures = []failureSignature{
+	{
+		Name:     "Registry authentication failure",
+		Services: regexp.MustCompile(`(?i)^(crio|kubelet)$`),
+		Pattern:  regexp.MustCompile(`(?i)(unauthorized: authentication required|401 Unauthorized|invalid username/password)`),
+		Hint:     "The global pull secret (openshift-config/pull-secret) is missing or has expired credentials for this registry",
+	},
+	{
+		Name:     "Image pull failure",
+		Services: regexp.MustCompile(`(?i)^(crio|kubelet)$`),
+		Pattern:  regexp.MustCompile(`(?i)(error pulling image|failed to pull image|pull access denied|manifest unknown|reading manifest .* (not found|unknown)|ErrImagePull)`),
+		Hint:     "Check the image reference, registry reachability, mirror configuration (ImageContentSourcePolicy/ImageDigestMirrorSet) and the pull secret",
+	},
+	{
+		Name:     "Registry TLS/connectivity failure",
+		Services: regexp.MustCompile(`(?i)^(crio|kubelet)$`),
+		Pattern:  regexp.MustCompile(`(?i)(x509: certificate signed by unknown authority|dial tcp .*: (i/o timeout|connection refused)|no such host)`),
+		Hint:     "Check proxy settings, additionalTrustBundle and DNS resolution from the node",
+	},
+	{
+		Name:    "Disk full",
+		Pattern: regexp.MustCompile(`(?i)no space left on device`),
+		Hint:    "Node filesystem is full; check /var usage, image garbage collection and log volume",
+	},
+	{
+		Name:     "DHCP timeout",
+		Services: regexp.MustCompile(`(?i)^NetworkManager`),
+		Pattern:  regexp.MustCompile(`(?i)(dhcp[46]? .*(timeout|timed out)|ip-config-unavailable|dhcp-transaction timed out)`),
+		Hint:     "The node did not get a DHCP lease; check the DHCP server, VLAN and NIC link state",
+	},
+	{
+		Name:     "Network device activation failure",
+		Services: regexp.MustCompile(`(?i)^NetworkManager`),
+		Pattern:  regexp.MustCompile(`(?i)(state change: .* -> failed|activation: .* failed|device .* link (is )?down)`),
+		Hint:     "A NetworkManager connection failed to activate; check nmstate/NodeNetworkConfigurationPolicy and the physical link",
+	},
+	{
+		Name:     "Machine config degraded",
+		Services: regexp.MustCompile(`(?i)machine-config`),
+		Pattern:  regexp.MustCompile(`(?i)(marking degraded|unexpected on-disk state|content mismatch for file|failed to drain|error running rpm-ostree|failed to update os)`),
+		Hint:     "machine-config-daemon could not apply the rendered config; use machineconfig_node_status and machineconfig_diff",
+	},
+	{
+		Name:     "Kubelet PLEG/runtime unhealthy",
+		Services: regexp.MustCompile(`(?i)^kubelet$`),
+		Pattern:  regexp.MustCompile(`(?i)(PLEG is not healthy|container runtime is down|ContainerRuntimeNotReady|RuntimeReady=false)`),
+		Hint:     "The container runtime is slow or unresponsive; check crio logs and node load",
+	},
+	{
+		Name:     "Kubelet node lease/API failure",
+		Services: regexp.MustCompile(`(?i)^kubelet$`),
+		Pattern:  regexp.MustCompile(`(?i)(failed to update lease|Error updating node status|unable to register node)`),
+		Hint:     "The kubelet cannot reach the API server; check api-int DNS, load balancer and control plane health",
+	},
+	{
+		Name:     "OVS stall",
+		Services: regexp.MustCompile(`(?i)^(ovs-vswitchd|ovsdb-server|openvswitch)`),
+		Pattern:  regexp.MustCompile(`(?i)(unreasonably long \d+ms poll interval|blocked \d+ ms waiting|deadlock)`),
+		Hint:     "Open vSwitch is starved for CPU or blocked; check node CPU pressure and OVN-Kubernetes health",
+	},
+	{
+		Name:    "Out of memory kill",
+		Pattern: regexp.MustCompile(`(?i)(Out of memory: Killed process|oom-kill|invoked oom-killer)`),
+		Hint:    "The node ran out of memory; check system-reserved settings and pod memory limits",
+	},
+	{
+		Name:    "Clock not synchronized",
+		Pattern: regexp.MustCompile(`(?i)(clock unsynchronized|System clock wrong|Can't synchronise: no selectable sources)`),
+		Hint:    "chronyd cannot reach NTP servers; clock skew breaks certificates and etcd",
+	},
+	{
+		Name:    "Systemd unit failure",
+		Pattern: regexp.MustCompile(`(Failed with result '|Main process exited, code=exited, status=[1-9]|Failed to start )`),
+		Hint:    "A systemd unit failed; look at the lines before the failure in the same service log",
+	},
+}
+
+func failureTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "host_service_failures",
+				Description: "Scan host service logs for common failure signatures (crio image pull errors, registry auth, NetworkManager DHCP timeouts, machine-config-daemon degradation, PLEG, OOM, disk full) with per-node counts and samples",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"role": {
+							Type:        "string",
+							Description: "Filter by node role directory (optional)",
+						},
+						"service": {
+							Type:        "string",
+							Description: "Filter by service name (optional)",
+						},
+						"node": {
+							Type:        "string",
+							Description: "Filter by node (optional)",
+						},
+						"since": {
+							Type:        "string",
+							Description: "Only scan entries at or after this RFC3339 time (optional)",
+						},
+						"until": {
+							Type:        "string",
+							Description: "Only scan entries at or before this RFC3339 time (optional)",
+						},
+					},
+				},
+			},
+			Handler: hostServiceFailures,
+		},
+	}
+}
+
+// failureHit aggregates matches of one signature in one service log
+type failureHit struct {
+	Signature *failureSignature
+	Source    string
+	Count     int
+	Nodes     map[string]int
+	First     time.Time
+	Last      time.Time
+	Sample    string
+}
+
+func hostServiceFailures(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	role := params.GetString("role", "")
+	service := params.GetString("service", "")
+	node := params.GetString("node", "")
+
+	window, err := parseTimeWindow(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	logs, err := selectHostServiceLogs(params, role, service)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	refTime := gatherTime(params)
+
+	hits := make([]*failureHit, 0)
+	for _, log := range logs {
+		content, err := params.MustGatherProvider.GetHostServiceLog(log.Role, log.Service)
+		if err != nil {
+			continue
+		}
+
+		bySignature := make(map[int]*failureHit)
+		for _, entry := range parseJournal(content, refTime) {
+			if node != "" && entry.Host != node {
+				continue
+			}
+			if !window.contains(entry) {
+				continue
+			}
+
+			for i := range failureSignatures {
+				sig := &failureSignatures[i]
+				if sig.Services != nil && !sig.Services.MatchString(log.Service) {
+					continue
+				}
+				if !sig.Pattern.MatchString(entry.Message) {
+					continue
+				}
+
+				hit, ok := bySignature[i]
+				if !ok {
+					hit = &failureHit{
+						Signature: sig,
+						Source:    fmt.Sprintf("%s/%s", log.Role, log.Service),
+						Nodes:     make(map[string]int),
+						Sample:    entry.Raw,
+					}
+					bySignature[i] = hit
+					hits = append(hits, hit)
+				}
+				hit.Count++
+				if entry.Host != "" {
+					hit.Nodes[entry.Host]++
+				}
+				if !entry.Time.IsZero() {
+					if hit.First.IsZero() || entry.Time.Before(hit.First) {
+						hit.First = entry.Time
+					}
+					if entry.Time.After(hit.Last) {
+						hit.Last = entry.Time
+					}
+				}
+
+				// One signature per line is enough
+				break
+			}
+		}
+	}
+
+	output := "Host Service Failure Signatures\n"
+	output += strings.Repeat("=", 80) + "\n"
+	output += fmt.Sprintf("Scanned: %d service logs", len(logs))
+	if node != "" {
+		output += fmt.Sprintf(", node %s", node)
+	}
+	if w := window.String(); w != "" {
+		output += fmt.Sprintf(", window %s", w)
+	}
+	output += "\n\n"
+
+	if len(hits) == 0 {
+		output += "✓ No known failure signatures found\n"
+		return api.NewToolCallResult(output, nil), nil
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Count > hits[j].Count })
+
+	for _, hit := range hits {
+		output += fmt.Sprintf("✗ %s [%s] - %d occurrence(s)\n", hit.Signature.Name, hit.Source, hit.Count)
+		if !hit.First.IsZero() {
+			output += fmt.Sprintf("  Time Range: %s -> %s\n", hit.First.Format(time.RFC3339), hit.Last.Format(time.RFC3339))
+		}
+		if len(hit.Nodes) > 0 {
+			nodes := make([]string, 0, len(hit.Nodes))
+			for n := range hit.Nodes {
+				nodes = append(nodes, n)
+			}
+			sort.Slice(nodes, func(i, j int) bool { return hit.Nodes[nodes[i]] > hit.Nodes[nodes[j]] })
+			parts := make([]string, 0, len(nodes))
+			for _, n := range nodes {
+				parts = append(parts, fmt.Sprintf("%s (%d)", n, hit.Nodes[n]))
+			}
+			output += fmt.Sprintf("  Nodes: %s\n", strings.Join(parts, ", "))
+		}
+		output += fmt.Sprintf("  Sample: %s\n", truncate(hit.Sample, 300))
+		output += fmt.Sprintf("  Hint: %s\n\n", hit.Signature.Hint)
+	}
+
+	output += "Use host_service_logs_grep with a node and time window to see surrounding entries.\n"
+
+	return api.NewToolCallResult(output, nil), nil
+}
@@ -0,0 +1,198 @@
+package hostservices
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+)
+
+// journalLinePattern matches journalctl short, short-precise and short-iso output:
+//
+//	Jan 10 10:00:00.123456 master-0 crio[1234]: message
+//	2025-01-10T10:00:00+0000 master-0 crio[1234]: message
+var journalLinePattern = regexp.MustCompile(`^(?:([A-Z][a-z]{2} +\d{1,2} \d{2}:\d{2}:\d{2}(?:\.\d+)?)|(\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?(?:Z|[+-]\d{2}:?\d{2})))\s+(\S+)\s+([^\s\[:]+)(?:\[\d+\])?:\s?(.*)$`)
+
+// journalEntry is a parsed journal line
+type journalEntry struct {
+	Time    time.Time // zero if the line could not be parsed
+	Host    string
+	Unit    string
+	Message string
+	Raw     string
+}
+
+// parseJournal splits a journal into entries. Lines that do not start with a
+// journal prefix inherit the time and host of the previous entry.
+// Short timestamps carry no year, so they are resolved against the gather time.
+func parseJournal(content string, gatherTime time.Time) []journalEntry {
+	lines := strings.Split(content, "\n")
+	entries := make([]journalEntry, 0, len(lines))
+
+	var last journalEntry
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+
+		entry := journalEntry{Raw: line, Message: line, Time: last.Time, Host: last.Host, Unit: last.Unit}
+		if m := journalLinePattern.FindStringSubmatch(line); m != nil {
+			entry.Time = parseJournalTime(m[1], m[2], gatherTime)
+			entry.Host = m[3]
+			entry.Unit = m[4]
+			entry.Message = m[5]
+		}
+
+		entries = append(entries, entry)
+		last = entry
+	}
+
+	return entries
+}
+
+func parseJournalTime(short, iso string, gatherTime time.Time) time.Time {
+	if iso != "" {
+		for _, layout := range []string{"2006-01-02T15:04:05.999999999Z0700", "2006-01-02T15:04:05.999999999Z07:00"} {
+			if t, err := time.Parse(layout, iso); err == nil {
+				return t.UTC()
+			}
+		}
+		return time.Time{}
+	}
+
+	normalized := strings.Join(strings.Fields(short), " ")
+	t, err := time.Parse("Jan 2 15:04:05.999999999 2006", fmt.Sprintf("%s %d", normalized, gatherTime.Year()))
+	if err != nil {
+		return time.Time{}
+	}
+
+	// A December entry in a January gather belongs to the previous year
+	if t.After(gatherTime.Add(24 * time.Hour)) {
+		t = t.AddDate(-1, 0, 0)
+	}
+	return t
+}
+
+// gatherTime returns the time the must-gather was collected
+func gatherTime(params api.ToolHandlerParams) time.Time {
+	metadata := params.MustGatherProvider.GetMetadata()
+	if !metadata.EndTime.IsZero() {
+		return metadata.EndTime
+	}
+	if !metadata.StartTime.IsZero() {
+		return metadata.StartTime
+	}
+	return time.Now()
+}
+
+// timeWindow filters entries by the optional since/until parameters
+type timeWindow struct {
+	Since time.Time
+	Until time.Time
+}
+
+func parseTimeWindow(params api.ToolHandlerParams) (timeWindow, error) {
+	var window timeWindow
+	for _, p := range []struct {
+		key    string
+		target *time.Time
+	}{{"since", &window.Since}, {"until", &window.Until}} {
+		value := params.GetString(p.key, "")
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return window, fmt.Errorf("invalid %s time %q, expected RFC3339 (e.g. 2025-01-10T10:00:00Z): %w", p.key, value, err)
+		}
+		*p.target = t.UTC()
+	}
+	return window, nil
+}
+
+// contains reports whether an entry falls in the window. Entries without a
+// timestamp are only kept when no window is set.
+func (w timeWindow) contains(entry journalEntry) bool {
+	if w.Since.IsZero() && w.Until.IsZero() {
+		return true
+	}
+	if entry.Time.IsZero() {
+		return false
+	}
+	if !w.Since.IsZero() && entry.Time.Before(w.Since) {
+		return false
+	}
+	if !w.Until.IsZero() && entry.Time.After(w.Until) {
+		return false
+	}
+	return true
+}
+
+func (w timeWindow) String() string {
+	if w.Since.IsZero() && w.Until.IsZero() {
+		return ""
+	}
+	since, until := "beginning", "end"
+	if !w.Since.IsZero() {
+		since = w.Since.Format(time.RFC3339)
+	}
+	if !w.Until.IsZero() {
+		until = w.Until.Format(time.RFC3339)
+	}
+	return fmt.Sprintf("%s -> %s", since, until)
+}
+
+// selectHostServiceLogs returns the logs matching optional role and service filters
+func selectHostServiceLogs(params api.ToolHandlerParams, role, service string) ([]api.HostServiceLog, error) {
+	logs, err := params.MustGatherProvider.ListHostServiceLogs()
+	if err != nil {
+		return nil, err
+	}
+	if len(logs) == 0 {
+		return nil, fmt.Errorf("no host service logs found in must-gather (host_service_logs/)")
+	}
+
+	selected := make([]api.HostServiceLog, 0)
+	for _, log := range logs {
+		if role != "" && !matchRole(log.Role, role) {
+			continue
+		}
+		if service != "" && !strings.EqualFold(log.Service, service) {
+			continue
+		}
+		selected = append(selected, log)
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no host service logs match role=%q service=%q", role, service)
+	}
+
+	return selected, nil
+}
+
+// matchRole accepts "master" for the "masters" directory and similar
+func matchRole(actual, requested string) bool {
+	requested = strings.ToLower(requested)
+	actual = strings.ToLower(actual)
+	return actual == requested || actual == requested+"s" || strings.TrimSuffix(actual, "s") == requested
+}
+
+func formatSize(size int64) string {
+	switch {
+	case size >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	case size >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+func truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
@@ -0,0 +1,363 @@
+package hostservices
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+)
+
+func logTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "host_services_list",
+				Description: "List host service logs (crio, kubelet, NetworkManager, machine-config-daemon, ...) collected per node role, with the nodes and time range each covers",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"role": {
+							Type:        "string",
+							Description: "Filter by node role directory (e.g., masters, workers) (optional)",
+						},
+						"node": {
+							Type:        "string",
+							Description: "Only show services with entries from this node (optional)",
+						},
+					},
+				},
+			},
+			Handler: hostServicesList,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "host_service_logs_get",
+				Description: "Get the journal of a host service for a node role, optionally filtered by node and time window",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"role": {
+							Type:        "string",
+							Description: "Node role directory (e.g., masters, workers)",
+						},
+						"service": {
+							Type:        "string",
+							Description: "Service name (e.g., crio, kubelet, NetworkManager, machine-config-daemon-host)",
+						},
+						"node": {
+							Type:        "string",
+							Description: "Only show entries from this node (optional)",
+						},
+						"since": {
+							Type:        "string",
+							Description: "Only show entries at or after this RFC3339 time (optional)",
+						},
+						"until": {
+							Type:        "string",
+							Description: "Only show entries at or before this RFC3339 time (optional)",
+						},
+						"tail": {
+							Type:        "integer",
+							Description: "Number of entries from the end (0 for all, default: 200)",
+						},
+					},
+					Required: []string{"role", "service"},
+				},
+			},
+			Handler: hostServiceLogsGet,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "host_service_logs_grep",
+				Description: "Search host service logs with a regular expression across roles and services, optionally filtered by node and time window",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"pattern": {
+							Type:        "string",
+							Description: "Regular expression to search for",
+						},
+						"role": {
+							Type:        "string",
+							Description: "Filter by node role directory (optional)",
+						},
+						"service": {
+							Type:        "string",
+							Description: "Filter by service name (optional)",
+						},
+						"node": {
+							Type:        "string",
+							Description: "Filter by node (optional)",
+						},
+						"since": {
+							Type:        "string",
+							Description: "Only search entries at or after this RFC3339 time (optional)",
+						},
+						"until": {
+							Type:        "string",
+							Description: "Only search entries at or before this RFC3339 time (optional)",
+						},
+						"ignoreCase": {
+							Type:        "boolean",
+							Description: "Case-insensitive search (default: false)",
+						},
+						"maxMatches": {
+							Type:        "integer",
+							Description: "Maximum matching lines to return (default: 100)",
+						},
+					},
+					Required: []string{"pattern"},
+				},
+			},
+			Handler: hostServiceLogsGrep,
+		},
+	}
+}
+
+// serviceCoverage summarizes which nodes and times a service log covers
+type serviceCoverage struct {
+	Log     api.HostServiceLog
+	Entries int
+	Nodes   map[string]int
+	First   time.Time
+	Last    time.Time
+}
+
+func hostServicesList(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	role := params.GetString("role", "")
+	node := params.GetString("node", "")
+
+	logs, err := selectHostServiceLogs(params, role, "")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	refTime := gatherTime(params)
+
+	coverages := make([]*serviceCoverage, 0, len(logs))
+	for _, log := range logs {
+		content, err := params.MustGatherProvider.GetHostServiceLog(log.Role, log.Service)
+		if err != nil {
+			continue
+		}
+
+		coverage := &serviceCoverage{Log: log, Nodes: make(map[string]int)}
+		for _, entry := range parseJournal(content, refTime) {
+			coverage.Entries++
+			if entry.Host != "" {
+				coverage.Nodes[entry.Host]++
+			}
+			if entry.Time.IsZero() {
+				continue
+			}
+			if coverage.First.IsZero() || entry.Time.Before(coverage.First) {
+				coverage.First = entry.Time
+			}
+			if entry.Time.After(coverage.Last) {
+				coverage.Last = entry.Time
+			}
+		}
+
+		if node != "" && coverage.Nodes[node] == 0 {
+			continue
+		}
+		coverages = append(coverages, coverage)
+	}
+
+	if len(coverages) == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No host service logs with entries from node %s", node), nil), nil
+	}
+
+	output := "Host Service Logs\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	currentRole := ""
+	for _, c := range coverages {
+		if c.Log.Role != currentRole {
+			if currentRole != "" {
+				output += "\n"
+			}
+			currentRole = c.Log.Role
+			output += fmt.Sprintf("Role: %s\n", currentRole)
+			output += strings.Repeat("-", 80) + "\n"
+		}
+
+		compressed := ""
+		if c.Log.Compressed {
+			compressed = ", gzip"
+		}
+		output += fmt.Sprintf("  %s (%s%s, %d entries)\n", c.Log.Service, formatSize(c.Log.Size), compressed, c.Entries)
+		if !c.First.IsZero() {
+			output += fmt.Sprintf("    Time Range: %s -> %s\n", c.First.Format(time.RFC3339), c.Last.Format(time.RFC3339))
+		}
+
+		if len(c.Nodes) > 0 {
+			nodes := make([]string, 0, len(c.Nodes))
+			for n := range c.Nodes {
+				nodes = append(nodes, n)
+			}
+			sort.Strings(nodes)
+
+			parts := make([]string, 0, len(nodes))
+			for _, n := range nodes {
+				if node != "" && n != node {
+					continue
+				}
+				parts = append(parts, fmt.Sprintf("%s (%d)", n, c.Nodes[n]))
+			}
+			output += fmt.Sprintf("    Nodes: %s\n", strings.Join(parts, ", "))
+		}
+	}
+
+	output += fmt.Sprintf("\nTotal: %d service logs\n", len(coverages))
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+func hostServiceLogsGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	role := params.GetString("role", "")
+	service := params.GetString("service", "")
+	node := params.GetString("node", "")
+	tail := params.GetInt("tail", 200)
+
+	if role == "" || service == "" {
+		return api.NewToolCallResult("", fmt.Errorf("role and service are required")), nil
+	}
+
+	window, err := parseTimeWindow(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	logs, err := selectHostServiceLogs(params, role, service)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	log := logs[0]
+
+	content, err := params.MustGatherProvider.GetHostServiceLog(log.Role, log.Service)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	entries := make([]journalEntry, 0)
+	for _, entry := range parseJournal(content, gatherTime(params)) {
+		if node != "" && entry.Host != node {
+			continue
+		}
+		if !window.contains(entry) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	total := len(entries)
+	if tail > 0 && len(entries) > tail {
+		entries = entries[len(entries)-tail:]
+	}
+
+	output := fmt.Sprintf("Host Service Log: %s/%s\n", log.Role, log.Service)
+	output += strings.Repeat("=", 80) + "\n"
+	if node != "" {
+		output += fmt.Sprintf("Node: %s\n", node)
+	}
+	if w := window.String(); w != "" {
+		output += fmt.Sprintf("Window: %s\n", w)
+	}
+	output += fmt.Sprintf("Showing %d of %d entries\n\n", len(entries), total)
+
+	for _, entry := range entries {
+		output += entry.Raw + "\n"
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+func hostServiceLogsGrep(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	pattern := params.GetString("pattern", "")
+	role := params.GetString("role", "")
+	service := params.GetString("service", "")
+	node := params.GetString("node", "")
+	ignoreCase := params.GetBool("ignoreCase", false)
+	maxMatches := params.GetInt("maxMatches", 100)
+
+	if pattern == "" {
+		return api.NewToolCallResult("", fmt.Errorf("pattern is required")), nil
+	}
+	if ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid pattern: %w", err)), nil
+	}
+
+	window, err := parseTimeWindow(params)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	logs, err := selectHostServiceLogs(params, role, service)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	refTime := gatherTime(params)
+
+	output := fmt.Sprintf("Host Service Log Search: %s\n", params.GetString("pattern", ""))
+	output += strings.Repeat("=", 80) + "\n"
+	if w := window.String(); w != "" {
+		output += fmt.Sprintf("Window: %s\n", w)
+	}
+	output += "\n"
+
+	totalMatches := 0
+	shown := 0
+	perLog := make([]string, 0)
+	for _, log := range logs {
+		content, err := params.MustGatherProvider.GetHostServiceLog(log.Role, log.Service)
+		if err != nil {
+			continue
+		}
+
+		logMatches := 0
+		for _, entry := range parseJournal(content, refTime) {
+			if node != "" && entry.Host != node {
+				continue
+			}
+			if !window.contains(entry) || !re.MatchString(entry.Raw) {
+				continue
+			}
+
+			logMatches++
+			if shown < maxMatches {
+				if logMatches == 1 {
+					output += fmt.Sprintf("## %s/%s\n", log.Role, log.Service)
+				}
+				output += truncate(entry.Raw, 500) + "\n"
+				shown++
+			}
+		}
+
+		if logMatches > 0 {
+			perLog = append(perLog, fmt.Sprintf("%s/%s: %d", log.Role, log.Service, logMatches))
+			totalMatches += logMatches
+		}
+	}
+
+	if totalMatches == 0 {
+		return api.NewToolCallResult(fmt.Sprintf("No matches for %q in %d host service logs", params.GetString("pattern", ""), len(logs)), nil), nil
+	}
+
+	output += "\n" + strings.Repeat("-", 80) + "\n"
+	output += fmt.Sprintf("Matches: %d (showing %d)\n", totalMatches, shown)
+	for _, line := range perLog {
+		output += fmt.Sprintf("  %s\n", line)
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
@@ -0,0 +1,26 @@
+package hostservices
+
+import (
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"github.com/openshift/must-gather-mcp-server/pkg/toolsets"
+)
+
+// Toolset represents the host services toolset
+type Toolset struct{}
+
+// Name returns the toolset name
+func (t *Toolset) Name() string {
+	return "host_services"
+}
+
+// GetTools returns all tools in this toolset
+func (t *Toolset) GetTools() []api.ServerTool {
+	tools := make([]api.ServerTool, 0)
+	tools = append(tools, logTools()...)
+	tools = append(tools, failureTools()...)
+	return tools
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
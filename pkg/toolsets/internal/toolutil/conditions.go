@@ -0,0 +1,44 @@
+// Package toolutil holds small helpers shared by several toolsets for reading
+// gathered Kubernetes objects.
+package toolutil
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ProblemConditions returns the conditions of an object that report a problem
+func ProblemConditions(obj *unstructured.Unstructured) []string {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	problems := make([]string, 0)
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condType, _ := cond["type"].(string)
+		status, _ := cond["status"].(string)
+		message, _ := cond["message"].(string)
+
+		bad := false
+		switch condType {
+		case "Degraded", "Failure", "Error":
+			bad = status == "True"
+		case "Available", "ReconcileComplete", "Ready":
+			bad = status == "False"
+		}
+		if bad {
+			problems = append(problems, fmt.Sprintf("%s=%s: %s", condType, status, Truncate(message, 150)))
+		}
+	}
+	return problems
+}
+
+// Truncate truncates a string to maxLen, adding "..." if truncated
+func Truncate(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen-3] + "..."
+}
@@ -0,0 +1,45 @@
+package toolutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// LabelSelector converts a metav1.LabelSelector field, returning nil when it is unset
+func LabelSelector(object map[string]interface{}, fields ...string) (labels.Selector, error) {
+	value, found, _ := unstructured.NestedFieldNoCopy(object, fields...)
+	if !found || value == nil {
+		return nil, nil
+	}
+	raw, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is not a label selector", strings.Join(fields, "."))
+	}
+	var selector metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(raw, &selector); err != nil {
+		return nil, err
+	}
+	return metav1.LabelSelectorAsSelector(&selector)
+}
+
+// NamespaceLabels returns the labels of a namespace and whether it was
+// gathered; namespaces missing from the gather only carry the name label
+// every namespace has
+func NamespaceLabels(params api.ToolHandlerParams, namespace string) (map[string]string, bool) {
+	nsLabels := map[string]string{"kubernetes.io/metadata.name": namespace}
+	ns, err := params.MustGatherProvider.GetResource(params.Context, schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, "", namespace)
+	if err != nil {
+		return nsLabels, false
+	}
+	for key, value := range ns.GetLabels() {
+		nsLabels[key] = value
+	}
+	return nsLabels, true
+}
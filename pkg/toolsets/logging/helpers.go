@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openshift/must-gather-mcp-server/pkg/toolsets/internal/toolutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// problemConditions returns the conditions reporting a problem. Condition
+// types may be prefixed (observability.openshift.io/Valid) or suffixed with
+// the element they apply to (ValidOutput-es)
+func problemConditions(conditions []interface{}) []string {
+	problems := make([]string, 0)
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condType, _ := cond["type"].(string)
+		status, _ := cond["status"].(string)
+		reason, _ := cond["reason"].(string)
+		message, _ := cond["message"].(string)
+
+		base := condType[strings.LastIndex(condType, "/")+1:]
+		bad := false
+		for _, prefix := range []string{"Degraded", "Failed", "Failure", "Error", "Warning"} {
+			if strings.HasPrefix(base, prefix) {
+				bad = status == "True"
+			}
+		}
+		for _, prefix := range []string{"Ready", "Valid", "Authorized", "Available"} {
+			if strings.HasPrefix(base, prefix) {
+				bad = status == "False"
+			}
+		}
+		if !bad {
+			continue
+		}
+		problem := fmt.Sprintf("%s=%s", condType, status)
+		if reason != "" {
+			problem += fmt.Sprintf(" (%s)", reason)
+		}
+		if message != "" {
+			problem += ": " + toolutil.Truncate(message, 200)
+		}
+		problems = append(problems, problem)
+	}
+	return problems
+}
+
+// statusConditions returns status.conditions of an object
+func statusConditions(obj *unstructured.Unstructured) []interface{} {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	return conditions
+}
@@ -0,0 +1,551 @@
+package logging
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// loggingNamespace is the default namespace of the logging stack
+const loggingNamespace = "openshift-logging"
+
+// forwarderGroups hold ClusterLogForwarder: logging.openshift.io up to Logging 5,
+// observability.openshift.io from Logging 6
+var forwarderGroups = []string{"observability.openshift.io", "logging.openshift.io"}
+
+// reservedInputs are the built-in log sources a pipeline can reference
+var reservedInputs = map[string]bool{"application": true, "infrastructure": true, "audit": true}
+
+// elementStatus maps the sections of a forwarder spec to their status fields:
+// a map of name to conditions (logging.openshift.io) or a list of conditions
+// (observability.openshift.io)
+var elementStatus = []struct {
+	Section  string
+	MapField string
+	Field    string
+}{
+	{"inputs", "inputs", "inputConditions"},
+	{"outputs", "outputs", "outputConditions"},
+	{"filters", "filters", "filterConditions"},
+	{"pipelines", "pipelines", "pipelineConditions"},
+}
+
+func statusTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "logging_status",
+				Description: "Show cluster logging health: ClusterLogging and ClusterLogForwarder conditions, LokiStack or Elasticsearch log store status, and unhealthy collector and operator pods",
+				InputSchema: &jsonschema.Schema{
+					Type:       "object",
+					Properties: map[string]*jsonschema.Schema{},
+				},
+			},
+			Handler: loggingStatus,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "logging_forwarder_pipelines",
+				Description: "Show ClusterLogForwarder inputs, outputs (type and endpoint), filters and pipelines with their status, flagging pipelines that reference undefined inputs or outputs",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"namespace": {
+							Type:        "string",
+							Description: "ClusterLogForwarder namespace (default: all namespaces)",
+						},
+						"name": {
+							Type:        "string",
+							Description: "ClusterLogForwarder name (default: all forwarders)",
+						},
+					},
+				},
+			},
+			Handler: loggingForwarderPipelines,
+		},
+	}
+}
+
+func loggingStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	provider := params.MustGatherProvider
+
+	output := "Cluster Logging Status\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	problems := 0
+	namespaces := map[string]bool{loggingNamespace: true}
+
+	// ClusterLogging (Logging 5)
+	instanceList, err := provider.ListResourcesByGroupKind(params.Context, "logging.openshift.io", "ClusterLogging", "", api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list ClusterLogging instances: %w", err)), nil
+	}
+	instances := instanceList.Items
+	if len(instances) > 0 {
+		output += "ClusterLogging:\n"
+		output += strings.Repeat("-", 80) + "\n"
+		for i := range instances {
+			instance := &instances[i]
+			namespaces[instance.GetNamespace()] = true
+			conditions := problemConditions(statusConditions(instance))
+
+			state, _, _ := unstructured.NestedString(instance.Object, "spec", "managementState")
+			collector, _, _ := unstructured.NestedString(instance.Object, "spec", "collection", "type")
+			if collector == "" {
+				collector, _, _ = unstructured.NestedString(instance.Object, "spec", "collection", "logs", "type")
+			}
+			logStore, _, _ := unstructured.NestedString(instance.Object, "spec", "logStore", "type")
+
+			output += fmt.Sprintf("  %s %s", problemSymbol(len(conditions)), qualifiedName(instance))
+			if state != "" {
+				output += fmt.Sprintf("  managementState=%s", state)
+			}
+			if collector != "" {
+				output += fmt.Sprintf("  collector=%s", collector)
+			}
+			if logStore != "" {
+				output += fmt.Sprintf("  logStore=%s", logStore)
+			}
+			output += "\n"
+			for _, condition := range conditions {
+				output += fmt.Sprintf("      ✗ %s\n", condition)
+			}
+			problems += len(conditions)
+		}
+		output += "\n"
+	}
+
+	// ClusterLogForwarder
+	forwarders, err := listForwarders(params, "")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if len(forwarders) > 0 {
+		output += "Log Forwarders:\n"
+		output += strings.Repeat("-", 80) + "\n"
+		for i := range forwarders {
+			forwarder := &forwarders[i]
+			namespaces[forwarder.GetNamespace()] = true
+
+			conditions := problemConditions(statusConditions(forwarder))
+			for _, element := range elementStatus {
+				for name, elementProblems := range elementConditions(forwarder, element.MapField, element.Field) {
+					for _, problem := range elementProblems {
+						if name != "" {
+							problem = fmt.Sprintf("%s %s: %s", strings.TrimSuffix(element.Section, "s"), name, problem)
+						}
+						conditions = append(conditions, problem)
+					}
+				}
+			}
+			sort.Strings(conditions)
+
+			outputs, _, _ := unstructured.NestedSlice(forwarder.Object, "spec", "outputs")
+			pipelines, _, _ := unstructured.NestedSlice(forwarder.Object, "spec", "pipelines")
+			output += fmt.Sprintf("  %s %s (%s)  outputs=%d  pipelines=%d\n", problemSymbol(len(conditions)),
+				qualifiedName(forwarder), forwarder.GroupVersionKind().Group, len(outputs), len(pipelines))
+			for _, condition := range conditions {
+				output += fmt.Sprintf("      ✗ %s\n", condition)
+			}
+			problems += len(conditions)
+		}
+		output += "\n"
+	}
+
+	// Log stores
+	lokiList, err := provider.ListResourcesByGroupKind(params.Context, "loki.grafana.com", "LokiStack", "", api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list LokiStacks: %w", err)), nil
+	}
+	esList, err := provider.ListResourcesByGroupKind(params.Context, "logging.openshift.io", "Elasticsearch", "", api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list Elasticsearch instances: %w", err)), nil
+	}
+	lokiStacks, elasticsearches := lokiList.Items, esList.Items
+	if len(lokiStacks) > 0 || len(elasticsearches) > 0 {
+		output += "Log Stores:\n"
+		output += strings.Repeat("-", 80) + "\n"
+	}
+	for i := range lokiStacks {
+		stack := &lokiStacks[i]
+		namespaces[stack.GetNamespace()] = true
+		conditions := problemConditions(statusConditions(stack))
+		conditions = append(conditions, lokiComponentProblems(stack)...)
+
+		size, _, _ := unstructured.NestedString(stack.Object, "spec", "size")
+		output += fmt.Sprintf("  %s LokiStack %s  size=%s\n", problemSymbol(len(conditions)), qualifiedName(stack), size)
+		for _, condition := range conditions {
+			output += fmt.Sprintf("      ✗ %s\n", condition)
+		}
+		problems += len(conditions)
+	}
+	for i := range elasticsearches {
+		es := &elasticsearches[i]
+		health, _, _ := unstructured.NestedString(es.Object, "status", "cluster", "status")
+		symbol := "✓"
+		switch health {
+		case "yellow":
+			symbol = "⚠"
+			problems++
+		case "red":
+			symbol = "✗"
+			problems++
+		}
+		output += fmt.Sprintf("  %s Elasticsearch %s  health=%s\n", symbol, qualifiedName(es), health)
+	}
+	if len(lokiStacks) > 0 || len(elasticsearches) > 0 {
+		output += "\n"
+	}
+
+	// Pods
+	names := make([]string, 0, len(namespaces))
+	for ns := range namespaces {
+		names = append(names, ns)
+	}
+	sort.Strings(names)
+	for _, ns := range names {
+		pods, err := provider.ListResources(params.Context, schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, ns, api.ListOptions{})
+		if err != nil || len(pods.Items) == 0 {
+			continue
+		}
+		unhealthy := make([]string, 0)
+		for i := range pods.Items {
+			if problem := podProblem(&pods.Items[i]); problem != "" {
+				unhealthy = append(unhealthy, fmt.Sprintf("%s: %s", pods.Items[i].GetName(), problem))
+			}
+		}
+		sort.Strings(unhealthy)
+
+		output += fmt.Sprintf("Pods in %s: %d total, %d unhealthy\n", ns, len(pods.Items), len(unhealthy))
+		output += strings.Repeat("-", 80) + "\n"
+		if len(unhealthy) == 0 {
+			output += "  ✓ All pods running and ready\n"
+		}
+		for _, pod := range unhealthy {
+			output += fmt.Sprintf("  ✗ %s\n", pod)
+		}
+		problems += len(unhealthy)
+		output += "\n"
+	}
+
+	output += strings.Repeat("=", 80) + "\n"
+	if problems == 0 {
+		output += "✓ No cluster logging problems found\n"
+	} else {
+		output += fmt.Sprintf("⚠ %d problem(s) found; use logging_forwarder_pipelines for forwarder details\n", problems)
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+func loggingForwarderPipelines(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace := params.GetString("namespace", "")
+	name := params.GetString("name", "")
+
+	forwarders, err := listForwarders(params, namespace)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if name != "" {
+		filtered := make([]unstructured.Unstructured, 0, 1)
+		for _, forwarder := range forwarders {
+			if forwarder.GetName() == name {
+				filtered = append(filtered, forwarder)
+			}
+		}
+		forwarders = filtered
+	}
+	if len(forwarders) == 0 {
+		return api.NewToolCallResult("No ClusterLogForwarder found", nil), nil
+	}
+
+	output := ""
+	for i := range forwarders {
+		output += formatForwarder(&forwarders[i])
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+func formatForwarder(forwarder *unstructured.Unstructured) string {
+	legacy := forwarder.GroupVersionKind().Group == "logging.openshift.io"
+
+	output := fmt.Sprintf("ClusterLogForwarder: %s (%s)\n", qualifiedName(forwarder), forwarder.GetAPIVersion())
+	output += strings.Repeat("=", 80) + "\n"
+
+	conditions := problemConditions(statusConditions(forwarder))
+	if len(conditions) == 0 {
+		output += "Status: ✓ Ready\n"
+	} else {
+		output += "Status: ✗ Not ready\n"
+		for _, condition := range conditions {
+			output += fmt.Sprintf("  ✗ %s\n", condition)
+		}
+	}
+	serviceAccount, _, _ := unstructured.NestedString(forwarder.Object, "spec", "serviceAccount", "name")
+	if serviceAccount == "" {
+		serviceAccount, _, _ = unstructured.NestedString(forwarder.Object, "spec", "serviceAccountName")
+	}
+	if serviceAccount != "" {
+		output += fmt.Sprintf("Service Account: %s\n", serviceAccount)
+	}
+	output += "\n"
+
+	// Element status: named for logging.openshift.io, a flat list otherwise
+	named := make(map[string]map[string][]string)
+	unnamed := make([]string, 0)
+	for _, element := range elementStatus {
+		byName := elementConditions(forwarder, element.MapField, element.Field)
+		named[element.Section] = byName
+		unnamed = append(unnamed, byName[""]...)
+	}
+
+	defined := make(map[string]map[string]bool)
+	for _, section := range []string{"inputs", "outputs", "filters"} {
+		defined[section] = make(map[string]bool)
+		items, _, _ := unstructured.NestedSlice(forwarder.Object, "spec", section)
+		if len(items) == 0 {
+			continue
+		}
+		output += fmt.Sprintf("%s:\n", strings.ToUpper(section[:1])+section[1:])
+		for _, item := range items {
+			spec, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			itemName, _ := spec["name"].(string)
+			defined[section][itemName] = true
+			problems := named[section][itemName]
+
+			line := fmt.Sprintf("  %s %-30s %-20s", problemSymbol(len(problems)), itemName, elementType(section, spec))
+			if section == "outputs" {
+				line += " " + outputEndpoint(spec)
+			}
+			output += strings.TrimRight(line, " ") + "\n"
+			for _, problem := range problems {
+				output += fmt.Sprintf("      ✗ %s\n", problem)
+			}
+		}
+		output += "\n"
+	}
+
+	pipelines, _, _ := unstructured.NestedSlice(forwarder.Object, "spec", "pipelines")
+	output += "Pipelines:\n"
+	if len(pipelines) == 0 {
+		output += "  ✗ No pipelines defined, no logs are forwarded\n"
+	}
+	for i, p := range pipelines {
+		pipeline, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		pipelineName, _ := pipeline["name"].(string)
+		if pipelineName == "" {
+			pipelineName = fmt.Sprintf("pipeline_%d", i)
+		}
+		inputs := stringSlice(pipeline["inputRefs"])
+		filters := stringSlice(pipeline["filterRefs"])
+		outputs := stringSlice(pipeline["outputRefs"])
+
+		problems := append([]string(nil), named["pipelines"][pipelineName]...)
+		for _, ref := range inputs {
+			if !defined["inputs"][ref] && !reservedInputs[ref] {
+				problems = append(problems, fmt.Sprintf("input %q is not defined", ref))
+			}
+		}
+		for _, ref := range filters {
+			if !defined["filters"][ref] {
+				problems = append(problems, fmt.Sprintf("filter %q is not defined", ref))
+			}
+		}
+		for _, ref := range outputs {
+			// "default" is the ClusterLogging log store in logging.openshift.io
+			if !defined["outputs"][ref] && !(legacy && ref == "default") {
+				problems = append(problems, fmt.Sprintf("output %q is not defined", ref))
+			}
+		}
+
+		flow := strings.Join(inputs, ", ")
+		if len(filters) > 0 {
+			flow += " -> [" + strings.Join(filters, ", ") + "]"
+		}
+		flow += " -> " + strings.Join(outputs, ", ")
+		output += fmt.Sprintf("  %s %s: %s\n", problemSymbol(len(problems)), pipelineName, flow)
+		for _, problem := range problems {
+			output += fmt.Sprintf("      ✗ %s\n", problem)
+		}
+	}
+
+	if len(unnamed) > 0 {
+		output += "\nElement Conditions:\n"
+		for _, problem := range unnamed {
+			output += fmt.Sprintf("  ✗ %s\n", problem)
+		}
+	}
+	output += "\n"
+
+	return output
+}
+
+// listForwarders lists ClusterLogForwarders of both API groups
+func listForwarders(params api.ToolHandlerParams, namespace string) ([]unstructured.Unstructured, error) {
+	forwarders := make([]unstructured.Unstructured, 0)
+	for _, group := range forwarderGroups {
+		list, err := params.MustGatherProvider.ListResourcesByGroupKind(params.Context, group, "ClusterLogForwarder", namespace, api.ListOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s ClusterLogForwarders: %w", group, err)
+		}
+		forwarders = append(forwarders, list.Items...)
+	}
+	return forwarders, nil
+}
+
+// elementConditions returns the problems of a forwarder section by element
+// name; conditions that are not attached to a name use the "" key
+func elementConditions(forwarder *unstructured.Unstructured, mapField, listField string) map[string][]string {
+	result := make(map[string][]string)
+	if byName, found, _ := unstructured.NestedMap(forwarder.Object, "status", mapField); found {
+		for name, conditions := range byName {
+			if list, ok := conditions.([]interface{}); ok {
+				if problems := problemConditions(list); len(problems) > 0 {
+					result[name] = problems
+				}
+			}
+		}
+	}
+	if list, found, _ := unstructured.NestedSlice(forwarder.Object, "status", listField); found {
+		if problems := problemConditions(list); len(problems) > 0 {
+			result[""] = append(result[""], problems...)
+		}
+	}
+	return result
+}
+
+// elementType returns the type of an input, output or filter spec
+func elementType(section string, spec map[string]interface{}) string {
+	if typ, _ := spec["type"].(string); typ != "" {
+		return typ
+	}
+	if section == "inputs" {
+		for _, typ := range []string{"application", "infrastructure", "audit", "receiver"} {
+			if _, ok := spec[typ]; ok {
+				return typ
+			}
+		}
+	}
+	return ""
+}
+
+// outputEndpoint returns where an output sends logs
+func outputEndpoint(spec map[string]interface{}) string {
+	if url, _ := spec["url"].(string); url != "" {
+		return url
+	}
+	typ, _ := spec["type"].(string)
+	config, ok := spec[typ].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if url, _ := config["url"].(string); url != "" {
+		return url
+	}
+	if target, ok := config["target"].(map[string]interface{}); ok {
+		targetName, _ := target["name"].(string)
+		targetNamespace, _ := target["namespace"].(string)
+		if targetNamespace != "" {
+			return "lokistack " + targetNamespace + "/" + targetName
+		}
+		return "lokistack " + targetName
+	}
+	if brokers := stringSlice(config["brokers"]); len(brokers) > 0 {
+		return strings.Join(brokers, ",")
+	}
+	if region, _ := config["region"].(string); region != "" {
+		return "region " + region
+	}
+	return ""
+}
+
+// lokiComponentProblems lists LokiStack component pods that are not running
+func lokiComponentProblems(stack *unstructured.Unstructured) []string {
+	components, _, _ := unstructured.NestedMap(stack.Object, "status", "components")
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	problems := make([]string, 0)
+	for _, name := range names {
+		states, ok := components[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, state := range []string{"Pending", "Failed", "Unknown"} {
+			if pods := stringSlice(states[state]); len(pods) > 0 {
+				problems = append(problems, fmt.Sprintf("%s %s: %s", name, state, strings.Join(pods, ", ")))
+			}
+		}
+	}
+	return problems
+}
+
+// podProblem returns why a pod is unhealthy, or "" when it is running and ready
+func podProblem(pod *unstructured.Unstructured) string {
+	phase, _, _ := unstructured.NestedString(pod.Object, "status", "phase")
+	if phase == "Succeeded" {
+		return ""
+	}
+	statuses, _, _ := unstructured.NestedSlice(pod.Object, "status", "containerStatuses")
+	for _, s := range statuses {
+		status, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ready, _ := status["ready"].(bool); ready {
+			continue
+		}
+		containerName, _ := status["name"].(string)
+		if reason, _, _ := unstructured.NestedString(status, "state", "waiting", "reason"); reason != "" {
+			return fmt.Sprintf("container %s %s", containerName, reason)
+		}
+		if reason, _, _ := unstructured.NestedString(status, "state", "terminated", "reason"); reason != "" {
+			return fmt.Sprintf("container %s terminated (%s)", containerName, reason)
+		}
+		return fmt.Sprintf("container %s not ready", containerName)
+	}
+	if phase != "Running" {
+		return fmt.Sprintf("phase %s", phase)
+	}
+	return ""
+}
+
+func problemSymbol(problems int) string {
+	if problems > 0 {
+		return "✗"
+	}
+	return "✓"
+}
+
+func stringSlice(value interface{}) []string {
+	items, _ := value.([]interface{})
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+func qualifiedName(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
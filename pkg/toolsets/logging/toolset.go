@@ -0,0 +1,34 @@
+package logging
+
+import (
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"github.com/openshift/must-gather-mcp-server/pkg/toolsets"
+)
+
+// Toolset represents the cluster logging toolset, enabled when the must-gather
+// includes logging resources (cluster-logging must-gather image)
+type Toolset struct{}
+
+// Name returns the toolset name
+func (t *Toolset) Name() string {
+	return "logging"
+}
+
+// GetTools returns all tools in this toolset
+func (t *Toolset) GetTools() []api.ServerTool {
+	tools := make([]api.ServerTool, 0)
+	tools = append(tools, statusTools()...)
+	return tools
+}
+
+// Detect reports whether cluster logging resources were gathered
+func (t *Toolset) Detect(provider api.MustGatherProvider) bool {
+	return provider.HasGroupKind("logging.openshift.io", "ClusterLogging") ||
+		provider.HasGroupKind("logging.openshift.io", "ClusterLogForwarder") ||
+		provider.HasGroupKind("observability.openshift.io", "ClusterLogForwarder") ||
+		provider.HasGroupKind("loki.grafana.com", "LokiStack")
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
@@ -0,0 +1,978 @@
+package monitoring
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"gopkg.in/yaml.v3"
+)
+
+// Alertmanager API dumps a must-gather may hold next to status.json
+var (
+	alertManagerSilenceFiles = []string{"silences.json"}
+	alertManagerGroupFiles   = []string{"alert-groups.json", "alertgroups.json", "groups.json"}
+)
+
+// matcherPattern matches a single label matcher such as severity=~"critical|warning"
+var matcherPattern = regexp.MustCompile(`^\s*("(?:[^"\\]|\\.)*"|[a-zA-Z_:][a-zA-Z0-9_:]*)\s*(=~|!~|!=|=)\s*(.*?)\s*$`)
+
+// labelMatcher is a parsed Alertmanager label matcher
+type labelMatcher struct {
+	Name  string
+	Op    string
+	Value string
+	re    *regexp.Regexp
+}
+
+func newLabelMatcher(name, op, value string) (labelMatcher, error) {
+	m := labelMatcher{Name: name, Op: op, Value: value}
+	if op == "=~" || op == "!~" {
+		re, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return m, fmt.Errorf("invalid regex in matcher %s: %w", m, err)
+		}
+		m.re = re
+	}
+	return m, nil
+}
+
+func (m labelMatcher) matches(labels map[string]string) bool {
+	value := labels[m.Name]
+	switch m.Op {
+	case "=":
+		return value == m.Value
+	case "!=":
+		return value != m.Value
+	case "=~":
+		return m.re.MatchString(value)
+	case "!~":
+		return !m.re.MatchString(value)
+	}
+	return false
+}
+
+func (m labelMatcher) String() string {
+	return fmt.Sprintf("%s%s%q", m.Name, m.Op, m.Value)
+}
+
+// routeResult is a route an alert ends up at, with inherited settings resolved
+type routeResult struct {
+	Path                []string
+	Receiver            string
+	GroupBy             []string
+	GroupWait           string
+	GroupInterval       string
+	RepeatInterval      string
+	MuteTimeIntervals   []string
+	ActiveTimeIntervals []string
+}
+
+// inhibitRule is a compiled inhibit rule, Index counts from 1
+type inhibitRule struct {
+	Index  int
+	Source []labelMatcher
+	Target []labelMatcher
+	Equal  []string
+}
+
+// inhibition is an inhibit rule together with the alert that triggers it
+type inhibition struct {
+	Rule   inhibitRule
+	Source map[string]string
+}
+
+// routedAlert is an alert instance to evaluate against the Alertmanager config
+type routedAlert struct {
+	Labels   map[string]string
+	State    string
+	Origin   string
+	Reported *AlertManagerAlert
+}
+
+func alertManagerTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "monitoring_alert_routing",
+				Description: "Explain what Alertmanager does with an alert: evaluates the gathered route tree against its labels to find the receivers, and checks active silences and inhibit rules (against the other firing alerts) to tell whether it was silenced or inhibited. Uses the alert groups reported by Alertmanager when they were gathered",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"alertName": {
+							Type:        "string",
+							Description: "Alert name, e.g. KubePodCrashLooping. Its active instances are evaluated",
+						},
+						"labels": {
+							Type:        "string",
+							Description: "Evaluate a hypothetical alert with these labels instead, e.g. 'severity=critical,namespace=openshift-etcd'",
+						},
+						"namespace": {
+							Type:        "string",
+							Description: "Only evaluate instances with this namespace label",
+						},
+						"limit": {
+							Type:        "integer",
+							Description: "Maximum instances to evaluate (default: 10)",
+						},
+					},
+				},
+			},
+			Handler: alertRouting,
+		},
+	}
+}
+
+func alertRouting(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	alertName := params.GetString("alertName", "")
+	labelsParam := params.GetString("labels", "")
+	nsFilter := params.GetString("namespace", "")
+	limit := params.GetInt("limit", 10)
+
+	if alertName == "" && labelsParam == "" {
+		return api.NewToolCallResult("", fmt.Errorf("alertName or labels is required")), nil
+	}
+
+	files := params.MustGatherProvider.Files()
+
+	config, configSource, err := loadAlertManagerConfig(files)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if config.Route == nil {
+		return api.NewToolCallResult("", fmt.Errorf("Alertmanager config from %s has no route", configSource)), nil
+	}
+	rules, err := compileInhibitRules(config.InhibitRules)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	silences, silenceSource, err := loadSilences(files)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	groups, groupSource, err := loadAlertGroups(files)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+
+	externalLabels := prometheusExternalLabels(files)
+	promAlerts, _ := loadActiveAlerts(files)
+
+	// Alerts Alertmanager knows about: the gathered alert groups, or else the
+	// alerts Prometheus was firing
+	var sources []map[string]string
+	var reported []AlertManagerAlert
+	if groups != nil {
+		for _, group := range groups {
+			for _, alert := range group.Alerts {
+				reported = append(reported, alert)
+				sources = append(sources, alert.Labels)
+			}
+		}
+	} else {
+		for _, alert := range promAlerts {
+			if alert.Alert.State == "firing" {
+				sources = append(sources, withExternalLabels(alert.Alert.Labels, externalLabels))
+			}
+		}
+	}
+
+	var instances []routedAlert
+	title := alertName
+	if labelsParam != "" {
+		labels, err := parseLabelSet(labelsParam)
+		if err != nil {
+			return api.NewToolCallResult("", err), nil
+		}
+		if alertName != "" {
+			labels["alertname"] = alertName
+		}
+		title = formatLabelSet(labels)
+		instances = append(instances, routedAlert{Labels: labels, State: "firing", Origin: "labels parameter"})
+	} else {
+		instances = alertInstances(alertName, promAlerts, reported, externalLabels)
+		if len(instances) == 0 {
+			rule, found := findAlertingRule(files, alertName)
+			if !found {
+				return api.NewToolCallResult("",
+					fmt.Errorf("alert %q has no active instances and no alerting rule in rules.json; pass labels to evaluate it", alertName)), nil
+			}
+			labels := withExternalLabels(rule.Labels, externalLabels)
+			labels["alertname"] = alertName
+			instances = append(instances, routedAlert{Labels: labels, State: "inactive", Origin: "rule labels (no active instance)"})
+		}
+	}
+
+	if nsFilter != "" {
+		filtered := make([]routedAlert, 0, len(instances))
+		for _, instance := range instances {
+			if instance.Labels["namespace"] == nsFilter {
+				filtered = append(filtered, instance)
+			}
+		}
+		instances = filtered
+	}
+
+	receivers := make(map[string]AlertManagerReceiver, len(config.Receivers))
+	for _, receiver := range config.Receivers {
+		receivers[receiver.Name] = receiver
+	}
+
+	output := fmt.Sprintf("Alert Routing: %s\n", title)
+	output += strings.Repeat("=", 80) + "\n\n"
+	output += fmt.Sprintf("Config: %s (%d receivers, %d inhibit rules)\n", configSource, len(config.Receivers), len(config.InhibitRules))
+	if silences != nil {
+		output += fmt.Sprintf("Silences: %s (%s)\n", silenceSource, silenceStateCounts(silences))
+	} else {
+		output += "Silences: not gathered, silencing can't be evaluated\n"
+	}
+	if groups != nil {
+		output += fmt.Sprintf("Alert Groups: %s (%d groups, %d alerts)\n", groupSource, len(groups), len(reported))
+	} else {
+		output += fmt.Sprintf("Alert Groups: not gathered, inhibitions evaluated against %d firing alerts from rules.json\n", len(sources))
+	}
+	if len(externalLabels) > 0 {
+		output += fmt.Sprintf("External Labels: %s (added by Prometheus)\n", formatLabelSet(externalLabels))
+	}
+	output += "\n"
+
+	if len(instances) == 0 {
+		output += fmt.Sprintf("No instances in namespace %s.\n", nsFilter)
+		return api.NewToolCallResult(output, nil), nil
+	}
+
+	for i, instance := range instances {
+		if limit > 0 && i >= limit {
+			output += fmt.Sprintf("... and %d more instances (raise limit to see them)\n", len(instances)-limit)
+			break
+		}
+
+		results, err := evaluateRoute(config.Route, routeResult{}, "root", instance.Labels)
+		if err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("failed to evaluate route tree: %w", err)), nil
+		}
+		silencedBy := matchingSilences(silences, instance.Labels)
+		inhibitedBy := inhibitionsFor(rules, instance.Labels, sources)
+
+		output += fmt.Sprintf("Instance %d/%d: %s %s\n", i+1, len(instances), statusSymbol(instance.State), instance.State)
+		output += strings.Repeat("-", 80) + "\n"
+		output += fmt.Sprintf("Labels: %s\n", formatLabelSet(instance.Labels))
+		output += fmt.Sprintf("Source: %s\n\n", instance.Origin)
+
+		output += "Route:\n"
+		delivered := make([]string, 0, len(results))
+		for _, result := range results {
+			output += fmt.Sprintf("  %s\n", strings.Join(result.Path, " → "))
+			output += "    " + formatReceiver(result.Receiver, receivers) + "\n"
+			output += fmt.Sprintf("    group_by: %s", valueOr(strings.Join(result.GroupBy, ", "), "(none)"))
+			if result.GroupWait != "" || result.GroupInterval != "" || result.RepeatInterval != "" {
+				output += fmt.Sprintf(", group_wait %s, group_interval %s, repeat_interval %s",
+					valueOr(result.GroupWait, "(default)"), valueOr(result.GroupInterval, "(default)"), valueOr(result.RepeatInterval, "(default)"))
+			}
+			output += "\n"
+			if len(result.MuteTimeIntervals) > 0 {
+				output += fmt.Sprintf("    ⚠ Muted during time intervals: %s\n", strings.Join(result.MuteTimeIntervals, ", "))
+			}
+			if len(result.ActiveTimeIntervals) > 0 {
+				output += fmt.Sprintf("    ⚠ Only active during time intervals: %s\n", strings.Join(result.ActiveTimeIntervals, ", "))
+			}
+			if hasIntegrations(receivers[result.Receiver]) {
+				delivered = append(delivered, result.Receiver)
+			}
+		}
+		output += "\n"
+
+		switch {
+		case silences == nil:
+			output += "Silenced: ? silences not gathered\n"
+		case len(silencedBy) == 0:
+			output += "Silenced: ✓ no active silence matches\n"
+		default:
+			output += fmt.Sprintf("Silenced: ⚠ by %d active silence(s)\n", len(silencedBy))
+			for _, silence := range silencedBy {
+				output += "  " + formatSilence(silence) + "\n"
+			}
+		}
+
+		if len(inhibitedBy) == 0 {
+			output += "Inhibited: ✓ no inhibit rule applies\n"
+		} else {
+			output += fmt.Sprintf("Inhibited: ⚠ by %d inhibit rule(s)\n", len(inhibitedBy))
+			for _, inh := range inhibitedBy {
+				output += fmt.Sprintf("  #%d %s\n", inh.Rule.Index, formatInhibitRule(inh.Rule))
+				output += fmt.Sprintf("     source alert: %s\n", formatLabelSet(inh.Source))
+			}
+		}
+
+		if instance.Reported != nil {
+			output += "\n" + formatReportedStatus(instance.Reported, len(silencedBy) > 0 || len(inhibitedBy) > 0) + "\n"
+		}
+
+		output += "\n"
+		switch {
+		case len(silencedBy) > 0:
+			output += "Verdict: ⚠ Suppressed by a silence, no notification is sent\n"
+		case len(inhibitedBy) > 0:
+			output += "Verdict: ⚠ Inhibited, no notification is sent\n"
+		case len(delivered) == 0:
+			output += "Verdict: ✗ Routed only to receivers without integrations, no notification is sent\n"
+		case instance.State == "pending":
+			output += fmt.Sprintf("Verdict: Pending in Prometheus, once firing it goes to %s\n", strings.Join(uniqueStrings(delivered), ", "))
+		case instance.State == "inactive":
+			output += fmt.Sprintf("Verdict: Not active, when it fires it goes to %s\n", strings.Join(uniqueStrings(delivered), ", "))
+		default:
+			output += fmt.Sprintf("Verdict: ✓ Notifies %s\n", strings.Join(uniqueStrings(delivered), ", "))
+		}
+		output += "\n"
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// alertManagerDetails summarizes the routing config, silences and alert groups
+// for monitoring_alertmanager_status
+func alertManagerDetails(files api.GatherFS) string {
+	output := ""
+
+	config, source, err := loadAlertManagerConfig(files)
+	if err != nil {
+		output += fmt.Sprintf("\nRouting Configuration: ✗ %v\n", err)
+	} else {
+		output += fmt.Sprintf("\nRouting Configuration (%s):\n", source)
+		output += formatAlertManagerConfig(config)
+	}
+
+	silences, source, err := loadSilences(files)
+	switch {
+	case err != nil:
+		output += fmt.Sprintf("\nSilences: ✗ %v\n", err)
+	case silences == nil:
+		output += "\nSilences: (not gathered)\n"
+	default:
+		output += fmt.Sprintf("\nSilences (%s): %s\n", source, silenceStateCounts(silences))
+		for _, silence := range silences {
+			if silence.Status.State == "active" || silence.Status.State == "pending" {
+				output += "  " + formatSilence(silence) + "\n"
+			}
+		}
+	}
+
+	groups, source, err := loadAlertGroups(files)
+	switch {
+	case err != nil:
+		output += fmt.Sprintf("\nAlert Groups: ✗ %v\n", err)
+	case groups == nil:
+		output += "\nAlert Groups: (not gathered)\n"
+	default:
+		states := make(map[string]int)
+		for _, group := range groups {
+			for _, alert := range group.Alerts {
+				states[alert.Status.State]++
+			}
+		}
+		output += fmt.Sprintf("\nAlert Groups (%s): %d groups, %d active, %d suppressed, %d unprocessed alerts\n",
+			source, len(groups), states["active"], states["suppressed"], states["unprocessed"])
+		for i, group := range groups {
+			if i >= 20 {
+				output += fmt.Sprintf("  ... and %d more groups\n", len(groups)-20)
+				break
+			}
+			suppressed := 0
+			for _, alert := range group.Alerts {
+				if alert.Status.State == "suppressed" {
+					suppressed++
+				}
+			}
+			line := fmt.Sprintf("  • %s → %s: %d alerts", formatLabelSet(group.Labels), group.Receiver.Name, len(group.Alerts))
+			if suppressed > 0 {
+				line += fmt.Sprintf(" (%d suppressed)", suppressed)
+			}
+			output += line + "\n"
+		}
+	}
+
+	return output
+}
+
+// loadAlertManagerConfig parses alertmanager.yaml from status.json, or from a
+// gathered alertmanager.yaml file, and returns it with the file it came from
+func loadAlertManagerConfig(files api.GatherFS) (*AlertManagerConfigFile, string, error) {
+	amPath := getAlertManagerPath()
+
+	raw, source := "", ""
+	var status AlertManagerStatus
+	if err := readJSON(files, path.Join(amPath, "status.json"), &status); err == nil && status.Config.Original != "" {
+		raw, source = status.Config.Original, "status.json"
+	} else if name, ok := findAlertManagerFile(files, "alertmanager.yaml"); ok {
+		data, err := files.ReadFile(name)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read Alertmanager config: %w", err)
+		}
+		raw, source = string(data), path.Base(name)
+	}
+	if raw == "" {
+		return nil, "", fmt.Errorf("no Alertmanager configuration found in %s", amPath)
+	}
+
+	var config AlertManagerConfigFile
+	if err := yaml.Unmarshal([]byte(raw), &config); err != nil {
+		return nil, source, fmt.Errorf("failed to parse Alertmanager config from %s: %w", source, err)
+	}
+	return &config, source, nil
+}
+
+// loadSilences reads the gathered silences, nil when they were not gathered
+func loadSilences(files api.GatherFS) ([]AlertManagerSilence, string, error) {
+	name, ok := findAlertManagerFile(files, alertManagerSilenceFiles...)
+	if !ok {
+		return nil, "", nil
+	}
+	silences := make([]AlertManagerSilence, 0)
+	if err := readJSON(files, name, &silences); err != nil {
+		return nil, "", fmt.Errorf("failed to read Alertmanager silences: %w", err)
+	}
+	return silences, path.Base(name), nil
+}
+
+// loadAlertGroups reads the gathered alert groups, nil when they were not gathered
+func loadAlertGroups(files api.GatherFS) ([]AlertManagerAlertGroup, string, error) {
+	name, ok := findAlertManagerFile(files, alertManagerGroupFiles...)
+	if !ok {
+		return nil, "", nil
+	}
+	groups := make([]AlertManagerAlertGroup, 0)
+	if err := readJSON(files, name, &groups); err != nil {
+		return nil, "", fmt.Errorf("failed to read Alertmanager alert groups: %w", err)
+	}
+	return groups, path.Base(name), nil
+}
+
+// findAlertManagerFile returns the first of names found in the alertmanager
+// directory, then in its per-pod subdirectories
+func findAlertManagerFile(files api.GatherFS, names ...string) (string, bool) {
+	amPath := getAlertManagerPath()
+	dirs := []string{amPath}
+	if entries, err := files.ReadDir(amPath); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() {
+				dirs = append(dirs, path.Join(amPath, entry.Name()))
+			}
+		}
+	}
+	for _, dir := range dirs {
+		for _, name := range names {
+			if fileExists(files, path.Join(dir, name)) {
+				return path.Join(dir, name), true
+			}
+		}
+	}
+	return "", false
+}
+
+// prometheusExternalLabels returns the external labels Prometheus adds to the
+// alerts it sends to Alertmanager
+func prometheusExternalLabels(files api.GatherFS) map[string]string {
+	var configResp struct {
+		ConfigResponse
+		Data ConfigResponse `json:"data"`
+	}
+	if err := readJSON(files, path.Join(getPrometheusCommonPath(), "status", "config.json"), &configResp); err != nil {
+		return nil
+	}
+	raw := valueOr(configResp.YAML, configResp.Data.YAML)
+
+	var config struct {
+		Global struct {
+			ExternalLabels map[string]string `yaml:"external_labels"`
+		} `yaml:"global"`
+	}
+	if err := yaml.Unmarshal([]byte(raw), &config); err != nil {
+		return nil
+	}
+	return config.Global.ExternalLabels
+}
+
+// alertInstances returns the active instances of an alert from rules.json,
+// matched with the alerts Alertmanager reported, followed by reported alerts
+// Prometheus doesn't show. A reported alert matches when it carries all labels
+// of the Prometheus alert, as Prometheus adds its external labels when sending
+func alertInstances(alertName string, promAlerts []activeAlert, reported []AlertManagerAlert, externalLabels map[string]string) []routedAlert {
+	instances := make([]routedAlert, 0)
+	matched := make(map[int]bool)
+	for _, alert := range promAlerts {
+		if alert.RuleName != alertName {
+			continue
+		}
+		instance := routedAlert{Labels: withExternalLabels(alert.Alert.Labels, externalLabels), State: alert.Alert.State, Origin: "rules.json"}
+		for i := range reported {
+			if !matched[i] && hasLabels(reported[i].Labels, alert.Alert.Labels) {
+				matched[i] = true
+				instance.Labels = reported[i].Labels
+				instance.Origin = "rules.json and Alertmanager alert groups"
+				instance.Reported = &reported[i]
+				break
+			}
+		}
+		instances = append(instances, instance)
+	}
+	for i := range reported {
+		if matched[i] || reported[i].Labels["alertname"] != alertName {
+			continue
+		}
+		instances = append(instances, routedAlert{Labels: reported[i].Labels, State: "firing", Origin: "Alertmanager alert groups", Reported: &reported[i]})
+	}
+	return instances
+}
+
+// findAlertingRule returns the first alerting rule with the given name
+func findAlertingRule(files api.GatherFS, alertName string) (Rule, bool) {
+	var rulesAPIResp RuleGroupsAPIResponse
+	if err := readJSON(files, path.Join(getPrometheusCommonPath(), "rules.json"), &rulesAPIResp); err != nil {
+		return Rule{}, false
+	}
+	for _, group := range rulesAPIResp.Data.Groups {
+		for _, rule := range group.Rules {
+			if rule.Type == "alerting" && rule.Name == alertName {
+				return rule, true
+			}
+		}
+	}
+	return Rule{}, false
+}
+
+// evaluateRoute walks the route tree the way Alertmanager does: children are
+// tried in order, the first match wins unless it sets continue, and a route
+// without a matching child handles the alert itself. Receiver, grouping and
+// timing settings are inherited from the parent.
+func evaluateRoute(route *AlertManagerRoute, parent routeResult, name string, labels map[string]string) ([]routeResult, error) {
+	current := parent
+	current.Path = append(append([]string{}, parent.Path...), name)
+	if route.Receiver != "" {
+		current.Receiver = route.Receiver
+	}
+	if len(route.GroupBy) > 0 {
+		current.GroupBy = route.GroupBy
+	}
+	if route.GroupWait != "" {
+		current.GroupWait = route.GroupWait
+	}
+	if route.GroupInterval != "" {
+		current.GroupInterval = route.GroupInterval
+	}
+	if route.RepeatInterval != "" {
+		current.RepeatInterval = route.RepeatInterval
+	}
+	// Time intervals are not inherited, they only apply to the route that sets them
+	current.MuteTimeIntervals = route.MuteTimeIntervals
+	current.ActiveTimeIntervals = route.ActiveTimeIntervals
+
+	results := make([]routeResult, 0)
+	for i, child := range route.Routes {
+		if child == nil {
+			continue
+		}
+		matchers, err := compileMatchers(child.Matchers, child.Match, child.MatchRE)
+		if err != nil {
+			return nil, fmt.Errorf("%s routes[%d]: %w", name, i, err)
+		}
+		if !matchesAll(matchers, labels) {
+			continue
+		}
+		childResults, err := evaluateRoute(child, current, fmt.Sprintf("routes[%d] %s", i, formatMatchers(matchers)), labels)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, childResults...)
+		if !child.Continue {
+			break
+		}
+	}
+	if len(results) == 0 {
+		return []routeResult{current}, nil
+	}
+	return results, nil
+}
+
+func compileInhibitRules(rules []AlertManagerInhibitRule) ([]inhibitRule, error) {
+	compiled := make([]inhibitRule, 0, len(rules))
+	for i, rule := range rules {
+		source, err := compileMatchers(rule.SourceMatchers, rule.SourceMatch, rule.SourceMatchRE)
+		if err != nil {
+			return nil, fmt.Errorf("inhibit rule #%d source: %w", i+1, err)
+		}
+		target, err := compileMatchers(rule.TargetMatchers, rule.TargetMatch, rule.TargetMatchRE)
+		if err != nil {
+			return nil, fmt.Errorf("inhibit rule #%d target: %w", i+1, err)
+		}
+		compiled = append(compiled, inhibitRule{Index: i + 1, Source: source, Target: target, Equal: rule.Equal})
+	}
+	return compiled, nil
+}
+
+// inhibitionsFor returns the rules inhibiting an alert with the source alert
+// that triggers each. As in Alertmanager, an alert matching both sides of a
+// rule isn't inhibited by alerts that also match both sides
+func inhibitionsFor(rules []inhibitRule, labels map[string]string, sources []map[string]string) []inhibition {
+	result := make([]inhibition, 0)
+	for _, rule := range rules {
+		if !matchesAll(rule.Target, labels) {
+			continue
+		}
+		twoSided := matchesAll(rule.Source, labels)
+		for _, source := range sources {
+			if !matchesAll(rule.Source, source) || (twoSided && matchesAll(rule.Target, source)) {
+				continue
+			}
+			equal := true
+			for _, name := range rule.Equal {
+				if labels[name] != source[name] {
+					equal = false
+					break
+				}
+			}
+			if equal {
+				result = append(result, inhibition{Rule: rule, Source: source})
+				break
+			}
+		}
+	}
+	return result
+}
+
+// matchingSilences returns the active silences whose matchers all match
+func matchingSilences(silences []AlertManagerSilence, labels map[string]string) []AlertManagerSilence {
+	result := make([]AlertManagerSilence, 0)
+	for _, silence := range silences {
+		if silence.Status.State != "active" {
+			continue
+		}
+		matchers, err := silenceMatchers(silence)
+		if err != nil || len(matchers) == 0 {
+			continue
+		}
+		if matchesAll(matchers, labels) {
+			result = append(result, silence)
+		}
+	}
+	return result
+}
+
+func silenceMatchers(silence AlertManagerSilence) ([]labelMatcher, error) {
+	matchers := make([]labelMatcher, 0, len(silence.Matchers))
+	for _, m := range silence.Matchers {
+		equal := m.IsEqual == nil || *m.IsEqual
+		op := "="
+		switch {
+		case m.IsRegex && equal:
+			op = "=~"
+		case m.IsRegex:
+			op = "!~"
+		case !equal:
+			op = "!="
+		}
+		matcher, err := newLabelMatcher(m.Name, op, m.Value)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+	return matchers, nil
+}
+
+// compileMatchers combines the matchers, match and match_re forms of a route
+// or inhibit rule
+func compileMatchers(list []string, equal, regex map[string]string) ([]labelMatcher, error) {
+	matchers := make([]labelMatcher, 0, len(list)+len(equal)+len(regex))
+	for _, entry := range list {
+		parsed, err := parseMatchers(entry)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, parsed...)
+	}
+	for _, name := range sortedKeys(equal) {
+		matcher, _ := newLabelMatcher(name, "=", equal[name])
+		matchers = append(matchers, matcher)
+	}
+	for _, name := range sortedKeys(regex) {
+		matcher, err := newLabelMatcher(name, "=~", regex[name])
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+	return matchers, nil
+}
+
+// parseMatchers parses a matchers entry, which may hold several
+// comma-separated matchers in braces, e.g. {severity="critical",namespace=~"openshift-.*"}
+func parseMatchers(input string) ([]labelMatcher, error) {
+	input = strings.TrimSpace(input)
+	input = strings.TrimSuffix(strings.TrimPrefix(input, "{"), "}")
+
+	matchers := make([]labelMatcher, 0)
+	for _, part := range splitOutsideQuotes(input) {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		groups := matcherPattern.FindStringSubmatch(part)
+		if groups == nil {
+			return nil, fmt.Errorf("invalid matcher %q", strings.TrimSpace(part))
+		}
+		matcher, err := newLabelMatcher(unquote(groups[1]), groups[2], unquote(groups[3]))
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+	return matchers, nil
+}
+
+// parseLabelSet parses "name=value,name=value" into a label set
+func parseLabelSet(input string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, part := range splitOutsideQuotes(strings.Trim(strings.TrimSpace(input), "{}")) {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid label %q, expected name=value", strings.TrimSpace(part))
+		}
+		labels[unquote(strings.TrimSpace(name))] = unquote(strings.TrimSpace(value))
+	}
+	return labels, nil
+}
+
+// splitOutsideQuotes splits on commas that are not inside double quotes
+func splitOutsideQuotes(input string) []string {
+	parts := make([]string, 0)
+	start, quoted, escaped := 0, false, false
+	for i, r := range input {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quoted:
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			parts = append(parts, input[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, input[start:])
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			return unquoted
+		}
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+func matchesAll(matchers []labelMatcher, labels map[string]string) bool {
+	for _, matcher := range matchers {
+		if !matcher.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func withExternalLabels(labels, externalLabels map[string]string) map[string]string {
+	result := make(map[string]string, len(labels)+len(externalLabels))
+	for key, value := range externalLabels {
+		result[key] = value
+	}
+	for key, value := range labels {
+		result[key] = value
+	}
+	return result
+}
+
+// hasLabels reports whether labels contains every label of subset
+func hasLabels(labels, subset map[string]string) bool {
+	for key, value := range subset {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+func formatLabelSet(labels map[string]string) string {
+	parts := make([]string, 0, len(labels))
+	for _, key := range sortedKeys(labels) {
+		parts = append(parts, fmt.Sprintf("%s=%q", key, labels[key]))
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+func formatMatchers(matchers []labelMatcher) string {
+	parts := make([]string, 0, len(matchers))
+	for _, matcher := range matchers {
+		parts = append(parts, matcher.String())
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+func formatInhibitRule(rule inhibitRule) string {
+	line := fmt.Sprintf("source %s inhibits target %s", formatMatchers(rule.Source), formatMatchers(rule.Target))
+	if len(rule.Equal) > 0 {
+		line += " when equal: " + strings.Join(rule.Equal, ", ")
+	}
+	return line
+}
+
+func formatSilence(silence AlertManagerSilence) string {
+	matchers, err := silenceMatchers(silence)
+	matcherText := ""
+	if err != nil {
+		matcherText = fmt.Sprintf("(invalid: %v)", err)
+	} else {
+		matcherText = formatMatchers(matchers)
+	}
+	line := fmt.Sprintf("• %s %s %s until %s", silence.ID, silence.Status.State, matcherText, valueOr(silence.EndsAt, "?"))
+	if silence.CreatedBy != "" {
+		line += " by " + silence.CreatedBy
+	}
+	if silence.Comment != "" {
+		line += ": " + truncate(silence.Comment, 80)
+	}
+	return line
+}
+
+func silenceStateCounts(silences []AlertManagerSilence) string {
+	states := make(map[string]int)
+	for _, silence := range silences {
+		states[silence.Status.State]++
+	}
+	return fmt.Sprintf("%d active, %d pending, %d expired", states["active"], states["pending"], states["expired"])
+}
+
+// integrationTypes returns the notifier types of a receiver, e.g. pagerduty
+func integrationTypes(receiver AlertManagerReceiver) []string {
+	types := make([]string, 0)
+	for key, value := range receiver.Integrations {
+		if configs, ok := value.([]interface{}); ok && len(configs) > 0 && strings.HasSuffix(key, "_configs") {
+			types = append(types, strings.TrimSuffix(key, "_configs"))
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+func hasIntegrations(receiver AlertManagerReceiver) bool {
+	return len(integrationTypes(receiver)) > 0
+}
+
+func formatReceiver(name string, receivers map[string]AlertManagerReceiver) string {
+	receiver, ok := receivers[name]
+	switch {
+	case name == "":
+		return "✗ no receiver set"
+	case !ok:
+		return fmt.Sprintf("✗ receiver %s is not defined", name)
+	case !hasIntegrations(receiver):
+		return fmt.Sprintf("⚠ receiver %s has no integrations, notifications are dropped", name)
+	}
+	return fmt.Sprintf("✓ receiver %s (%s)", name, strings.Join(integrationTypes(receiver), ", "))
+}
+
+// formatReportedStatus shows what Alertmanager itself reported for an alert
+// and whether it agrees with the evaluation
+func formatReportedStatus(alert *AlertManagerAlert, suppressed bool) string {
+	names := make([]string, 0, len(alert.Receivers))
+	for _, receiver := range alert.Receivers {
+		names = append(names, receiver.Name)
+	}
+	output := fmt.Sprintf("Alertmanager reported: %s, receivers: %s", valueOr(alert.Status.State, "unknown"), valueOr(strings.Join(names, ", "), "(none)"))
+	if len(alert.Status.SilencedBy) > 0 {
+		output += fmt.Sprintf(", silenced by %s", strings.Join(alert.Status.SilencedBy, ", "))
+	}
+	if len(alert.Status.InhibitedBy) > 0 {
+		output += fmt.Sprintf(", inhibited by %s", strings.Join(alert.Status.InhibitedBy, ", "))
+	}
+	if reportedSuppressed := alert.Status.State == "suppressed"; reportedSuppressed != suppressed {
+		output += "\n  ⚠ Differs from the evaluation above, the gathered config or silences may have changed since"
+	}
+	return output
+}
+
+func formatAlertManagerConfig(config *AlertManagerConfigFile) string {
+	output := ""
+	if config.Route != nil {
+		output += "  Route Tree:\n"
+		output += formatRouteTree(config.Route, "root", "    ")
+	} else {
+		output += "  ✗ No route configured\n"
+	}
+
+	output += fmt.Sprintf("  Receivers (%d):\n", len(config.Receivers))
+	for _, receiver := range config.Receivers {
+		if types := integrationTypes(receiver); len(types) > 0 {
+			output += fmt.Sprintf("    ✓ %s - %s\n", receiver.Name, strings.Join(types, ", "))
+		} else {
+			output += fmt.Sprintf("    ⚠ %s - no integrations\n", receiver.Name)
+		}
+	}
+
+	output += fmt.Sprintf("  Inhibit Rules (%d):\n", len(config.InhibitRules))
+	rules, err := compileInhibitRules(config.InhibitRules)
+	if err != nil {
+		output += fmt.Sprintf("    ✗ %v\n", err)
+	}
+	for _, rule := range rules {
+		output += fmt.Sprintf("    #%d %s\n", rule.Index, formatInhibitRule(rule))
+	}
+
+	intervals := make([]string, 0)
+	for _, interval := range append(config.MuteTimeIntervals, config.TimeIntervals...) {
+		intervals = append(intervals, interval.Name)
+	}
+	if len(intervals) > 0 {
+		output += fmt.Sprintf("  Time Intervals: %s\n", strings.Join(intervals, ", "))
+	}
+	return output
+}
+
+func formatRouteTree(route *AlertManagerRoute, name, indent string) string {
+	line := indent + name
+	if route.Receiver != "" {
+		line += " → " + route.Receiver
+	}
+	if len(route.GroupBy) > 0 {
+		line += fmt.Sprintf(" (group_by: %s)", strings.Join(route.GroupBy, ", "))
+	}
+	if route.Continue {
+		line += " [continue]"
+	}
+	if len(route.MuteTimeIntervals) > 0 {
+		line += fmt.Sprintf(" [muted: %s]", strings.Join(route.MuteTimeIntervals, ", "))
+	}
+	output := line + "\n"
+
+	for i, child := range route.Routes {
+		if child == nil {
+			continue
+		}
+		matchers, err := compileMatchers(child.Matchers, child.Match, child.MatchRE)
+		childName := fmt.Sprintf("routes[%d] ", i)
+		if err != nil {
+			childName += fmt.Sprintf("✗ %v", err)
+		} else {
+			childName += formatMatchers(matchers)
+		}
+		output += formatRouteTree(child, childName, indent+"  ")
+	}
+	return output
+}
@@ -2,7 +2,7 @@ package monitoring
 
 import (
 	"fmt"
-	"path/filepath"
+	"path"
 	"sort"
 	"strings"
 
@@ -15,7 +15,7 @@ func alertTools() []api.ServerTool {
 		{
 			Tool: api.Tool{
 				Name:        "monitoring_alertmanager_status",
-				Description: "Get AlertManager cluster status including peers, version, and uptime",
+				Description: "Get AlertManager cluster status including peers, version, uptime, the routing tree, receivers and inhibit rules, and gathered silences and alert groups",
 				InputSchema: &jsonschema.Schema{
 					Type: "object",
 				},
@@ -78,18 +78,14 @@ func alertTools() []api.ServerTool {
 }
 
 func alertManagerStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	// Get container directory
-	containerDir, err := findContainerDir(params.MustGatherProvider.GetMetadata().Path)
-	if err != nil {
-		containerDir = params.MustGatherProvider.GetMetadata().Path
-	}
+	files := params.MustGatherProvider.Files()
 
 	// Read AlertManager status
-	amPath := getAlertManagerPath(containerDir)
-	statusFile := filepath.Join(amPath, "status.json")
+	amPath := getAlertManagerPath()
+	statusFile := path.Join(amPath, "status.json")
 
 	var status AlertManagerStatus
-	if err := readJSON(statusFile, &status); err != nil {
+	if err := readJSON(files, statusFile, &status); err != nil {
 		return api.NewToolCallResult("",
 			fmt.Errorf("failed to read AlertManager status: %w", err)), nil
 	}
@@ -120,6 +116,9 @@ func alertManagerStatus(params api.ToolHandlerParams) (*api.ToolCallResult, erro
 		}
 	}
 
+	// Routing config, silences and alert groups
+	output += alertManagerDetails(files)
+
 	return api.NewToolCallResult(output, nil), nil
 }
 
@@ -128,18 +127,14 @@ func prometheusRules(params api.ToolHandlerParams) (*api.ToolCallResult, error)
 	groupFilter := params.GetString("group", "")
 	healthFilter := params.GetString("health", "all")
 
-	// Get container directory
-	containerDir, err := findContainerDir(params.MustGatherProvider.GetMetadata().Path)
-	if err != nil {
-		containerDir = params.MustGatherProvider.GetMetadata().Path
-	}
+	files := params.MustGatherProvider.Files()
 
 	// Read rules from common Prometheus directory
-	promPath := getPrometheusCommonPath(containerDir)
-	rulesFile := filepath.Join(promPath, "rules.json")
+	promPath := getPrometheusCommonPath()
+	rulesFile := path.Join(promPath, "rules.json")
 
 	var rulesAPIResp RuleGroupsAPIResponse
-	if err := readJSON(rulesFile, &rulesAPIResp); err != nil {
+	if err := readJSON(files, rulesFile, &rulesAPIResp); err != nil {
 		return api.NewToolCallResult("",
 			fmt.Errorf("failed to read Prometheus rules: %w", err)), nil
 	}
@@ -265,18 +260,14 @@ func prometheusAlerts(params api.ToolHandlerParams) (*api.ToolCallResult, error)
 	stateFilter := params.GetString("state", "all")
 	namespaceFilter := params.GetString("namespace", "")
 
-	// Get container directory
-	containerDir, err := findContainerDir(params.MustGatherProvider.GetMetadata().Path)
-	if err != nil {
-		containerDir = params.MustGatherProvider.GetMetadata().Path
-	}
+	files := params.MustGatherProvider.Files()
 
 	// Read rules to get active alerts
-	promPath := getPrometheusCommonPath(containerDir)
-	rulesFile := filepath.Join(promPath, "rules.json")
+	promPath := getPrometheusCommonPath()
+	rulesFile := path.Join(promPath, "rules.json")
 
 	var rulesAPIResp RuleGroupsAPIResponse
-	if err := readJSON(rulesFile, &rulesAPIResp); err != nil {
+	if err := readJSON(files, rulesFile, &rulesAPIResp); err != nil {
 		return api.NewToolCallResult("",
 			fmt.Errorf("failed to read Prometheus rules: %w", err)), nil
 	}
@@ -0,0 +1,386 @@
+package monitoring
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// bytesPerSeries is a rule of thumb for the head memory of one active series,
+// including its chunks, labels and index entries
+const bytesPerSeries = 4096
+
+// knownMetricJobs maps metric name prefixes of well-known exporters to the job
+// scraping them on OpenShift
+var knownMetricJobs = []struct {
+	Prefix string
+	Job    string
+}{
+	{"container_", "kubelet"},
+	{"machine_", "kubelet"},
+	{"kubelet_", "kubelet"},
+	{"prober_", "kubelet"},
+	{"storage_operation_", "kubelet"},
+	{"node_", "node-exporter"},
+	{"kube_", "kube-state-metrics"},
+	{"apiserver_", "apiserver"},
+	{"etcd_", "etcd"},
+	{"coredns_", "dns-default"},
+	{"haproxy_", "router-internal-default"},
+	{"scheduler_", "scheduler"},
+	{"ovnkube_", "ovnkube-node"},
+	{"ovs_", "ovnkube-node"},
+}
+
+// labelHints explains typical high-cardinality labels
+var labelHints = map[string]string{
+	"id":           "cAdvisor cgroup path, one value per container and slice",
+	"uid":          "object UID, changes whenever a pod is recreated",
+	"pod_uid":      "pod UID, changes whenever a pod is recreated",
+	"container_id": "runtime ID, changes on every container restart",
+	"image_id":     "image digest, changes on every image update",
+	"le":           "histogram bucket boundary, multiplies every histogram",
+	"path":         "request path, unbounded when it includes IDs",
+	"url":          "request URL, unbounded when it includes IDs",
+	"uri":          "request URI, unbounded when it includes IDs",
+	"user":         "one value per client",
+	"client":       "one value per client",
+	"ip":           "one value per client address",
+	"name":         "object name, grows with the number of objects",
+	"pod":          "pod name, grows with pod churn",
+}
+
+// scrapeOwner is the monitor a scrape pool belongs to, e.g. serviceMonitor/ns/name/0
+type scrapeOwner struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Endpoint  string
+	Pool      string
+}
+
+func cardinalityTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "monitoring_cardinality",
+				Description: "Find cardinality hot spots from the TSDB status: the metrics with most series and the labels with most values and memory, attributed to the job and owning ServiceMonitor/PodMonitor, with metricRelabelings drop rules to apply and the head memory they would save",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"replica": {
+							Type:        "string",
+							Description: "Prometheus replica to analyse, e.g. 'prometheus-k8s-0' or '0', or 'both' for the highest counts across replicas (default: both)",
+						},
+						"top": {
+							Type:        "integer",
+							Description: "Number of metrics and labels to analyse (0 for all, default: 10)",
+						},
+						"minSeries": {
+							Type:        "integer",
+							Description: "Only suggest drop rules for metrics with at least this many series (default: 10000)",
+						},
+					},
+				},
+			},
+			Handler: cardinalityAnalysis,
+		},
+	}
+}
+
+func cardinalityAnalysis(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	replicaParam := params.GetString("replica", "both")
+	top := params.GetInt("top", 10)
+	minSeries := int64(params.GetInt("minSeries", 10000))
+
+	files := params.MustGatherProvider.Files()
+
+	output := "Prometheus Cardinality Hot Spots\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	replicas := getReplicas(files, replicaParam)
+	if len(replicas) == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("no Prometheus replica data found under monitoring/prometheus/")), nil
+	}
+
+	// Highest counts across replicas, as every replica scrapes the same targets
+	var headSeries int64
+	metricSeries := make(map[string]int64)
+	labelValues := make(map[string]int64)
+	labelMemory := make(map[string]int64)
+	jobPools := make(map[string]map[string]bool)
+	loaded := make([]string, 0, len(replicas))
+	for _, replica := range replicas {
+		replicaPath := getPrometheusReplicaPath(replica)
+		var tsdbResp TSDBStatusResponse
+		if err := readPrometheusJSON(files, replicaPath, "status/tsdb.json", &tsdbResp); err != nil {
+			output += fmt.Sprintf("⚠ %s: Failed to read TSDB status - %v\n", replica, err)
+			continue
+		}
+		tsdb := tsdbResp.Data
+		loaded = append(loaded, fmt.Sprintf("%s (%s series)", replica, formatNumber(tsdb.HeadStats.NumSeries)))
+		headSeries = max(headSeries, tsdb.HeadStats.NumSeries)
+		for _, metric := range tsdb.SeriesCountByMetricName {
+			metricSeries[metric.Name] = max(metricSeries[metric.Name], metric.Value)
+		}
+		for _, label := range tsdb.LabelValueCountByLabelName {
+			labelValues[label.Name] = max(labelValues[label.Name], label.Value)
+		}
+		for _, label := range tsdb.MemoryInBytesByLabelName {
+			labelMemory[label.Name] = max(labelMemory[label.Name], label.Value)
+		}
+
+		var targetsAPIResp ActiveTargetsAPIResponse
+		if err := readPrometheusJSON(files, replicaPath, "active-targets.json", &targetsAPIResp); err == nil {
+			for _, target := range targetsAPIResp.Data.ActiveTargets {
+				job := getJob(target.Labels)
+				if jobPools[job] == nil {
+					jobPools[job] = make(map[string]bool)
+				}
+				jobPools[job][target.ScrapePool] = true
+			}
+		}
+	}
+	if len(loaded) == 0 {
+		return api.NewToolCallResult("", fmt.Errorf("no TSDB status could be read for %s", strings.Join(replicas, ", "))), nil
+	}
+
+	output += fmt.Sprintf("Replicas: %s\n", strings.Join(loaded, ", "))
+	output += fmt.Sprintf("Head Series: %s (≈%s at %s per series)\n", formatNumber(headSeries),
+		formatBytes(headSeries*bytesPerSeries), formatBytes(bytesPerSeries))
+	if len(loaded) > 1 {
+		output += "Counts are the highest across replicas, savings apply to each replica\n"
+	}
+
+	// Exact metric to job attribution from metric snapshots, when gathered
+	snapshotJobs := make(map[string]map[string]int)
+	if store, err := loadMetricSnapshots(files, ""); err == nil {
+		for _, series := range store.series {
+			name, job := series.labels.Get("__name__"), series.labels.Get("job")
+			if name == "" || job == "" {
+				continue
+			}
+			if snapshotJobs[name] == nil {
+				snapshotJobs[name] = make(map[string]int)
+			}
+			snapshotJobs[name][job]++
+		}
+	}
+	output += "\n"
+
+	metrics := sortedByValue(metricSeries)
+	if top > 0 && len(metrics) > top {
+		metrics = metrics[:top]
+	}
+
+	output += "Top Metrics by Series Count\n"
+	output += strings.Repeat("-", 80) + "\n"
+	output += fmt.Sprintf("%-3s %-46s %10s %7s %10s\n", "#", "Metric", "Series", "Head", "Memory")
+	var topTotal int64
+	for i, name := range metrics {
+		series := metricSeries[name]
+		topTotal += series
+		output += fmt.Sprintf("%-3d %-46s %10s %6.1f%% %10s\n", i+1, truncate(name, 46),
+			formatNumber(series), percentOf(series, headSeries), formatBytes(series*bytesPerSeries))
+	}
+	output += fmt.Sprintf("These %d metrics hold %.1f%% of the head series\n\n", len(metrics), percentOf(topTotal, headSeries))
+
+	output += "Hot Metrics and Suggested Drop Rules\n"
+	output += strings.Repeat("-", 80) + "\n"
+	suggested := 0
+	var totalSavings int64
+	for _, name := range metrics {
+		series := metricSeries[name]
+		if series < minSeries {
+			continue
+		}
+		suggested++
+		savings := series * bytesPerSeries
+		totalSavings += savings
+
+		job, attribution := attributeMetric(name, snapshotJobs, jobPools)
+		output += fmt.Sprintf("\n[%d] %s - %s series (%.1f%%)\n", suggested, name, formatNumber(series), percentOf(series, headSeries))
+
+		owners := make([]scrapeOwner, 0)
+		if job != "" {
+			for _, pool := range sortedPools(jobPools[job]) {
+				owners = append(owners, parseScrapePool(pool))
+			}
+			output += fmt.Sprintf("  Job: %s (%s)\n", job, attribution)
+		} else {
+			output += "  Job: ? not attributable, no snapshot or job name matches\n"
+		}
+		for _, owner := range owners {
+			output += "  Owner: " + formatScrapeOwner(params, owner) + "\n"
+		}
+
+		switch {
+		case strings.HasSuffix(name, "_bucket"):
+			output += "  Histogram: every le bucket is a series; drop unused buckets or the whole histogram if no dashboard or alert uses it\n"
+		case strings.HasPrefix(name, "container_"):
+			output += "  Per-container metric: grows with pods and restarts\n"
+		}
+		output += fmt.Sprintf("  Dropping it saves ≈%s of head memory per replica\n", formatBytes(savings))
+		output += "  metricRelabelings:\n"
+		output += "  - sourceLabels: [__name__]\n"
+		output += fmt.Sprintf("    regex: %s\n", name)
+		output += "    action: drop\n"
+		for _, owner := range owners {
+			if strings.HasPrefix(owner.Namespace, "openshift-") || strings.HasPrefix(owner.Namespace, "kube-") {
+				output += "  ⚠ Platform monitor, managed by its operator: edits are reverted, raise it with the component instead\n"
+				break
+			}
+		}
+	}
+	if suggested == 0 {
+		output += fmt.Sprintf("\n✓ No metric in the top %d has %s or more series\n", len(metrics), formatNumber(minSeries))
+	} else {
+		output += fmt.Sprintf("\nAll %d drop rules together save ≈%s per replica\n", suggested, formatBytes(totalSavings))
+	}
+	output += "\n"
+
+	labels := sortedByValue(labelValues)
+	if top > 0 && len(labels) > top {
+		labels = labels[:top]
+	}
+	if len(labels) > 0 {
+		output += "High-Cardinality Labels\n"
+		output += strings.Repeat("-", 80) + "\n"
+		output += fmt.Sprintf("%-24s %10s %10s  %s\n", "Label", "Values", "Memory", "Note")
+		for _, label := range labels {
+			memory := "-"
+			if bytes, ok := labelMemory[label]; ok {
+				memory = formatBytes(bytes)
+			}
+			output += fmt.Sprintf("%-24s %10s %10s  %s\n", truncate(label, 24),
+				formatNumber(labelValues[label]), memory, labelHints[label])
+		}
+
+		droppable := make([]string, 0)
+		for _, label := range labels {
+			switch label {
+			case "id", "uid", "pod_uid", "container_id", "image_id":
+				droppable = append(droppable, label)
+			}
+		}
+		if len(droppable) > 0 {
+			var memory int64
+			for _, label := range droppable {
+				memory += labelMemory[label]
+			}
+			output += fmt.Sprintf("\nIdentifier labels %s add values without adding meaning. If dashboards and alerts\n", strings.Join(droppable, ", "))
+			output += "don't use them, drop them on the monitors that expose them. Only do so when the remaining\n"
+			output += "labels still identify each series, otherwise samples collide and are rejected:\n"
+			output += "  metricRelabelings:\n"
+			output += "  - action: labeldrop\n"
+			output += fmt.Sprintf("    regex: %s\n", strings.Join(droppable, "|"))
+			output += fmt.Sprintf("Their label values alone take %s; series that collapse save ≈%s each\n", formatBytes(memory), formatBytes(bytesPerSeries))
+		}
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// attributeMetric finds the job exposing a metric: from metric snapshots,
+// well-known exporter prefixes, or a job name the metric name starts with
+func attributeMetric(name string, snapshotJobs map[string]map[string]int, jobPools map[string]map[string]bool) (string, string) {
+	if jobs := snapshotJobs[name]; len(jobs) > 0 {
+		best := ""
+		for job, count := range jobs {
+			if best == "" || count > jobs[best] || (count == jobs[best] && job < best) {
+				best = job
+			}
+		}
+		return best, "from metric snapshots"
+	}
+
+	for _, known := range knownMetricJobs {
+		if strings.HasPrefix(name, known.Prefix) && jobPools[known.Job] != nil {
+			return known.Job, "well-known exporter"
+		}
+	}
+
+	best := ""
+	for job := range jobPools {
+		prefix := strings.NewReplacer("-", "_", ".", "_").Replace(strings.ToLower(job)) + "_"
+		if strings.HasPrefix(name, prefix) && len(job) > len(best) {
+			best = job
+		}
+	}
+	if best != "" {
+		return best, "metric name starts with the job name"
+	}
+	return "", ""
+}
+
+// parseScrapePool splits serviceMonitor/<namespace>/<name>/<endpoint>
+func parseScrapePool(pool string) scrapeOwner {
+	parts := strings.Split(pool, "/")
+	if len(parts) == 4 && (parts[0] == "serviceMonitor" || parts[0] == "podMonitor") {
+		kind := "ServiceMonitor"
+		if parts[0] == "podMonitor" {
+			kind = "PodMonitor"
+		}
+		return scrapeOwner{Kind: kind, Namespace: parts[1], Name: parts[2], Endpoint: parts[3], Pool: pool}
+	}
+	return scrapeOwner{Pool: pool}
+}
+
+// formatScrapeOwner names the monitor and its existing metricRelabelings
+func formatScrapeOwner(params api.ToolHandlerParams, owner scrapeOwner) string {
+	if owner.Kind == "" {
+		return fmt.Sprintf("scrape pool %s (static config, not a monitor)", owner.Pool)
+	}
+	line := fmt.Sprintf("%s %s/%s endpoint %s", owner.Kind, owner.Namespace, owner.Name, owner.Endpoint)
+	monitor, err := params.MustGatherProvider.GetResource(params.Context, parseGVK("monitoring.coreos.com/v1", owner.Kind), owner.Namespace, owner.Name)
+	if err != nil {
+		return line + " (not in gather)"
+	}
+	field := "endpoints"
+	if owner.Kind == "PodMonitor" {
+		field = "podMetricsEndpoints"
+	}
+	endpoints, _, _ := unstructured.NestedSlice(monitor.Object, "spec", field)
+	var index int
+	if _, err := fmt.Sscanf(owner.Endpoint, "%d", &index); err == nil && index < len(endpoints) {
+		if endpoint, ok := endpoints[index].(map[string]interface{}); ok {
+			relabelings, _, _ := unstructured.NestedSlice(endpoint, "metricRelabelings")
+			line += fmt.Sprintf(", %d existing metricRelabelings", len(relabelings))
+		}
+	}
+	return line
+}
+
+func sortedByValue(values map[string]int64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if values[keys[i]] != values[keys[j]] {
+			return values[keys[i]] > values[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+func sortedPools(pools map[string]bool) []string {
+	sorted := make([]string, 0, len(pools))
+	for pool := range pools {
+		sorted = append(sorted, pool)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+func percentOf(part, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(total)
+}
@@ -2,7 +2,7 @@ package monitoring
 
 import (
 	"fmt"
-	"path/filepath"
+	"path"
 	"sort"
 	"strings"
 
@@ -52,27 +52,23 @@ func configTools() []api.ServerTool {
 func prometheusConfigSummary(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	_ = params.GetString("replica", "prometheus-k8s-0")
 
-	// Get container directory
-	containerDir, err := findContainerDir(params.MustGatherProvider.GetMetadata().Path)
-	if err != nil {
-		containerDir = params.MustGatherProvider.GetMetadata().Path
-	}
+	files := params.MustGatherProvider.Files()
 
 	// Config is shared across replicas, so read from common prometheus directory
-	promPath := getPrometheusCommonPath(containerDir)
+	promPath := getPrometheusCommonPath()
 
 	// Read config
 	var configResp ConfigResponse
-	configFile := filepath.Join(promPath, "status", "config.json")
-	if err := readJSON(configFile, &configResp); err != nil {
+	configFile := path.Join(promPath, "status", "config.json")
+	if err := readJSON(files, configFile, &configResp); err != nil {
 		return api.NewToolCallResult("",
 			fmt.Errorf("failed to read Prometheus config: %w", err)), nil
 	}
 
 	// Read flags for additional context
 	var flags FlagsResponse
-	flagsFile := filepath.Join(promPath, "status", "flags.json")
-	readJSON(flagsFile, &flags)
+	flagsFile := path.Join(promPath, "status", "flags.json")
+	readJSON(files, flagsFile, &flags)
 
 	// Parse YAML config
 	var config map[string]interface{}
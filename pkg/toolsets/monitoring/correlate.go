@@ -0,0 +1,478 @@
+package monitoring
+
+import (
+	"fmt"
+	"net"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// kubeletErrorPattern matches klog error lines (E0110 10:00:00.000000 ...) in kubelet journals
+var kubeletErrorPattern = regexp.MustCompile(`(^|\s)E\d{4} \d{2}:\d{2}:\d{2}`)
+
+// activeAlert is a firing or pending alert with the rule it came from
+type activeAlert struct {
+	Alert    Alert
+	RuleName string
+	Severity string
+}
+
+// resourceUnit groups the alerts and health signals of one resource
+type resourceUnit struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Alerts    []activeAlert
+	Object    *unstructured.Unstructured
+	Symbol    string
+	Status    string
+	Details   []string
+}
+
+func (u *resourceUnit) display() string {
+	if u.Namespace != "" {
+		return fmt.Sprintf("%s %s/%s", u.Kind, u.Namespace, u.Name)
+	}
+	return fmt.Sprintf("%s %s", u.Kind, u.Name)
+}
+
+// score orders units by alert severity, then by health
+func (u *resourceUnit) score() int {
+	score := 0
+	for _, alert := range u.Alerts {
+		switch alert.Severity {
+		case "critical":
+			score += 100
+		case "warning":
+			score += 10
+		default:
+			score++
+		}
+	}
+	if u.Symbol == "✗" {
+		score++
+	}
+	return score
+}
+
+func correlateTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "alerts_by_resource",
+				Description: "Group firing and pending alerts by the pod, node, ClusterOperator or namespace their labels reference, together with that resource's health, Warning events and kubelet errors, and list unhealthy pods, nodes and operators without alerts",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"state": {
+							Type:        "string",
+							Description: "Filter alerts by state: 'all', 'firing', 'pending' (default: all)",
+							Enum:        []interface{}{"all", "firing", "pending"},
+						},
+						"namespace": {
+							Type:        "string",
+							Description: "Only show resources in this namespace (cluster-scoped resources are hidden)",
+						},
+						"includeUnalerted": {
+							Type:        "boolean",
+							Description: "Also list unhealthy pods, nodes and ClusterOperators without alerts (default: true)",
+						},
+						"limit": {
+							Type:        "integer",
+							Description: "Maximum resources to show per section (default: 30)",
+						},
+					},
+				},
+			},
+			Handler: alertsByResource,
+		},
+	}
+}
+
+func alertsByResource(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	stateFilter := params.GetString("state", "all")
+	nsFilter := params.GetString("namespace", "")
+	includeUnalerted := params.GetBool("includeUnalerted", true)
+	limit := params.GetInt("limit", 30)
+
+	alerts, err := loadActiveAlerts(params.MustGatherProvider.Files())
+	if err != nil {
+		return api.NewToolCallResult("",
+			fmt.Errorf("failed to read Prometheus rules: %w", err)), nil
+	}
+
+	units := make(map[string]*resourceUnit)
+	order := make([]string, 0)
+	getUnit := func(kind, namespace, name string) *resourceUnit {
+		key := kind + "/" + namespace + "/" + name
+		if unit, ok := units[key]; ok {
+			return unit
+		}
+		unit := &resourceUnit{Kind: kind, Namespace: namespace, Name: name}
+		units[key] = unit
+		order = append(order, key)
+		return unit
+	}
+
+	nodes := nodeAddresses(params)
+	unattributed := make([]activeAlert, 0)
+	firing, pending := 0, 0
+	for _, alert := range alerts {
+		if stateFilter != "all" && alert.Alert.State != stateFilter {
+			continue
+		}
+		kind, namespace, name := alertResource(alert, nodes)
+		if nsFilter != "" && namespace != nsFilter {
+			continue
+		}
+		if alert.Alert.State == "firing" {
+			firing++
+		} else {
+			pending++
+		}
+		if kind == "" {
+			unattributed = append(unattributed, alert)
+			continue
+		}
+		unit := getUnit(kind, namespace, name)
+		unit.Alerts = append(unit.Alerts, alert)
+	}
+
+	// Unhealthy resources from the index, with or without alerts
+	for _, unhealthy := range unhealthyResources(params, nsFilter) {
+		unit := getUnit(unhealthy.GetKind(), unhealthy.GetNamespace(), unhealthy.GetName())
+		unit.Object = unhealthy
+	}
+
+	// Events are listed once per namespace and shared by the units in it
+	eventsByNamespace := make(map[string]map[string][]warningEvent)
+	for _, key := range order {
+		enrichUnit(params, units[key], eventsByNamespace)
+	}
+
+	alerted := make([]*resourceUnit, 0)
+	unalerted := make([]*resourceUnit, 0)
+	for _, key := range order {
+		unit := units[key]
+		if len(unit.Alerts) > 0 {
+			alerted = append(alerted, unit)
+		} else if unit.Symbol == "✗" {
+			unalerted = append(unalerted, unit)
+		}
+	}
+	sort.SliceStable(alerted, func(i, j int) bool { return alerted[i].score() > alerted[j].score() })
+	sort.SliceStable(unalerted, func(i, j int) bool {
+		return kindOrder(unalerted[i].Kind) < kindOrder(unalerted[j].Kind)
+	})
+
+	output := "Alerts by Resource\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+	output += fmt.Sprintf("Active Alerts: %d (%d firing, %d pending) on %d resources", firing+pending, firing, pending, len(alerted))
+	if len(unattributed) > 0 {
+		output += fmt.Sprintf(", %d not tied to a resource", len(unattributed))
+	}
+	output += "\n"
+	if includeUnalerted {
+		output += fmt.Sprintf("Unhealthy Without Alerts: %d resources\n", len(unalerted))
+	}
+	output += "\n"
+
+	if len(alerted) > 0 {
+		output += "Resources With Alerts\n"
+		output += strings.Repeat("-", 80) + "\n"
+		for i, unit := range alerted {
+			if limit > 0 && i >= limit {
+				output += fmt.Sprintf("... and %d more resources\n\n", len(alerted)-limit)
+				break
+			}
+			output += formatResourceUnit(unit)
+		}
+	}
+
+	if len(unattributed) > 0 {
+		output += "Alerts Not Tied to a Resource\n"
+		output += strings.Repeat("-", 80) + "\n"
+		for _, alert := range unattributed {
+			output += fmt.Sprintf("  %s %s %s [%s]", severitySymbol(alert.Severity), alert.Alert.State, alert.RuleName, alert.Severity)
+			if labels := alertContextLabels(alert); labels != "" {
+				output += " " + labels
+			}
+			output += "\n"
+		}
+		output += "\n"
+	}
+
+	if includeUnalerted && len(unalerted) > 0 {
+		output += "Unhealthy Without Alerts\n"
+		output += strings.Repeat("-", 80) + "\n"
+		for i, unit := range unalerted {
+			if limit > 0 && i >= limit {
+				output += fmt.Sprintf("... and %d more resources\n\n", len(unalerted)-limit)
+				break
+			}
+			output += formatResourceUnit(unit)
+		}
+	}
+
+	if len(alerted) == 0 && len(unattributed) == 0 && (!includeUnalerted || len(unalerted) == 0) {
+		output += "✓ No active alerts or unhealthy resources found\n"
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// loadActiveAlerts returns the firing and pending alerts recorded in rules.json
+func loadActiveAlerts(files api.GatherFS) ([]activeAlert, error) {
+	var rulesAPIResp RuleGroupsAPIResponse
+	if err := readJSON(files, path.Join(getPrometheusCommonPath(), "rules.json"), &rulesAPIResp); err != nil {
+		return nil, err
+	}
+
+	alerts := make([]activeAlert, 0)
+	for _, group := range rulesAPIResp.Data.Groups {
+		for _, rule := range group.Rules {
+			if rule.Type != "alerting" {
+				continue
+			}
+			for _, alert := range rule.Alerts {
+				severity := alert.Labels["severity"]
+				if severity == "" {
+					severity = getSeverity(rule.Labels)
+				}
+				alerts = append(alerts, activeAlert{Alert: alert, RuleName: rule.Name, Severity: severity})
+			}
+		}
+	}
+	return alerts, nil
+}
+
+// alertResource returns the most specific resource an alert's labels reference.
+// Node-scoped alerts from node-exporter or the kubelet carry the pod and
+// namespace of the exporter that was scraped, which are not the subject.
+func alertResource(alert activeAlert, nodes map[string]string) (string, string, string) {
+	labels := alert.Alert.Labels
+	namespace := labels["namespace"]
+	instanceNode := nodes[instanceHost(labels["instance"])]
+	switch {
+	case labels["node"] != "":
+		return "Node", "", labels["node"]
+	case labels["pod"] != "" && namespace != "" && !isScrapeTargetPod(labels):
+		return "Pod", namespace, labels["pod"]
+	case instanceNode != "":
+		return "Node", "", instanceNode
+	case isScrapeTargetPod(labels):
+		return "", "", ""
+	case strings.HasPrefix(alert.RuleName, "ClusterOperator") && labels["name"] != "":
+		return "ClusterOperator", "", labels["name"]
+	case namespace != "":
+		return "Namespace", "", namespace
+	}
+	return "", "", ""
+}
+
+// isScrapeTargetPod reports whether the pod label names the scraped exporter
+// rather than the pod the metric is about. Exporter pods are named after their
+// job (node-exporter-x7k2p for job node-exporter), kube-state-metrics reports
+// other pods.
+func isScrapeTargetPod(labels map[string]string) bool {
+	job := labels["job"]
+	return labels["pod"] != "" && job != "" && strings.HasPrefix(labels["pod"], job+"-")
+}
+
+// instanceHost strips the port from an instance label
+func instanceHost(instance string) string {
+	if host, _, err := net.SplitHostPort(instance); err == nil {
+		return host
+	}
+	return instance
+}
+
+// nodeAddresses maps the names and addresses of gathered nodes to the node name
+func nodeAddresses(params api.ToolHandlerParams) map[string]string {
+	nodes := make(map[string]string)
+	list, err := params.MustGatherProvider.ListResources(params.Context, parseGVK("v1", "Node"), "", api.ListOptions{})
+	if err != nil {
+		return nodes
+	}
+	for i := range list.Items {
+		node := &list.Items[i]
+		nodes[node.GetName()] = node.GetName()
+		addresses, _, _ := unstructured.NestedSlice(node.Object, "status", "addresses")
+		for _, item := range addresses {
+			if address, ok := item.(map[string]interface{}); ok {
+				if value, _ := address["address"].(string); value != "" {
+					nodes[value] = node.GetName()
+				}
+			}
+		}
+	}
+	return nodes
+}
+
+// unhealthyResources returns pods, nodes and ClusterOperators in a problem state
+func unhealthyResources(params api.ToolHandlerParams, namespace string) []*unstructured.Unstructured {
+	sources := []struct {
+		APIVersion string
+		Kind       string
+		Namespaced bool
+	}{
+		{"config.openshift.io/v1", "ClusterOperator", false},
+		{"v1", "Node", false},
+		{"v1", "Pod", true},
+	}
+
+	result := make([]*unstructured.Unstructured, 0)
+	for _, source := range sources {
+		if namespace != "" && !source.Namespaced {
+			continue
+		}
+		list, err := params.MustGatherProvider.ListResources(params.Context, parseGVK(source.APIVersion, source.Kind), namespace, api.ListOptions{})
+		if err != nil {
+			continue
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if symbol, _ := objectStatus(obj); symbol == "✗" {
+				result = append(result, obj)
+			}
+		}
+	}
+	return result
+}
+
+// enrichUnit looks up the resource and gathers its health signals
+func enrichUnit(params api.ToolHandlerParams, unit *resourceUnit, eventsByNamespace map[string]map[string][]warningEvent) {
+	if unit.Object == nil {
+		apiVersion := "v1"
+		if unit.Kind == "ClusterOperator" {
+			apiVersion = "config.openshift.io/v1"
+		}
+		obj, err := params.MustGatherProvider.GetResource(params.Context, parseGVK(apiVersion, unit.Kind), unit.Namespace, unit.Name)
+		if err != nil {
+			unit.Symbol, unit.Status = "⚠", "not in must-gather"
+			return
+		}
+		unit.Object = obj
+	}
+	unit.Symbol, unit.Status = objectStatus(unit.Object)
+
+	switch unit.Kind {
+	case "Pod", "Node":
+		byObject, ok := eventsByNamespace[unit.Namespace]
+		if !ok {
+			byObject = warningEventsByObject(params, unit.Namespace)
+			eventsByNamespace[unit.Namespace] = byObject
+		}
+		events := byObject[unit.Kind+"/"+unit.Name]
+		if len(events) > 0 {
+			reasons := make([]string, 0)
+			for i, ev := range events {
+				if i >= 3 {
+					break
+				}
+				reasons = append(reasons, fmt.Sprintf("%s x%d", ev.Reason, ev.Count))
+			}
+			unit.Details = append(unit.Details, fmt.Sprintf("Warning events: %s", strings.Join(reasons, ", ")))
+		}
+		if unit.Kind == "Node" {
+			if detail := kubeletErrors(params, unit.Name); detail != "" {
+				unit.Details = append(unit.Details, detail)
+			}
+		}
+
+	case "Namespace":
+		pods, err := params.MustGatherProvider.ListResources(params.Context, parseGVK("v1", "Pod"), unit.Name, api.ListOptions{})
+		if err != nil {
+			return
+		}
+		unhealthy := make([]string, 0)
+		for i := range pods.Items {
+			if symbol, _ := objectStatus(&pods.Items[i]); symbol == "✗" {
+				unhealthy = append(unhealthy, pods.Items[i].GetName())
+			}
+		}
+		if len(unhealthy) > 0 {
+			unit.Details = append(unit.Details, fmt.Sprintf("Unhealthy pods (%d): %s", len(unhealthy), truncate(strings.Join(unhealthy, ", "), 100)))
+		}
+	}
+}
+
+// kubeletErrors summarizes the error lines of a node's gathered kubelet log
+func kubeletErrors(params api.ToolHandlerParams, nodeName string) string {
+	diag, err := params.MustGatherProvider.GetNodeDiagnostics(nodeName)
+	if err != nil || diag.KubeletLog == "" {
+		return ""
+	}
+
+	count, last := 0, ""
+	for _, line := range strings.Split(diag.KubeletLog, "\n") {
+		if kubeletErrorPattern.MatchString(line) {
+			count++
+			last = line
+		}
+	}
+	if count == 0 {
+		return ""
+	}
+	return fmt.Sprintf("Kubelet errors: %d lines, last: %s", count, truncate(strings.TrimSpace(last), 100))
+}
+
+func formatResourceUnit(unit *resourceUnit) string {
+	output := fmt.Sprintf("%s %s: %s\n", unit.Symbol, unit.display(), unit.Status)
+
+	alerts := append([]activeAlert(nil), unit.Alerts...)
+	sort.SliceStable(alerts, func(i, j int) bool {
+		if alerts[i].Alert.State != alerts[j].Alert.State {
+			return alerts[i].Alert.State == "firing"
+		}
+		return alerts[i].RuleName < alerts[j].RuleName
+	})
+	for _, alert := range alerts {
+		output += fmt.Sprintf("    %s %s %s [%s] since %s", statusSymbol(alert.Alert.State), alert.Alert.State, alert.RuleName, alert.Severity, alert.Alert.ActiveAt)
+		if labels := alertContextLabels(alert); labels != "" {
+			output += " " + labels
+		}
+		output += "\n"
+	}
+	for _, detail := range unit.Details {
+		output += fmt.Sprintf("    • %s\n", detail)
+	}
+	return output + "\n"
+}
+
+// alertContextLabels returns the labels that distinguish alert instances on the same resource
+func alertContextLabels(alert activeAlert) string {
+	skip := map[string]bool{"alertname": true, "severity": true, "namespace": true, "pod": true, "node": true, "prometheus": true}
+	if strings.HasPrefix(alert.RuleName, "ClusterOperator") {
+		skip["name"] = true
+	}
+
+	labels := make([]string, 0)
+	for _, key := range sortedKeys(alert.Alert.Labels) {
+		if !skip[key] {
+			labels = append(labels, fmt.Sprintf("%s=%s", key, alert.Alert.Labels[key]))
+		}
+	}
+	if len(labels) == 0 {
+		return ""
+	}
+	return "(" + truncate(strings.Join(labels, ", "), 80) + ")"
+}
+
+func kindOrder(kind string) int {
+	switch kind {
+	case "ClusterOperator":
+		return 0
+	case "Node":
+		return 1
+	case "Pod":
+		return 2
+	}
+	return 3
+}
@@ -0,0 +1,488 @@
+package monitoring
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// alertObjectLabels maps alert labels to the objects they name, in display order
+var alertObjectLabels = []struct {
+	Label      string
+	APIVersion string
+	Kind       string
+	Namespaced bool
+}{
+	{"namespace", "v1", "Namespace", false},
+	{"pod", "v1", "Pod", true},
+	{"deployment", "apps/v1", "Deployment", true},
+	{"statefulset", "apps/v1", "StatefulSet", true},
+	{"daemonset", "apps/v1", "DaemonSet", true},
+	{"node", "v1", "Node", false},
+}
+
+func explainTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "monitoring_alert_explain",
+				Description: "Explain an alert: its alerting rule from rules.json (expression, for, labels, annotations and runbook), every active instance, and the pods, nodes and namespaces it names with their state and recent Warning events",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"alertName": {
+							Type:        "string",
+							Description: "Alert name, e.g. KubePodCrashLooping",
+						},
+						"limit": {
+							Type:        "integer",
+							Description: "Maximum active instances to show (default: 20)",
+						},
+						"maxEvents": {
+							Type:        "integer",
+							Description: "Maximum Warning events per related object (default: 5)",
+						},
+					},
+					Required: []string{"alertName"},
+				},
+			},
+			Handler: alertExplain,
+		},
+	}
+}
+
+func alertExplain(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	alertName := params.GetString("alertName", "")
+	limit := params.GetInt("limit", 20)
+	maxEvents := params.GetInt("maxEvents", 5)
+
+	if alertName == "" {
+		return api.NewToolCallResult("", fmt.Errorf("alertName is required")), nil
+	}
+
+	files := params.MustGatherProvider.Files()
+	rulesFile := path.Join(getPrometheusCommonPath(), "rules.json")
+
+	var rulesAPIResp RuleGroupsAPIResponse
+	if err := readJSON(files, rulesFile, &rulesAPIResp); err != nil {
+		return api.NewToolCallResult("",
+			fmt.Errorf("failed to read Prometheus rules: %w", err)), nil
+	}
+
+	type ruleInGroup struct {
+		Rule  Rule
+		Group RuleGroup
+	}
+	var matches []ruleInGroup
+	similar := make([]string, 0)
+	for _, group := range rulesAPIResp.Data.Groups {
+		for _, rule := range group.Rules {
+			if rule.Type != "alerting" {
+				continue
+			}
+			if rule.Name == alertName {
+				matches = append(matches, ruleInGroup{Rule: rule, Group: group})
+			} else if strings.Contains(strings.ToLower(rule.Name), strings.ToLower(alertName)) {
+				similar = append(similar, rule.Name)
+			}
+		}
+	}
+	if len(matches) == 0 {
+		err := fmt.Errorf("alerting rule %q not found in rules.json", alertName)
+		if len(similar) > 0 {
+			sort.Strings(similar)
+			err = fmt.Errorf("%w (similar: %s)", err, strings.Join(uniqueStrings(similar), ", "))
+		}
+		return api.NewToolCallResult("", err), nil
+	}
+
+	output := fmt.Sprintf("Alert: %s\n", alertName)
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	// Objects named by instance labels, in order of first appearance
+	type objectRef struct {
+		APIVersion string
+		Kind       string
+		Namespace  string
+		Name       string
+	}
+	objects := make([]objectRef, 0)
+	seenObjects := make(map[objectRef]bool)
+
+	for _, match := range matches {
+		rule := match.Rule
+
+		if len(matches) > 1 {
+			output += fmt.Sprintf("Rule in group %s\n", match.Group.Name)
+		} else {
+			output += "Rule\n"
+		}
+		output += strings.Repeat("-", 80) + "\n"
+		output += fmt.Sprintf("Group: %s (%s)\n", match.Group.Name, path.Base(match.Group.File))
+		output += fmt.Sprintf("Severity: %s %s\n", severitySymbol(getSeverity(rule.Labels)), getSeverity(rule.Labels))
+		output += fmt.Sprintf("State: %s %s\n", statusSymbol(rule.State), rule.State)
+		if rule.Health != "" && rule.Health != "ok" {
+			output += fmt.Sprintf("Health: ✗ %s", rule.Health)
+			if rule.LastError != "" {
+				output += fmt.Sprintf(" - %s", rule.LastError)
+			}
+			output += "\n"
+		}
+		output += fmt.Sprintf("For: %s\n", secondsToDuration(rule.Duration))
+		if rule.KeepFiringFor > 0 {
+			output += fmt.Sprintf("Keep Firing For: %s\n", secondsToDuration(rule.KeepFiringFor))
+		}
+
+		output += "\nExpression:\n"
+		for _, line := range strings.Split(strings.TrimSpace(rule.Query), "\n") {
+			output += "  " + line + "\n"
+		}
+
+		if len(rule.Labels) > 0 {
+			output += "\nLabels:\n"
+			for _, key := range sortedKeys(rule.Labels) {
+				output += fmt.Sprintf("  %s: %s\n", key, rule.Labels[key])
+			}
+		}
+
+		if len(rule.Annotations) > 0 {
+			output += "\nAnnotations:\n"
+			for _, key := range annotationKeys(rule.Annotations) {
+				output += fmt.Sprintf("  %s: %s\n", key, strings.ReplaceAll(rule.Annotations[key], "\n", " "))
+			}
+		}
+		if runbook := rule.Annotations["runbook_url"]; runbook != "" {
+			output += fmt.Sprintf("\nRunbook: %s\n", runbook)
+		}
+
+		// Active instances, firing first, oldest first
+		alerts := append([]Alert(nil), rule.Alerts...)
+		sort.SliceStable(alerts, func(i, j int) bool {
+			if alerts[i].State != alerts[j].State {
+				return alerts[i].State == "firing"
+			}
+			return alerts[i].ActiveAt < alerts[j].ActiveAt
+		})
+
+		output += fmt.Sprintf("\nActive Instances (%d):\n", len(alerts))
+		if len(alerts) == 0 {
+			output += "  None, the alert is not firing or pending\n"
+		}
+		for i, alert := range alerts {
+			if limit > 0 && i >= limit {
+				output += fmt.Sprintf("  ... and %d more\n", len(alerts)-limit)
+				break
+			}
+
+			output += fmt.Sprintf("  %s %s since %s", statusSymbol(alert.State), alert.State, alert.ActiveAt)
+			if since := activeFor(alert.ActiveAt, params.MustGatherProvider.GetMetadata().EndTime); since != "" {
+				output += fmt.Sprintf(" (%s before collection)", since)
+			}
+			output += "\n"
+			if alert.Value != "" {
+				output += fmt.Sprintf("      Value: %s\n", alert.Value)
+			}
+
+			labels := make([]string, 0, len(alert.Labels))
+			for _, key := range sortedKeys(alert.Labels) {
+				if key == "alertname" || (key == "severity" && alert.Labels[key] == rule.Labels["severity"]) {
+					continue
+				}
+				labels = append(labels, fmt.Sprintf("%s=%s", key, alert.Labels[key]))
+			}
+			if len(labels) > 0 {
+				output += fmt.Sprintf("      Labels: %s\n", strings.Join(labels, ", "))
+			}
+			if description := alert.Annotations["description"]; description != "" {
+				output += fmt.Sprintf("      Description: %s\n", truncate(strings.ReplaceAll(description, "\n", " "), 200))
+			} else if message := alert.Annotations["message"]; message != "" {
+				output += fmt.Sprintf("      Message: %s\n", truncate(strings.ReplaceAll(message, "\n", " "), 200))
+			}
+
+			for _, objLabel := range alertObjectLabels {
+				name := alert.Labels[objLabel.Label]
+				if name == "" {
+					continue
+				}
+				ref := objectRef{APIVersion: objLabel.APIVersion, Kind: objLabel.Kind, Name: name}
+				if objLabel.Namespaced {
+					ref.Namespace = alert.Labels["namespace"]
+					if ref.Namespace == "" {
+						continue
+					}
+				}
+				if !seenObjects[ref] {
+					seenObjects[ref] = true
+					objects = append(objects, ref)
+				}
+			}
+		}
+		output += "\n"
+	}
+
+	if len(objects) > 0 {
+		output += "Related Objects\n"
+		output += strings.Repeat("-", 80) + "\n"
+		for _, ref := range objects {
+			display := ref.Kind + " " + ref.Name
+			if ref.Namespace != "" {
+				display = fmt.Sprintf("%s %s/%s", ref.Kind, ref.Namespace, ref.Name)
+			}
+
+			obj, err := params.MustGatherProvider.GetResource(params.Context, parseGVK(ref.APIVersion, ref.Kind), ref.Namespace, ref.Name)
+			if err != nil {
+				output += fmt.Sprintf("✗ %s: not in must-gather (deleted before collection or not gathered)\n\n", display)
+				continue
+			}
+
+			symbol, status := objectStatus(obj)
+			output += fmt.Sprintf("%s %s: %s\n", symbol, display, status)
+
+			events := objectWarningEvents(params, ref.Kind, ref.Namespace, ref.Name)
+			for i, ev := range events {
+				if i >= maxEvents {
+					output += fmt.Sprintf("    ... and %d more Warning events\n", len(events)-maxEvents)
+					break
+				}
+				output += fmt.Sprintf("    ⚠ %s (x%d, last %s): %s\n", ev.Reason, ev.Count, ev.Last, truncate(ev.Message, 120))
+			}
+			output += "\n"
+		}
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// objectStatus summarizes the state of a pod, node, namespace or workload
+func objectStatus(obj *unstructured.Unstructured) (string, string) {
+	switch obj.GetKind() {
+	case "Pod":
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		statuses, _, _ := unstructured.NestedSlice(obj.Object, "status", "containerStatuses")
+		ready, restarts := 0, int64(0)
+		reasons := make([]string, 0)
+		for _, cs := range statuses {
+			csMap, ok := cs.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if isReady, _, _ := unstructured.NestedBool(csMap, "ready"); isReady {
+				ready++
+			}
+			count, _, _ := unstructured.NestedInt64(csMap, "restartCount")
+			restarts += count
+			if reason, _, _ := unstructured.NestedString(csMap, "state", "waiting", "reason"); reason != "" {
+				reasons = append(reasons, reason)
+			}
+		}
+		status := fmt.Sprintf("%s, %d/%d ready, %d restarts", phase, ready, len(statuses), restarts)
+		if len(reasons) > 0 {
+			status += " (" + strings.Join(uniqueStrings(reasons), ", ") + ")"
+		}
+		if nodeName, _, _ := unstructured.NestedString(obj.Object, "spec", "nodeName"); nodeName != "" {
+			status += ", node " + nodeName
+		}
+		if (phase == "Running" && ready == len(statuses)) || phase == "Succeeded" {
+			return "✓", status
+		}
+		return "✗", status
+
+	case "Node":
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		readyStatus := "Unknown"
+		pressure := make([]string, 0)
+		for _, c := range conditions {
+			cMap, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			condType, _, _ := unstructured.NestedString(cMap, "type")
+			condStatus, _, _ := unstructured.NestedString(cMap, "status")
+			if condType == "Ready" {
+				readyStatus = condStatus
+			} else if condStatus == "True" {
+				pressure = append(pressure, condType)
+			}
+		}
+		status := "Ready=" + readyStatus
+		if len(pressure) > 0 {
+			status += ", " + strings.Join(pressure, ", ")
+		}
+		if unschedulable, _, _ := unstructured.NestedBool(obj.Object, "spec", "unschedulable"); unschedulable {
+			status += ", cordoned"
+		}
+		if readyStatus == "True" && len(pressure) == 0 {
+			return "✓", status
+		}
+		return "✗", status
+
+	case "ClusterOperator":
+		conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+		states := make(map[string]string)
+		for _, c := range conditions {
+			cMap, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			condType, _, _ := unstructured.NestedString(cMap, "type")
+			condStatus, _, _ := unstructured.NestedString(cMap, "status")
+			states[condType] = condStatus
+		}
+		status := fmt.Sprintf("Available=%s, Degraded=%s, Progressing=%s",
+			valueOr(states["Available"], "Unknown"), valueOr(states["Degraded"], "Unknown"), valueOr(states["Progressing"], "Unknown"))
+		if states["Available"] == "False" || states["Degraded"] == "True" {
+			return "✗", status
+		}
+		return "✓", status
+
+	case "Namespace":
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		if phase == "" || phase == "Active" {
+			return "✓", "Active"
+		}
+		return "✗", phase
+
+	case "DaemonSet":
+		desired, _, _ := unstructured.NestedInt64(obj.Object, "status", "desiredNumberScheduled")
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "numberReady")
+		status := fmt.Sprintf("%d/%d ready", ready, desired)
+		if ready == desired {
+			return "✓", status
+		}
+		return "✗", status
+
+	default:
+		desired, found, _ := unstructured.NestedInt64(obj.Object, "spec", "replicas")
+		if !found {
+			desired = 1
+		}
+		ready, _, _ := unstructured.NestedInt64(obj.Object, "status", "readyReplicas")
+		status := fmt.Sprintf("%d/%d ready", ready, desired)
+		if ready == desired {
+			return "✓", status
+		}
+		return "✗", status
+	}
+}
+
+// warningEvent is a condensed Warning event
+type warningEvent struct {
+	Reason  string
+	Message string
+	Count   int64
+	Last    string
+}
+
+// objectWarningEvents returns the Warning events of an object, most recent first
+func objectWarningEvents(params api.ToolHandlerParams, kind, namespace, name string) []warningEvent {
+	return warningEventsByObject(params, namespace)[kind+"/"+name]
+}
+
+// warningEventsByObject returns the Warning events of a namespace keyed by
+// involved object kind/name, most recent first
+func warningEventsByObject(params api.ToolHandlerParams, namespace string) map[string][]warningEvent {
+	byObject := make(map[string][]warningEvent)
+	eventList, err := params.MustGatherProvider.ListResources(params.Context, parseGVK("v1", "Event"), namespace, api.ListOptions{})
+	if err != nil {
+		return byObject
+	}
+
+	for i := range eventList.Items {
+		ev := &eventList.Items[i]
+		if evType, _, _ := unstructured.NestedString(ev.Object, "type"); evType != "Warning" {
+			continue
+		}
+		evKind, _, _ := unstructured.NestedString(ev.Object, "involvedObject", "kind")
+		evName, _, _ := unstructured.NestedString(ev.Object, "involvedObject", "name")
+
+		reason, _, _ := unstructured.NestedString(ev.Object, "reason")
+		message, _, _ := unstructured.NestedString(ev.Object, "message")
+		count, found, _ := unstructured.NestedInt64(ev.Object, "count")
+		if !found || count == 0 {
+			count = 1
+		}
+		last, _, _ := unstructured.NestedString(ev.Object, "lastTimestamp")
+		if last == "" {
+			last, _, _ = unstructured.NestedString(ev.Object, "eventTime")
+		}
+
+		key := evKind + "/" + evName
+		byObject[key] = append(byObject[key], warningEvent{
+			Reason:  reason,
+			Message: strings.ReplaceAll(message, "\n", " "),
+			Count:   count,
+			Last:    last,
+		})
+	}
+
+	for _, events := range byObject {
+		sort.Slice(events, func(i, j int) bool { return events[i].Last > events[j].Last })
+	}
+	return byObject
+}
+
+// annotationKeys orders summary and description first, then the rest by name
+func annotationKeys(annotations map[string]string) []string {
+	keys := make([]string, 0, len(annotations))
+	for _, key := range []string{"summary", "description", "message"} {
+		if _, ok := annotations[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	for _, key := range sortedKeys(annotations) {
+		if key != "summary" && key != "description" && key != "message" && key != "runbook_url" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// activeFor returns how long an alert had been active when the must-gather ended
+func activeFor(activeAt string, end time.Time) string {
+	start, err := time.Parse(time.RFC3339, activeAt)
+	if err != nil || end.IsZero() || end.Before(start) {
+		return ""
+	}
+	return end.Sub(start).Round(time.Second).String()
+}
+
+func secondsToDuration(seconds float64) string {
+	if seconds <= 0 {
+		return "0s (fires immediately)"
+	}
+	return (time.Duration(seconds * float64(time.Second))).String()
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
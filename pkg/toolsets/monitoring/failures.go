@@ -0,0 +1,349 @@
+package monitoring
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// scrapeCause is a class of scrape errors with a hint where to look next
+type scrapeCause struct {
+	Name    string
+	Pattern *regexp.Regexp
+	Hint    string
+}
+
+// scrapeCauses are checked in order, the first match names the cause
+var scrapeCauses = []scrapeCause{
+	{"TLS/certificate error", regexp.MustCompile(`x509:|tls:|certificate`),
+		"Check the serving certificate and the CA the ServiceMonitor trusts, expired or rotated service CA certificates are common"},
+	{"connection refused", regexp.MustCompile(`connection refused`),
+		"Nothing listens on the port: the container is down or restarting, or serves metrics on another port"},
+	{"timeout", regexp.MustCompile(`context deadline exceeded|i/o timeout|Client\.Timeout|timeout awaiting`),
+		"The endpoint is slow or unreachable: check the node and pod network, NetworkPolicies and the scrape timeout"},
+	{"no route to host", regexp.MustCompile(`no route to host|network is unreachable|host is down`),
+		"The pod network path is broken: check the SDN/OVN pods on the nodes involved"},
+	{"DNS failure", regexp.MustCompile(`no such host|server misbehaving`),
+		"The target host name does not resolve: check the Service and cluster DNS"},
+	{"connection reset", regexp.MustCompile(`connection reset|broken pipe|EOF`),
+		"The target closed the connection: check for restarts and proxies such as kube-rbac-proxy"},
+	{"unauthorized", regexp.MustCompile(`HTTP status 40[13]`),
+		"Prometheus is not authorized: check the bearer token and RBAC of the metrics proxy"},
+	{"HTTP error", regexp.MustCompile(`HTTP status \d+`),
+		"The target answers with an error status: check its logs"},
+	{"limit exceeded", regexp.MustCompile(`sample limit|body size limit|label (name|value)? ?length limit|label limit|target limit`),
+		"The scrape exceeds a configured limit: raise it or reduce the target's cardinality"},
+}
+
+// Patterns that vary between targets failing for the same reason
+var (
+	errorURLPattern      = regexp.MustCompile(`https?://[^\s"]+`)
+	errorAddrPattern     = regexp.MustCompile(`\[[0-9a-fA-F:]+\](:\d+)?|\b\d{1,3}(\.\d{1,3}){3}(:\d+)?\b`)
+	errorLookupPattern   = regexp.MustCompile(`lookup [^\s:]+`)
+	errorDurationPattern = regexp.MustCompile(`\b\d+(\.\d+)?(ns|us|µs|ms|s|m|h)\b`)
+)
+
+// failedTarget is a down target, merged across the replicas that report it
+type failedTarget struct {
+	Target   ActiveTarget
+	Replicas []string
+	Node     string
+	Pod      string
+	Symbol   string
+	Status   string
+	Endpoint string
+}
+
+// failureCluster groups down targets of a job failing with the same normalised error
+type failureCluster struct {
+	Cause   scrapeCause
+	Error   string
+	Job     string
+	Targets []*failedTarget
+}
+
+// prometheusTargetClusters clusters down targets by normalised lastError and
+// job, and correlates them with the pods, nodes and endpoints in the gather
+func prometheusTargetClusters(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	replicaParam := params.GetString("replica", "both")
+	jobFilter := params.GetString("job", "")
+	nsFilter := params.GetString("namespace", "")
+	limit := params.GetInt("limit", 5)
+
+	files := params.MustGatherProvider.Files()
+
+	output := "Scrape Target Failure Clusters\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	replicas := getReplicas(files, replicaParam)
+	if len(replicas) == 0 {
+		output += "No Prometheus replica data found under monitoring/prometheus/\n"
+		return api.NewToolCallResult(output, nil), nil
+	}
+
+	clusters := make(map[string]*failureCluster)
+	targets := make(map[string]*failedTarget)
+	loaded := make([]string, 0, len(replicas))
+	for _, replica := range replicas {
+		var targetsAPIResp ActiveTargetsAPIResponse
+		if err := readPrometheusJSON(files, getPrometheusReplicaPath(replica), "active-targets.json", &targetsAPIResp); err != nil {
+			output += fmt.Sprintf("⚠ %s: Failed to read targets - %v\n", replica, err)
+			continue
+		}
+		loaded = append(loaded, replica)
+
+		for _, target := range targetsAPIResp.Data.ActiveTargets {
+			if target.Health == "up" || target.LastError == "" {
+				continue
+			}
+			job := getJob(target.Labels)
+			if jobFilter != "" && !strings.Contains(strings.ToLower(job), strings.ToLower(jobFilter)) {
+				continue
+			}
+			ns := getNamespace(target.Labels)
+			if nsFilter != "" && !strings.Contains(strings.ToLower(ns), strings.ToLower(nsFilter)) {
+				continue
+			}
+
+			normalised := normaliseScrapeError(target.LastError)
+			key := target.ScrapePool + "|" + target.ScrapeURL + "|" + normalised
+			if failed, ok := targets[key]; ok {
+				failed.Replicas = append(failed.Replicas, replica)
+				continue
+			}
+			failed := &failedTarget{Target: target, Replicas: []string{replica}}
+			targets[key] = failed
+
+			clusterKey := normalised + "|" + job
+			cluster, ok := clusters[clusterKey]
+			if !ok {
+				cluster = &failureCluster{Cause: classifyScrapeError(target.LastError), Error: normalised, Job: job}
+				clusters[clusterKey] = cluster
+			}
+			cluster.Targets = append(cluster.Targets, failed)
+		}
+	}
+
+	output += fmt.Sprintf("Replicas: %s\n", strings.Join(loaded, ", "))
+	if len(clusters) == 0 {
+		output += "\n✓ No down targets with a scrape error.\n"
+		return api.NewToolCallResult(output, nil), nil
+	}
+
+	for _, failed := range targets {
+		correlateFailedTarget(params, failed)
+	}
+
+	sorted := make([]*failureCluster, 0, len(clusters))
+	for _, cluster := range clusters {
+		sort.Slice(cluster.Targets, func(i, j int) bool {
+			return targetName(cluster.Targets[i].Target) < targetName(cluster.Targets[j].Target)
+		})
+		sorted = append(sorted, cluster)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i].Targets) != len(sorted[j].Targets) {
+			return len(sorted[i].Targets) > len(sorted[j].Targets)
+		}
+		if sorted[i].Error != sorted[j].Error {
+			return sorted[i].Error < sorted[j].Error
+		}
+		return sorted[i].Job < sorted[j].Job
+	})
+
+	output += fmt.Sprintf("Down Targets: %d in %d clusters\n\n", len(targets), len(sorted))
+
+	for i, cluster := range sorted {
+		output += fmt.Sprintf("[%d] ✗ %s - job %s - %d targets\n", i+1, cluster.Cause.Name, cluster.Job, len(cluster.Targets))
+		output += strings.Repeat("-", 80) + "\n"
+		output += fmt.Sprintf("Error: %s\n", truncate(cluster.Error, 200))
+
+		nodes, namespaces := make(map[string]int), make(map[string]int)
+		podStates, endpointStates := make(map[string]int), make(map[string]int)
+		for _, failed := range cluster.Targets {
+			if failed.Node != "" {
+				nodes[failed.Node]++
+			}
+			if ns := getNamespace(failed.Target.Labels); ns != "" {
+				namespaces[ns]++
+			}
+			switch {
+			case failed.Pod == "":
+			case failed.Symbol == "":
+				podStates["not in gather"]++
+			case failed.Symbol == "✓":
+				podStates["✓ running and ready"]++
+			default:
+				podStates["✗ not ready"]++
+			}
+			if failed.Endpoint != "" {
+				endpointStates[failed.Endpoint]++
+			}
+		}
+		if len(namespaces) > 0 {
+			output += fmt.Sprintf("Namespaces: %s\n", formatCounts(namespaces))
+		}
+		if len(nodes) > 0 {
+			output += fmt.Sprintf("Nodes: %s\n", formatCounts(nodes))
+		}
+		if len(podStates) > 0 {
+			output += fmt.Sprintf("Pods: %s\n", formatCounts(podStates))
+		}
+		if len(endpointStates) > 0 {
+			output += fmt.Sprintf("Endpoints: %s\n", formatCounts(endpointStates))
+		}
+		output += fmt.Sprintf("Hint: %s\n", clusterHint(cluster, podStates))
+
+		output += "Targets:\n"
+		for j, failed := range cluster.Targets {
+			if limit > 0 && j >= limit {
+				output += fmt.Sprintf("  ... and %d more (raise limit to see them)\n", len(cluster.Targets)-limit)
+				break
+			}
+			output += "  • " + targetName(failed.Target) + "\n"
+			details := make([]string, 0, 3)
+			if failed.Pod != "" {
+				if failed.Symbol != "" {
+					details = append(details, fmt.Sprintf("pod %s %s %s", failed.Pod, failed.Symbol, failed.Status))
+				} else {
+					details = append(details, fmt.Sprintf("pod %s not in gather", failed.Pod))
+				}
+			} else if failed.Node != "" {
+				details = append(details, "node "+failed.Node)
+			}
+			if failed.Endpoint != "" {
+				details = append(details, "endpoint "+failed.Endpoint)
+			}
+			if len(failed.Replicas) < len(loaded) {
+				details = append(details, "only on "+strings.Join(failed.Replicas, ", "))
+			}
+			if len(details) > 0 {
+				output += "    " + strings.Join(details, "; ") + "\n"
+			}
+		}
+		output += "\n"
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// normaliseScrapeError strips the URLs, addresses, host names and durations
+// that differ between targets failing for the same reason
+func normaliseScrapeError(lastError string) string {
+	normalised := errorURLPattern.ReplaceAllString(lastError, "<url>")
+	normalised = errorLookupPattern.ReplaceAllString(normalised, "lookup <host>")
+	normalised = errorAddrPattern.ReplaceAllString(normalised, "<addr>")
+	normalised = errorDurationPattern.ReplaceAllString(normalised, "<duration>")
+	return strings.TrimSpace(normalised)
+}
+
+func classifyScrapeError(lastError string) scrapeCause {
+	for _, cause := range scrapeCauses {
+		if cause.Pattern.MatchString(lastError) {
+			return cause
+		}
+	}
+	return scrapeCause{Name: "other error", Hint: "Check the target's logs and the scrape configuration"}
+}
+
+// correlateFailedTarget looks up the pod, node and endpoint address behind a target
+func correlateFailedTarget(params api.ToolHandlerParams, failed *failedTarget) {
+	target := failed.Target
+	discovered := target.DiscoveredLabels
+	namespace := valueOr(target.Labels["namespace"], discovered["__meta_kubernetes_namespace"])
+	podName := valueOr(target.Labels["pod"], discovered["__meta_kubernetes_pod_name"])
+	failed.Node = valueOr(target.Labels["node"], valueOr(discovered["__meta_kubernetes_pod_node_name"],
+		valueOr(discovered["__meta_kubernetes_endpoint_node_name"], discovered["__meta_kubernetes_node_name"])))
+
+	if namespace != "" && podName != "" {
+		failed.Pod = namespace + "/" + podName
+		pod, err := params.MustGatherProvider.GetResource(params.Context, parseGVK("v1", "Pod"), namespace, podName)
+		if err == nil {
+			failed.Symbol, failed.Status = objectStatus(pod)
+			if nodeName, _, _ := unstructured.NestedString(pod.Object, "spec", "nodeName"); nodeName != "" {
+				failed.Node = nodeName
+			}
+		}
+	}
+
+	service := valueOr(target.Labels["service"], discovered["__meta_kubernetes_service_name"])
+	if namespace == "" || service == "" {
+		return
+	}
+	endpoints, err := params.MustGatherProvider.GetResource(params.Context, parseGVK("v1", "Endpoints"), namespace, service)
+	if err != nil {
+		return
+	}
+	host := targetHost(target)
+	failed.Endpoint = "missing from " + service + " endpoints"
+	subsets, _, _ := unstructured.NestedSlice(endpoints.Object, "subsets")
+	for _, subset := range subsets {
+		subsetMap, ok := subset.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for field, state := range map[string]string{"addresses": "ready", "notReadyAddresses": "not ready"} {
+			addresses, _, _ := unstructured.NestedSlice(subsetMap, field)
+			for _, address := range addresses {
+				if addressMap, ok := address.(map[string]interface{}); ok && addressMap["ip"] == host {
+					failed.Endpoint = state
+				}
+			}
+		}
+	}
+}
+
+// targetHost returns the IP or host name a target is scraped at
+func targetHost(target ActiveTarget) string {
+	address := target.DiscoveredLabels["__address__"]
+	if address == "" {
+		if parsed, err := url.Parse(target.ScrapeURL); err == nil {
+			address = parsed.Host
+		}
+	}
+	if host, _, found := strings.Cut(address, ":"); found && !strings.HasPrefix(address, "[") {
+		return host
+	}
+	return strings.Trim(strings.Split(address, "]")[0], "[")
+}
+
+// clusterHint adds what the pod states suggest to the cause hint
+func clusterHint(cluster *failureCluster, podStates map[string]int) string {
+	hint := cluster.Cause.Hint
+	notReady, ready := podStates["✗ not ready"], podStates["✓ running and ready"]
+	switch {
+	case notReady > 0 && ready == 0:
+		hint += ". All pods found are not ready, fix them first"
+	case ready > 0 && notReady == 0 && cluster.Cause.Name == "connection refused":
+		hint += ". The pods are ready, so the metrics port or scheme is likely wrong"
+	}
+	return hint
+}
+
+// formatCounts renders "a (3), b (1)", most frequent first
+func formatCounts(counts map[string]int) string {
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	parts := make([]string, 0, len(keys))
+	for i, key := range keys {
+		if i >= 8 {
+			parts = append(parts, fmt.Sprintf("%d more", len(keys)-i))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s (%d)", key, counts[key]))
+	}
+	return strings.Join(parts, ", ")
+}
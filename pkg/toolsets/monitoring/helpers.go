@@ -3,53 +3,32 @@ package monitoring
 import (
 	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
+	"path"
+	"strconv"
 	"strings"
 
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
-// findContainerDir finds the must-gather container directory
-// Must-gather structure can be: must-gather-root/quay-io-...-sha256-.../
-func findContainerDir(mustGatherPath string) (string, error) {
-	entries, err := os.ReadDir(mustGatherPath)
-	if err != nil {
-		return "", err
-	}
-
-	// Look for directory starting with "quay" or containing "sha256"
-	for _, entry := range entries {
-		if entry.IsDir() {
-			name := entry.Name()
-			if strings.HasPrefix(name, "quay") || strings.Contains(name, "sha256") {
-				return filepath.Join(mustGatherPath, name), nil
-			}
-		}
-	}
-
-	return "", fmt.Errorf("container directory not found")
-}
-
 // getPrometheusReplicaPath builds path to Prometheus replica data
-func getPrometheusReplicaPath(containerDir string, replicaNum int) string {
-	return filepath.Join(containerDir, "monitoring", "prometheus",
-		fmt.Sprintf("prometheus-k8s-%d", replicaNum))
+func getPrometheusReplicaPath(replica string) string {
+	return path.Join("monitoring", "prometheus", replica)
 }
 
 // getPrometheusCommonPath builds path to common Prometheus data
-func getPrometheusCommonPath(containerDir string) string {
-	return filepath.Join(containerDir, "monitoring", "prometheus")
+func getPrometheusCommonPath() string {
+	return path.Join("monitoring", "prometheus")
 }
 
 // getAlertManagerPath builds path to AlertManager data
-func getAlertManagerPath(containerDir string) string {
-	return filepath.Join(containerDir, "monitoring", "alertmanager")
+func getAlertManagerPath() string {
+	return path.Join("monitoring", "alertmanager")
 }
 
-// readJSON reads and unmarshals a JSON file
-func readJSON(filePath string, v interface{}) error {
-	data, err := os.ReadFile(filePath)
+// readJSON reads and unmarshals a JSON file of the gather directory
+func readJSON(files api.GatherFS, filePath string, v interface{}) error {
+	data, err := files.ReadFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to read file: %w", err)
 	}
@@ -60,21 +39,39 @@ func readJSON(filePath string, v interface{}) error {
 }
 
 // readPrometheusJSON reads JSON from a Prometheus replica directory
-func readPrometheusJSON(replicaPath, filename string, v interface{}) error {
-	dataFile := filepath.Join(replicaPath, filename)
-	return readJSON(dataFile, v)
+func readPrometheusJSON(files api.GatherFS, replicaPath, filename string, v interface{}) error {
+	dataFile := path.Join(replicaPath, filename)
+	return readJSON(files, dataFile, v)
 }
 
-// getReplicaNumbers converts replica parameter to numbers
-func getReplicaNumbers(replicaParam string) []int {
+// listPrometheusReplicas returns the replicas gathered under monitoring/prometheus/,
+// e.g. prometheus-k8s-0 and prometheus-k8s-1
+func listPrometheusReplicas(files api.GatherFS) []string {
+	entries, err := files.ReadDir(getPrometheusCommonPath())
+	if err != nil {
+		return nil
+	}
+
+	replicas := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() && strings.HasPrefix(entry.Name(), "prometheus-") {
+			replicas = append(replicas, entry.Name())
+		}
+	}
+	return replicas
+}
+
+// getReplicas resolves the replica parameter: "both" (or "all") selects every
+// gathered replica and a bare number selects prometheus-k8s-<n>
+func getReplicas(files api.GatherFS, replicaParam string) []string {
 	switch replicaParam {
-	case "prometheus-k8s-0", "0":
-		return []int{0}
-	case "prometheus-k8s-1", "1":
-		return []int{1}
-	default: // "both"
-		return []int{0, 1}
+	case "", "both", "all":
+		return listPrometheusReplicas(files)
+	}
+	if _, err := strconv.Atoi(replicaParam); err == nil {
+		return []string{"prometheus-k8s-" + replicaParam}
 	}
+	return []string{replicaParam}
 }
 
 // formatBytes formats bytes as human-readable string
@@ -200,8 +197,8 @@ func getJob(labels map[string]string) string {
 }
 
 // fileExists checks if a file exists
-func fileExists(path string) bool {
-	_, err := os.Stat(path)
+func fileExists(files api.GatherFS, name string) bool {
+	_, err := files.Stat(name)
 	return err == nil
 }
 
@@ -20,8 +20,7 @@ func prometheusTools() []api.ServerTool {
 					Properties: map[string]*jsonschema.Schema{
 						"replica": {
 							Type:        "string",
-							Description: "Prometheus replica to query: 'prometheus-k8s-0', 'prometheus-k8s-1', or 'both'",
-							Enum:        []interface{}{"prometheus-k8s-0", "prometheus-k8s-1", "both", "0", "1"},
+							Description: "Prometheus replica to query, e.g. 'prometheus-k8s-0' or '0', or 'both' for every gathered replica (default: both)",
 						},
 					},
 				},
@@ -31,14 +30,13 @@ func prometheusTools() []api.ServerTool {
 		{
 			Tool: api.Tool{
 				Name:        "monitoring_prometheus_targets",
-				Description: "List Prometheus scrape targets with health status, job, and namespace filtering",
+				Description: "List Prometheus scrape targets with health status, job, and namespace filtering, or cluster down targets by failure cause",
 				InputSchema: &jsonschema.Schema{
 					Type: "object",
 					Properties: map[string]*jsonschema.Schema{
 						"replica": {
 							Type:        "string",
-							Description: "Prometheus replica to query: 'prometheus-k8s-0', 'prometheus-k8s-1', or 'both'",
-							Enum:        []interface{}{"prometheus-k8s-0", "prometheus-k8s-1", "both", "0", "1"},
+							Description: "Prometheus replica to query, e.g. 'prometheus-k8s-0' or '0', or 'both' for every gathered replica (default: both)",
 						},
 						"health": {
 							Type:        "string",
@@ -55,7 +53,12 @@ func prometheusTools() []api.ServerTool {
 						},
 						"limit": {
 							Type:        "integer",
-							Description: "Maximum number of targets to show (0 for all)",
+							Description: "Maximum number of targets to show (0 for all; default: all in list mode, 5 per cluster in clusters mode)",
+						},
+						"mode": {
+							Type:        "string",
+							Description: "'list' shows each target, 'clusters' groups down targets by normalised error and job with their node, pod and endpoint state (default: list)",
+							Enum:        []interface{}{"list", "clusters"},
 						},
 					},
 				},
@@ -71,8 +74,7 @@ func prometheusTools() []api.ServerTool {
 					Properties: map[string]*jsonschema.Schema{
 						"replica": {
 							Type:        "string",
-							Description: "Prometheus replica to query: 'prometheus-k8s-0', 'prometheus-k8s-1', or 'both'",
-							Enum:        []interface{}{"prometheus-k8s-0", "prometheus-k8s-1", "both", "0", "1"},
+							Description: "Prometheus replica to query, e.g. 'prometheus-k8s-0' or '0', or 'both' for every gathered replica (default: both)",
 						},
 						"top": {
 							Type:        "integer",
@@ -87,37 +89,36 @@ func prometheusTools() []api.ServerTool {
 }
 
 func prometheusStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	replica := params.GetString("replica", "both")
+	replicaParam := params.GetString("replica", "both")
 
-	// Get container directory
-	containerDir, err := findContainerDir(params.MustGatherProvider.GetMetadata().Path)
-	if err != nil {
-		containerDir = params.MustGatherProvider.GetMetadata().Path
-	}
+	files := params.MustGatherProvider.Files()
 
 	// Format output
 	output := "Prometheus Server Status\n"
 	output += strings.Repeat("=", 80) + "\n\n"
 
-	replicaNums := getReplicaNumbers(replica)
+	replicas := getReplicas(files, replicaParam)
+	if len(replicas) == 0 {
+		output += "No Prometheus replica data found under monitoring/prometheus/\n"
+	}
 
-	for _, num := range replicaNums {
-		replicaPath := getPrometheusReplicaPath(containerDir, num)
+	for _, replica := range replicas {
+		replicaPath := getPrometheusReplicaPath(replica)
 
 		// Read TSDB status
 		var tsdbResp TSDBStatusResponse
-		if err := readPrometheusJSON(replicaPath, "status/tsdb.json", &tsdbResp); err != nil {
-			output += fmt.Sprintf("⚠ prometheus-k8s-%d: Failed to read TSDB status - %v\n\n", num, err)
+		if err := readPrometheusJSON(files, replicaPath, "status/tsdb.json", &tsdbResp); err != nil {
+			output += fmt.Sprintf("⚠ %s: Failed to read TSDB status - %v\n\n", replica, err)
 			continue
 		}
 		tsdb := tsdbResp.Data
 
 		// Read runtime info
 		var runtimeResp RuntimeInfoResponse
-		runtimeErr := readPrometheusJSON(replicaPath, "status/runtimeinfo.json", &runtimeResp)
+		runtimeErr := readPrometheusJSON(files, replicaPath, "status/runtimeinfo.json", &runtimeResp)
 		runtime := runtimeResp.Data
 
-		output += fmt.Sprintf("Replica: prometheus-k8s-%d\n", num)
+		output += fmt.Sprintf("Replica: %s\n", replica)
 		output += strings.Repeat("-", 80) + "\n"
 
 		if runtimeErr == nil {
@@ -170,30 +171,37 @@ func prometheusStatus(params api.ToolHandlerParams) (*api.ToolCallResult, error)
 }
 
 func prometheusTargets(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	replica := params.GetString("replica", "both")
+	switch mode := params.GetString("mode", "list"); mode {
+	case "list":
+	case "clusters":
+		return prometheusTargetClusters(params)
+	default:
+		return api.NewToolCallResult("", fmt.Errorf("unknown mode %q, use 'list' or 'clusters'", mode)), nil
+	}
+
+	replicaParam := params.GetString("replica", "both")
 	healthFilter := params.GetString("health", "all")
 	jobFilter := params.GetString("job", "")
 	nsFilter := params.GetString("namespace", "")
 	limit := params.GetInt("limit", 0)
 
-	// Get container directory
-	containerDir, err := findContainerDir(params.MustGatherProvider.GetMetadata().Path)
-	if err != nil {
-		containerDir = params.MustGatherProvider.GetMetadata().Path
-	}
+	files := params.MustGatherProvider.Files()
 
 	output := "Prometheus Scrape Targets\n"
 	output += strings.Repeat("=", 80) + "\n\n"
 
-	replicaNums := getReplicaNumbers(replica)
+	replicas := getReplicas(files, replicaParam)
+	if len(replicas) == 0 {
+		output += "No Prometheus replica data found under monitoring/prometheus/\n"
+	}
 
-	for _, num := range replicaNums {
-		replicaPath := getPrometheusReplicaPath(containerDir, num)
+	for _, replica := range replicas {
+		replicaPath := getPrometheusReplicaPath(replica)
 
 		// Read active targets
 		var targetsAPIResp ActiveTargetsAPIResponse
-		if err := readPrometheusJSON(replicaPath, "active-targets.json", &targetsAPIResp); err != nil {
-			output += fmt.Sprintf("⚠ prometheus-k8s-%d: Failed to read targets - %v\n\n", num, err)
+		if err := readPrometheusJSON(files, replicaPath, "active-targets.json", &targetsAPIResp); err != nil {
+			output += fmt.Sprintf("⚠ %s: Failed to read targets - %v\n\n", replica, err)
 			continue
 		}
 		targetsResp := targetsAPIResp.Data
@@ -252,7 +260,7 @@ func prometheusTargets(params api.ToolHandlerParams) (*api.ToolCallResult, error
 			filteredTargets = filteredTargets[:limit]
 		}
 
-		output += fmt.Sprintf("Replica: prometheus-k8s-%d\n", num)
+		output += fmt.Sprintf("Replica: %s\n", replica)
 		output += strings.Repeat("-", 80) + "\n"
 
 		output += fmt.Sprintf("Total Targets: %d\n", len(targetsResp.ActiveTargets))
@@ -306,32 +314,31 @@ func prometheusTargets(params api.ToolHandlerParams) (*api.ToolCallResult, error
 }
 
 func prometheusTSDB(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	replica := params.GetString("replica", "both")
+	replicaParam := params.GetString("replica", "both")
 	top := params.GetInt("top", 10)
 
-	// Get container directory
-	containerDir, err := findContainerDir(params.MustGatherProvider.GetMetadata().Path)
-	if err != nil {
-		containerDir = params.MustGatherProvider.GetMetadata().Path
-	}
+	files := params.MustGatherProvider.Files()
 
 	output := "Prometheus TSDB Details\n"
 	output += strings.Repeat("=", 80) + "\n\n"
 
-	replicaNums := getReplicaNumbers(replica)
+	replicas := getReplicas(files, replicaParam)
+	if len(replicas) == 0 {
+		output += "No Prometheus replica data found under monitoring/prometheus/\n"
+	}
 
-	for _, num := range replicaNums {
-		replicaPath := getPrometheusReplicaPath(containerDir, num)
+	for _, replica := range replicas {
+		replicaPath := getPrometheusReplicaPath(replica)
 
 		// Read TSDB status
 		var tsdbResp TSDBStatusResponse
-		if err := readPrometheusJSON(replicaPath, "status/tsdb.json", &tsdbResp); err != nil {
-			output += fmt.Sprintf("⚠ prometheus-k8s-%d: Failed to read TSDB status - %v\n\n", num, err)
+		if err := readPrometheusJSON(files, replicaPath, "status/tsdb.json", &tsdbResp); err != nil {
+			output += fmt.Sprintf("⚠ %s: Failed to read TSDB status - %v\n\n", replica, err)
 			continue
 		}
 		tsdb := tsdbResp.Data
 
-		output += fmt.Sprintf("Replica: prometheus-k8s-%d\n", num)
+		output += fmt.Sprintf("Replica: %s\n", replica)
 		output += strings.Repeat("-", 80) + "\n\n"
 
 		// Head stats
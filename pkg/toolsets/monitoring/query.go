@@ -0,0 +1,206 @@
+package monitoring
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"github.com/prometheus/prometheus/promql"
+)
+
+func queryTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "monitoring_query",
+				Description: "Evaluate an instant PromQL query offline against metric snapshots in the must-gather: Prometheus text or OpenMetrics dumps (*.prom, *.metrics, metrics.txt) and saved /api/v1/query or query_range JSON results under monitoring/ and metrics/. Query results without a metric name are named after their file, e.g. top_cpu.json becomes top_cpu",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"query": {
+							Type:        "string",
+							Description: "PromQL expression, e.g. topk(10, sum by (namespace, pod) (rate(container_cpu_usage_seconds_total[5m])))",
+						},
+						"time": {
+							Type:        "string",
+							Description: "Evaluation time as RFC3339 or unix seconds (default: newest sample in the snapshots)",
+						},
+						"source": {
+							Type:        "string",
+							Description: "File or directory relative to the gather directory to load instead of discovering snapshots",
+						},
+						"lookbackDelta": {
+							Type:        "string",
+							Description: "How far back an instant selector looks for a sample, e.g. 1h for snapshots taken at different times (default: 5m)",
+						},
+						"limit": {
+							Type:        "integer",
+							Description: "Maximum result series to show (default: 50)",
+						},
+					},
+					Required: []string{"query"},
+				},
+			},
+			Handler: promQuery,
+		},
+	}
+}
+
+func promQuery(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	query := params.GetString("query", "")
+	timeParam := params.GetString("time", "")
+	source := strings.Trim(params.GetString("source", ""), "/")
+	lookbackParam := params.GetString("lookbackDelta", "5m")
+	limit := params.GetInt("limit", 50)
+
+	if query == "" {
+		return api.NewToolCallResult("", fmt.Errorf("query is required")), nil
+	}
+	lookback, err := time.ParseDuration(lookbackParam)
+	if err != nil || lookback <= 0 {
+		return api.NewToolCallResult("", fmt.Errorf("invalid lookbackDelta %q", lookbackParam)), nil
+	}
+
+	store, err := loadMetricSnapshots(params.MustGatherProvider.Files(), source)
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if store.SeriesCount() == 0 {
+		err := fmt.Errorf("no metric snapshots found under %s", strings.Join(metricSnapshotDirs, ", "))
+		if source != "" {
+			err = fmt.Errorf("no metric snapshots could be loaded from %s", source)
+		}
+		if len(store.Errors) > 0 {
+			err = fmt.Errorf("%w (%s)", err, strings.Join(store.Errors, "; "))
+		}
+		return api.NewToolCallResult("", err), nil
+	}
+
+	// Evaluation time: requested, else the newest sample, else the end of the gather
+	var evalTime time.Time
+	evalSource := "requested"
+	switch {
+	case timeParam != "":
+		evalTime, err = parseEvalTime(timeParam)
+		if err != nil {
+			return api.NewToolCallResult("", err), nil
+		}
+	default:
+		if latest, ok := store.LatestTimestamp(); ok {
+			evalTime, evalSource = time.UnixMilli(latest).UTC(), "newest sample"
+		} else if end := params.MustGatherProvider.GetMetadata().EndTime; !end.IsZero() {
+			evalTime, evalSource = end.UTC(), "must-gather end, snapshots have no timestamps"
+		} else {
+			evalTime, evalSource = time.Now().UTC(), "now, snapshots have no timestamps"
+		}
+	}
+	store.Seal(evalTime.UnixMilli())
+
+	engine := promql.NewEngine(promql.EngineOpts{
+		MaxSamples:           50000000,
+		Timeout:              30 * time.Second,
+		LookbackDelta:        lookback,
+		EnableAtModifier:     true,
+		EnableNegativeOffset: true,
+	})
+	q, err := engine.NewInstantQuery(params.Context, store, promql.NewPrometheusQueryOpts(false, lookback), query, evalTime)
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("invalid query: %w", err)), nil
+	}
+	defer q.Close()
+	result := q.Exec(params.Context)
+	if result.Err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("query failed: %w", result.Err)), nil
+	}
+
+	output := "PromQL Query\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+	output += fmt.Sprintf("Query: %s\n", query)
+	output += fmt.Sprintf("Evaluated At: %s (%s)\n", evalTime.Format(time.RFC3339), evalSource)
+	output += fmt.Sprintf("Snapshots: %d files, %s series\n", len(store.Files), formatNumber(int64(store.SeriesCount())))
+	for _, file := range store.Files {
+		line := fmt.Sprintf("  • %s (%s, %d series, %d samples", file.Path, file.Format, file.Series, file.Samples)
+		if file.Skipped > 0 {
+			line += fmt.Sprintf(", %d skipped", file.Skipped)
+		}
+		output += line + ")\n"
+	}
+	for _, loadErr := range store.Errors {
+		output += fmt.Sprintf("  ⚠ %s\n", loadErr)
+	}
+	for _, warning := range result.Warnings.AsErrors() {
+		output += fmt.Sprintf("⚠ %v\n", warning)
+	}
+	output += "\n"
+
+	switch value := result.Value.(type) {
+	case promql.Vector:
+		output += fmt.Sprintf("Result: vector (%d series)\n", len(value))
+		output += strings.Repeat("-", 80) + "\n"
+		if len(value) == 0 {
+			output += "(empty result)\n"
+		}
+		for i, sample := range value {
+			if limit > 0 && i >= limit {
+				output += fmt.Sprintf("... and %d more series\n", len(value)-limit)
+				break
+			}
+			sampleValue := formatSampleValue(sample.F)
+			if sample.H != nil {
+				sampleValue = sample.H.String()
+			}
+			output += fmt.Sprintf("%-18s %s\n", sampleValue, sample.Metric.String())
+		}
+	case promql.Matrix:
+		output += fmt.Sprintf("Result: matrix (%d series)\n", len(value))
+		output += strings.Repeat("-", 80) + "\n"
+		if len(value) == 0 {
+			output += "(empty result)\n"
+		}
+		for i, series := range value {
+			if limit > 0 && i >= limit {
+				output += fmt.Sprintf("... and %d more series\n", len(value)-limit)
+				break
+			}
+			output += fmt.Sprintf("%s\n", series.Metric.String())
+			for _, point := range series.Floats {
+				output += fmt.Sprintf("  %s  %s\n", time.UnixMilli(point.T).UTC().Format(time.RFC3339), formatSampleValue(point.F))
+			}
+			if len(series.Histograms) > 0 {
+				output += fmt.Sprintf("  (%d histogram samples)\n", len(series.Histograms))
+			}
+		}
+	case promql.Scalar:
+		output += fmt.Sprintf("Result: scalar %s\n", formatSampleValue(value.V))
+	case promql.String:
+		output += fmt.Sprintf("Result: string %q\n", value.V)
+	default:
+		output += fmt.Sprintf("Result: %s\n", result.Value.String())
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// parseEvalTime accepts RFC3339 or unix seconds
+func parseEvalTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q, expected RFC3339 or unix seconds", value)
+	}
+	return time.UnixMilli(int64(seconds * 1000)).UTC(), nil
+}
+
+// formatSampleValue prints plain decimals except for very large or small values
+func formatSampleValue(value float64) string {
+	if abs := math.Abs(value); abs == 0 || (abs >= 1e-4 && abs < 1e15) {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
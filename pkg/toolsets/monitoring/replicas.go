@@ -0,0 +1,453 @@
+package monitoring
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+)
+
+const (
+	// seriesDivergenceThreshold is the relative series count difference reported as divergence
+	seriesDivergenceThreshold = 0.10
+	// headLagThreshold is how far one replica's newest sample may trail the other's
+	headLagThreshold = 5 * time.Minute
+)
+
+func replicaTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "monitoring_replica_diff",
+				Description: "Compare two Prometheus replicas: targets up on one and down on the other, rule groups with different health, and head series or sample time divergence that points at a stuck or split-brain replica",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"replicaA": {
+							Type:        "string",
+							Description: "First replica, e.g. 'prometheus-k8s-0' or '0' (default: first gathered replica)",
+						},
+						"replicaB": {
+							Type:        "string",
+							Description: "Second replica, e.g. 'prometheus-k8s-1' or '1' (default: next gathered replica)",
+						},
+						"limit": {
+							Type:        "integer",
+							Description: "Maximum differences to list per section (default: 20)",
+						},
+					},
+				},
+			},
+			Handler: prometheusReplicaDiff,
+		},
+	}
+}
+
+func prometheusReplicaDiff(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	limit := params.GetInt("limit", 20)
+
+	files := params.MustGatherProvider.Files()
+	gathered := listPrometheusReplicas(files)
+
+	replicaA := resolveReplica(files, params.GetString("replicaA", ""))
+	replicaB := resolveReplica(files, params.GetString("replicaB", ""))
+	for _, replica := range gathered {
+		if replicaA == "" && replica != replicaB {
+			replicaA = replica
+		} else if replicaB == "" && replica != replicaA {
+			replicaB = replica
+		}
+	}
+	if replicaA == "" || replicaB == "" {
+		return api.NewToolCallResult("", fmt.Errorf("need two Prometheus replicas to compare, found %d under monitoring/prometheus/", len(gathered))), nil
+	}
+	if replicaA == replicaB {
+		return api.NewToolCallResult("", fmt.Errorf("replicaA and replicaB are both %s", replicaA)), nil
+	}
+
+	output := "Prometheus Replica Comparison\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+	output += fmt.Sprintf("Replicas: %s vs %s\n", replicaA, replicaB)
+	if others := otherReplicas(gathered, replicaA, replicaB); len(others) > 0 {
+		output += fmt.Sprintf("Also gathered: %s\n", strings.Join(others, ", "))
+	}
+	output += "\n"
+
+	differences := 0
+
+	section, count := diffReplicaSeries(files, replicaA, replicaB, limit)
+	output += section
+	differences += count
+
+	section, count = diffReplicaTargets(files, replicaA, replicaB, limit)
+	output += section
+	differences += count
+
+	section, count = diffReplicaRules(files, replicaA, replicaB, limit)
+	output += section
+	differences += count
+
+	output += strings.Repeat("=", 80) + "\n"
+	if differences == 0 {
+		output += "✓ Replicas agree on targets, rule health and series counts\n"
+	} else {
+		output += fmt.Sprintf("⚠ %d difference(s) between %s and %s\n", differences, replicaA, replicaB)
+		output += "  A replica trailing in samples or series is likely stuck or restarting; targets down on\n"
+		output += "  one replica only point at network reachability from that replica's node.\n"
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// diffReplicaSeries compares head series counts, the newest sample and the
+// per-metric series counts of status/tsdb.json
+func diffReplicaSeries(files api.GatherFS, replicaA, replicaB string, limit int) (string, int) {
+	output := "Series (status/tsdb.json)\n"
+	output += strings.Repeat("-", 80) + "\n"
+
+	var tsdbA, tsdbB TSDBStatusResponse
+	errA := readPrometheusJSON(files, getPrometheusReplicaPath(replicaA), "status/tsdb.json", &tsdbA)
+	errB := readPrometheusJSON(files, getPrometheusReplicaPath(replicaB), "status/tsdb.json", &tsdbB)
+	if errA != nil || errB != nil {
+		if errA != nil {
+			output += fmt.Sprintf("  ⚠ %s: %v\n", replicaA, errA)
+		}
+		if errB != nil {
+			output += fmt.Sprintf("  ⚠ %s: %v\n", replicaB, errB)
+		}
+		return output + "\n", 0
+	}
+	headA, headB := tsdbA.Data.HeadStats, tsdbB.Data.HeadStats
+
+	differences := 0
+	output += fmt.Sprintf("  %-16s %22s %22s\n", "", replicaA, replicaB)
+
+	marker := ""
+	if divergence(headA.NumSeries, headB.NumSeries) > seriesDivergenceThreshold {
+		marker = fmt.Sprintf("  ⚠ %.1f%% apart", divergence(headA.NumSeries, headB.NumSeries)*100)
+		differences++
+	}
+	output += fmt.Sprintf("  %-16s %22s %22s%s\n", "Head Series", formatNumber(headA.NumSeries), formatNumber(headB.NumSeries), marker)
+
+	if headA.MaxTime > 0 && headB.MaxTime > 0 {
+		newestA, newestB := time.UnixMilli(headA.MaxTime).UTC(), time.UnixMilli(headB.MaxTime).UTC()
+		marker = ""
+		if lag := newestA.Sub(newestB); lag > headLagThreshold {
+			marker = fmt.Sprintf("  ⚠ %s trails by %s", replicaB, lag)
+			differences++
+		} else if lag < -headLagThreshold {
+			marker = fmt.Sprintf("  ⚠ %s trails by %s", replicaA, -lag)
+			differences++
+		}
+		output += fmt.Sprintf("  %-16s %22s %22s%s\n", "Newest Sample", newestA.Format(time.RFC3339), newestB.Format(time.RFC3339), marker)
+	}
+
+	// Only the top metrics are gathered, so a metric missing on one side is not a difference
+	seriesB := make(map[string]int64, len(tsdbB.Data.SeriesCountByMetricName))
+	for _, metric := range tsdbB.Data.SeriesCountByMetricName {
+		seriesB[metric.Name] = metric.Value
+	}
+	type metricDiff struct {
+		name   string
+		a, b   int64
+		amount int64
+	}
+	diverging := make([]metricDiff, 0)
+	for _, metric := range tsdbA.Data.SeriesCountByMetricName {
+		b, ok := seriesB[metric.Name]
+		if !ok || divergence(metric.Value, b) <= seriesDivergenceThreshold {
+			continue
+		}
+		amount := metric.Value - b
+		if amount < 0 {
+			amount = -amount
+		}
+		diverging = append(diverging, metricDiff{name: metric.Name, a: metric.Value, b: b, amount: amount})
+	}
+	sort.Slice(diverging, func(i, j int) bool { return diverging[i].amount > diverging[j].amount })
+
+	if len(diverging) > 0 {
+		differences += len(diverging)
+		output += "\n  Metrics with diverging series counts:\n"
+		output += fmt.Sprintf("  %-50s %12s %12s\n", "METRIC", replicaSuffix(replicaA), replicaSuffix(replicaB))
+		for i, metric := range diverging {
+			if limit > 0 && i >= limit {
+				output += fmt.Sprintf("  ... and %d more\n", len(diverging)-limit)
+				break
+			}
+			output += fmt.Sprintf("  %-50s %12s %12s\n", truncate(metric.name, 50), formatNumber(metric.a), formatNumber(metric.b))
+		}
+	}
+
+	if differences == 0 {
+		output += "  ✓ Series counts and newest samples agree\n"
+	}
+	return output + "\n", differences
+}
+
+// diffReplicaTargets compares the health of the targets in active-targets.json
+func diffReplicaTargets(files api.GatherFS, replicaA, replicaB string, limit int) (string, int) {
+	output := "Targets (active-targets.json)\n"
+	output += strings.Repeat("-", 80) + "\n"
+
+	targets := make(map[string]map[string]ActiveTarget, 2)
+	for _, replica := range []string{replicaA, replicaB} {
+		var resp ActiveTargetsAPIResponse
+		if err := readPrometheusJSON(files, getPrometheusReplicaPath(replica), "active-targets.json", &resp); err != nil {
+			output += fmt.Sprintf("  ⚠ %s: %v\n", replica, err)
+			continue
+		}
+
+		byKey := make(map[string]ActiveTarget, len(resp.Data.ActiveTargets))
+		counts := make(map[string]int)
+		for _, target := range resp.Data.ActiveTargets {
+			byKey[target.ScrapePool+" "+target.ScrapeURL] = target
+			counts[target.Health]++
+		}
+		targets[replica] = byKey
+		output += fmt.Sprintf("  %s: %d targets (%d up, %d down, %d unknown)\n",
+			replica, len(byKey), counts["up"], counts["down"], counts["unknown"])
+	}
+	if len(targets) < 2 {
+		return output + "\n", 0
+	}
+
+	keys := make([]string, 0)
+	for key := range targets[replicaA] {
+		keys = append(keys, key)
+	}
+	for key := range targets[replicaB] {
+		if _, ok := targets[replicaA][key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	healthDiffs := make([]string, 0)
+	onlyA := make([]string, 0)
+	onlyB := make([]string, 0)
+	for _, key := range keys {
+		a, inA := targets[replicaA][key]
+		b, inB := targets[replicaB][key]
+		switch {
+		case !inB:
+			onlyA = append(onlyA, fmt.Sprintf("    • %s [%s]\n", targetName(a), a.Health))
+		case !inA:
+			onlyB = append(onlyB, fmt.Sprintf("    • %s [%s]\n", targetName(b), b.Health))
+		case a.Health != b.Health:
+			entry := fmt.Sprintf("  ✗ %s\n", targetName(a))
+			entry += fmt.Sprintf("      %-18s %s%s\n", replicaA+":", a.Health, targetErrorSuffix(a))
+			entry += fmt.Sprintf("      %-18s %s%s\n", replicaB+":", b.Health, targetErrorSuffix(b))
+			healthDiffs = append(healthDiffs, entry)
+		}
+	}
+
+	if len(healthDiffs) > 0 {
+		output += fmt.Sprintf("\n  Targets with different health (%d):\n", len(healthDiffs))
+		output += joinLimited(healthDiffs, limit, "  ")
+	}
+	if len(onlyA) > 0 {
+		output += fmt.Sprintf("\n  Only scraped by %s (%d):\n", replicaA, len(onlyA))
+		output += joinLimited(onlyA, limit, "    ")
+	}
+	if len(onlyB) > 0 {
+		output += fmt.Sprintf("\n  Only scraped by %s (%d):\n", replicaB, len(onlyB))
+		output += joinLimited(onlyB, limit, "    ")
+	}
+
+	differences := len(healthDiffs) + len(onlyA) + len(onlyB)
+	if differences == 0 {
+		output += "  ✓ Both replicas scrape the same targets with the same health\n"
+	}
+	return output + "\n", differences
+}
+
+// diffReplicaRules compares rule group health in per-replica rules.json files
+func diffReplicaRules(files api.GatherFS, replicaA, replicaB string, limit int) (string, int) {
+	output := "Rule Groups (rules.json)\n"
+	output += strings.Repeat("-", 80) + "\n"
+
+	groups := make(map[string]map[string]RuleGroup, 2)
+	for _, replica := range []string{replicaA, replicaB} {
+		rulesFile := path.Join(getPrometheusReplicaPath(replica), "rules.json")
+		if !fileExists(files, rulesFile) {
+			continue
+		}
+		var resp RuleGroupsAPIResponse
+		if err := readJSON(files, rulesFile, &resp); err != nil {
+			output += fmt.Sprintf("  ⚠ %s: %v\n", replica, err)
+			continue
+		}
+		byKey := make(map[string]RuleGroup, len(resp.Data.Groups))
+		for _, group := range resp.Data.Groups {
+			byKey[group.File+" "+group.Name] = group
+		}
+		groups[replica] = byKey
+	}
+
+	switch len(groups) {
+	case 0:
+		output += "  - Rules were not gathered per replica (only monitoring/prometheus/rules.json), rule health cannot be compared\n"
+		return output + "\n", 0
+	case 1:
+		for _, replica := range []string{replicaA, replicaB} {
+			if _, ok := groups[replica]; !ok {
+				output += fmt.Sprintf("  ⚠ rules.json not gathered for %s\n", replica)
+			}
+		}
+		return output + "\n", 0
+	}
+
+	keys := make([]string, 0)
+	for key := range groups[replicaA] {
+		keys = append(keys, key)
+	}
+	for key := range groups[replicaB] {
+		if _, ok := groups[replicaA][key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	healthDiffs := make([]string, 0)
+	missing := make([]string, 0)
+	for _, key := range keys {
+		a, inA := groups[replicaA][key]
+		b, inB := groups[replicaB][key]
+		switch {
+		case !inB:
+			missing = append(missing, fmt.Sprintf("    • %s: only loaded by %s\n", a.Name, replicaA))
+		case !inA:
+			missing = append(missing, fmt.Sprintf("    • %s: only loaded by %s\n", b.Name, replicaB))
+		default:
+			healthA, errA := ruleGroupHealth(a)
+			healthB, errB := ruleGroupHealth(b)
+			if healthA == healthB {
+				continue
+			}
+			entry := fmt.Sprintf("  ✗ %s (%s)\n", a.Name, path.Base(a.File))
+			entry += fmt.Sprintf("      %-18s %s%s\n", replicaA+":", healthA, errorSuffix(errA))
+			entry += fmt.Sprintf("      %-18s %s%s\n", replicaB+":", healthB, errorSuffix(errB))
+			healthDiffs = append(healthDiffs, entry)
+		}
+	}
+
+	output += fmt.Sprintf("  %s: %d groups, %s: %d groups\n", replicaA, len(groups[replicaA]), replicaB, len(groups[replicaB]))
+	if len(healthDiffs) > 0 {
+		output += fmt.Sprintf("\n  Groups with different health (%d):\n", len(healthDiffs))
+		output += joinLimited(healthDiffs, limit, "  ")
+	}
+	if len(missing) > 0 {
+		output += fmt.Sprintf("\n  Groups loaded by one replica only (%d):\n", len(missing))
+		output += joinLimited(missing, limit, "    ")
+	}
+
+	differences := len(healthDiffs) + len(missing)
+	if differences == 0 {
+		output += "  ✓ Same rule groups with the same health on both replicas\n"
+	}
+	return output + "\n", differences
+}
+
+// ruleGroupHealth returns the worst rule health of a group and the first rule error
+func ruleGroupHealth(group RuleGroup) (string, string) {
+	health, lastError := "ok", ""
+	for _, rule := range group.Rules {
+		switch rule.Health {
+		case "err":
+			if health != "err" {
+				lastError = fmt.Sprintf("%s: %s", rule.Name, rule.LastError)
+			}
+			health = "err"
+		case "unknown":
+			if health == "ok" {
+				health = "unknown"
+			}
+		}
+	}
+	return health, lastError
+}
+
+// resolveReplica maps a replica parameter to a replica name, "" when unset
+func resolveReplica(files api.GatherFS, replicaParam string) string {
+	if replicaParam == "" {
+		return ""
+	}
+	replicas := getReplicas(files, replicaParam)
+	if len(replicas) == 0 {
+		return ""
+	}
+	return replicas[0]
+}
+
+func otherReplicas(gathered []string, exclude ...string) []string {
+	others := make([]string, 0)
+	for _, replica := range gathered {
+		excluded := false
+		for _, e := range exclude {
+			if replica == e {
+				excluded = true
+			}
+		}
+		if !excluded {
+			others = append(others, replica)
+		}
+	}
+	return others
+}
+
+// divergence returns the difference of two counts relative to the larger one
+func divergence(a, b int64) float64 {
+	larger, diff := a, a-b
+	if b > a {
+		larger, diff = b, b-a
+	}
+	if larger == 0 {
+		return 0
+	}
+	return float64(diff) / float64(larger)
+}
+
+// replicaSuffix shortens prometheus-k8s-0 to k8s-0 for table headers
+func replicaSuffix(replica string) string {
+	return strings.TrimPrefix(replica, "prometheus-")
+}
+
+func targetName(target ActiveTarget) string {
+	name := getJob(target.Labels)
+	if ns := getNamespace(target.Labels); ns != "" {
+		name += " (" + ns + ")"
+	}
+	return name + " " + target.ScrapeURL
+}
+
+func targetErrorSuffix(target ActiveTarget) string {
+	if target.Health == "up" {
+		return ""
+	}
+	return errorSuffix(target.LastError)
+}
+
+func errorSuffix(err string) string {
+	if err == "" {
+		return ""
+	}
+	return " - " + truncate(err, 60)
+}
+
+// joinLimited joins at most limit entries, noting how many were left out
+func joinLimited(entries []string, limit int, indent string) string {
+	output := ""
+	for i, entry := range entries {
+		if limit > 0 && i >= limit {
+			output += fmt.Sprintf("%s... and %d more\n", indent, len(entries)-limit)
+			break
+		}
+		output += entry
+	}
+	return output
+}
@@ -0,0 +1,444 @@
+package monitoring
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"github.com/prometheus/prometheus/model/histogram"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/textparse"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb/chunkenc"
+	"github.com/prometheus/prometheus/tsdb/chunks"
+	"github.com/prometheus/prometheus/util/annotations"
+)
+
+// metricSnapshotDirs are searched for metric dumps when no source is given
+var metricSnapshotDirs = []string{"monitoring", "metrics"}
+
+// invalidMetricChars matches characters not allowed in a metric name
+var invalidMetricChars = regexp.MustCompile(`[^a-zA-Z0-9_:]`)
+
+// untimed marks samples of text dumps without a timestamp, they are placed at
+// the evaluation time
+const untimed = math.MinInt64
+
+// snapshotFile describes a loaded metric dump
+type snapshotFile struct {
+	Path    string
+	Format  string
+	Series  int
+	Samples int
+	Skipped int
+}
+
+// snapshotStore holds metric dumps in memory and serves them to the PromQL engine
+type snapshotStore struct {
+	series map[string]*snapshotSeries
+	Files  []snapshotFile
+	Errors []string
+}
+
+type snapshotSeries struct {
+	labels  labels.Labels
+	samples []snapshotSample
+}
+
+// snapshotSample is a float sample, implementing chunks.Sample
+type snapshotSample struct {
+	t int64
+	f float64
+}
+
+func (s snapshotSample) T() int64                      { return s.t }
+func (s snapshotSample) F() float64                    { return s.f }
+func (s snapshotSample) H() *histogram.Histogram       { return nil }
+func (s snapshotSample) FH() *histogram.FloatHistogram { return nil }
+func (s snapshotSample) Type() chunkenc.ValueType      { return chunkenc.ValFloat }
+func (s snapshotSample) Copy() chunks.Sample           { return s }
+
+// parsedSample is a sample read from a dump, added to the store once the whole
+// file parsed
+type parsedSample struct {
+	labels labels.Labels
+	t      int64
+	f      float64
+}
+
+// queryResultFile is a saved /api/v1/query or /api/v1/query_range response
+type queryResultFile struct {
+	Status string `json:"status"`
+	Data   struct {
+		ResultType string `json:"resultType"`
+		Result     []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+			Values [][]interface{}   `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+func newSnapshotStore() *snapshotStore {
+	return &snapshotStore{series: make(map[string]*snapshotSeries)}
+}
+
+// loadMetricSnapshots loads the metric dumps under source, or under the default
+// snapshot directories when source is empty. Only files that look like metric
+// dumps are picked up by discovery, an explicit source loads every file
+func loadMetricSnapshots(files api.GatherFS, source string) (*snapshotStore, error) {
+	store := newSnapshotStore()
+
+	roots := metricSnapshotDirs
+	if source != "" {
+		info, err := files.Stat(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot source %s: %w", source, err)
+		}
+		if !info.IsDir() {
+			store.loadFile(files, source, true)
+			return store, nil
+		}
+		roots = []string{source}
+	}
+
+	for _, root := range roots {
+		if !fileExists(files, root) {
+			continue
+		}
+		_ = fs.WalkDir(files, root, func(filePath string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			if source != "" || isMetricSnapshot(filePath) {
+				store.loadFile(files, filePath, source != "")
+			}
+			return nil
+		})
+	}
+	return store, nil
+}
+
+// isMetricSnapshot reports whether a file name looks like a metric dump
+func isMetricSnapshot(filePath string) bool {
+	name := strings.ToLower(strings.TrimSuffix(path.Base(filePath), ".gz"))
+	inMetricsDir := strings.Contains("/"+path.Dir(filePath)+"/", "/metrics/")
+	switch path.Ext(name) {
+	case ".prom", ".metrics", ".om":
+		return true
+	case ".txt":
+		return inMetricsDir || strings.Contains(name, "metrics")
+	case ".json":
+		return inMetricsDir || strings.Contains(name, "metrics") || strings.Contains(name, "query")
+	}
+	return name == "metrics"
+}
+
+// loadFile loads one dump, recording failures when the file was asked for
+// explicitly or was expected to hold metrics
+func (s *snapshotStore) loadFile(files api.GatherFS, filePath string, explicit bool) {
+	data, err := files.ReadFile(filePath)
+	if err != nil {
+		s.Errors = append(s.Errors, fmt.Sprintf("%s: %v", filePath, err))
+		return
+	}
+
+	file := snapshotFile{Path: filePath}
+	var samples []parsedSample
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		file.Format = "query result"
+		samples, err = parseQueryResult(data, &file)
+	} else {
+		file.Format = "text"
+		if bytes.HasSuffix(trimmed, []byte("# EOF")) {
+			file.Format = "openmetrics"
+		}
+		samples, err = parseExposition(data, file.Format, &file)
+	}
+	if err != nil {
+		if explicit || !strings.HasSuffix(strings.TrimSuffix(filePath, ".gz"), ".json") {
+			s.Errors = append(s.Errors, fmt.Sprintf("%s: %v", filePath, err))
+		}
+		return
+	}
+
+	before := len(s.series)
+	for _, sample := range samples {
+		s.add(sample.labels, sample.t, sample.f)
+	}
+	file.Series = len(s.series) - before
+	file.Samples = len(samples)
+	if file.Samples > 0 {
+		s.Files = append(s.Files, file)
+	}
+}
+
+// parseExposition parses a Prometheus text or OpenMetrics exposition
+func parseExposition(data []byte, format string, file *snapshotFile) ([]parsedSample, error) {
+	contentType := "text/plain"
+	if format == "openmetrics" {
+		contentType = "application/openmetrics-text"
+	}
+	parser, err := textparse.New(data, contentType, "text/plain", false, false, false, labels.NewSymbolTable())
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]parsedSample, 0)
+	var lset labels.Labels
+	for {
+		entry, err := parser.Next()
+		if errors.Is(err, io.EOF) {
+			return samples, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch entry {
+		case textparse.EntrySeries:
+			_, ts, value := parser.Series()
+			parser.Labels(&lset)
+			t := int64(untimed)
+			if ts != nil {
+				t = *ts
+			}
+			samples = append(samples, parsedSample{labels: lset.Copy(), t: t, f: value})
+		case textparse.EntryHistogram:
+			file.Skipped++
+		}
+	}
+}
+
+// parseQueryResult parses a saved query API response. Results without a metric
+// name are named after the file, e.g. top_cpu.json becomes top_cpu
+func parseQueryResult(data []byte, file *snapshotFile) ([]parsedSample, error) {
+	var result queryResultFile
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	if result.Data.ResultType != "vector" && result.Data.ResultType != "matrix" {
+		return nil, fmt.Errorf("not a vector or matrix query result")
+	}
+
+	name := strings.TrimSuffix(path.Base(file.Path), ".gz")
+	name = invalidMetricChars.ReplaceAllString(strings.TrimSuffix(name, path.Ext(name)), "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+
+	samples := make([]parsedSample, 0)
+	for _, entry := range result.Data.Result {
+		builder := labels.NewBuilder(labels.EmptyLabels())
+		for key, value := range entry.Metric {
+			builder.Set(key, value)
+		}
+		if entry.Metric[labels.MetricName] == "" {
+			builder.Set(labels.MetricName, name)
+		}
+		lset := builder.Labels()
+
+		points := entry.Values
+		if entry.Value != nil {
+			points = append(points, entry.Value)
+		}
+		for _, point := range points {
+			t, value, err := parseQueryPoint(point)
+			if err != nil {
+				file.Skipped++
+				continue
+			}
+			samples = append(samples, parsedSample{labels: lset, t: t, f: value})
+		}
+	}
+	return samples, nil
+}
+
+// parseQueryPoint parses a [<unix seconds>, "<value>"] pair
+func parseQueryPoint(point []interface{}) (int64, float64, error) {
+	if len(point) != 2 {
+		return 0, 0, fmt.Errorf("invalid sample %v", point)
+	}
+	seconds, ok := point[0].(float64)
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid timestamp %v", point[0])
+	}
+	raw, ok := point[1].(string)
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid value %v", point[1])
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int64(math.Round(seconds * 1000)), value, nil
+}
+
+func (s *snapshotStore) add(lset labels.Labels, t int64, value float64) {
+	key := lset.String()
+	series, ok := s.series[key]
+	if !ok {
+		series = &snapshotSeries{labels: lset}
+		s.series[key] = series
+	}
+	series.samples = append(series.samples, snapshotSample{t: t, f: value})
+}
+
+// SeriesCount returns the number of distinct series loaded
+func (s *snapshotStore) SeriesCount() int {
+	return len(s.series)
+}
+
+// LatestTimestamp returns the newest sample timestamp in milliseconds, false
+// when every sample is untimed
+func (s *snapshotStore) LatestTimestamp() (int64, bool) {
+	latest, found := int64(0), false
+	for _, series := range s.series {
+		for _, sample := range series.samples {
+			if sample.t != untimed && (!found || sample.t > latest) {
+				latest, found = sample.t, true
+			}
+		}
+	}
+	return latest, found
+}
+
+// Seal places untimed samples at the evaluation time, then sorts and
+// deduplicates the samples of every series
+func (s *snapshotStore) Seal(evalTime int64) {
+	for _, series := range s.series {
+		for i := range series.samples {
+			if series.samples[i].t == untimed {
+				series.samples[i].t = evalTime
+			}
+		}
+		sort.SliceStable(series.samples, func(i, j int) bool {
+			return series.samples[i].t < series.samples[j].t
+		})
+		deduped := series.samples[:0]
+		for _, sample := range series.samples {
+			if n := len(deduped); n > 0 && deduped[n-1].t == sample.t {
+				deduped[n-1] = sample
+				continue
+			}
+			deduped = append(deduped, sample)
+		}
+		series.samples = deduped
+	}
+}
+
+// Querier implements storage.Queryable
+func (s *snapshotStore) Querier(mint, maxt int64) (storage.Querier, error) {
+	return &snapshotQuerier{store: s, mint: mint, maxt: maxt}, nil
+}
+
+type snapshotQuerier struct {
+	store      *snapshotStore
+	mint, maxt int64
+}
+
+func (q *snapshotQuerier) Select(_ context.Context, sortSeries bool, hints *storage.SelectHints, matchers ...*labels.Matcher) storage.SeriesSet {
+	mint, maxt := q.mint, q.maxt
+	if hints != nil {
+		mint, maxt = hints.Start, hints.End
+	}
+
+	result := make([]storage.Series, 0)
+	for _, series := range q.store.series {
+		if !matchLabels(series.labels, matchers) {
+			continue
+		}
+		samples := make([]chunks.Sample, 0, len(series.samples))
+		for _, sample := range series.samples {
+			if sample.t >= mint && sample.t <= maxt {
+				samples = append(samples, sample)
+			}
+		}
+		if len(samples) > 0 {
+			result = append(result, storage.NewListSeries(series.labels, samples))
+		}
+	}
+	if sortSeries {
+		sort.Slice(result, func(i, j int) bool {
+			return labels.Compare(result[i].Labels(), result[j].Labels()) < 0
+		})
+	}
+	return &snapshotSeriesSet{series: result, index: -1}
+}
+
+func (q *snapshotQuerier) LabelValues(_ context.Context, name string, _ *storage.LabelHints, matchers ...*labels.Matcher) ([]string, annotations.Annotations, error) {
+	seen := make(map[string]bool)
+	for _, series := range q.store.series {
+		if value := series.labels.Get(name); value != "" && matchLabels(series.labels, matchers) {
+			seen[value] = true
+		}
+	}
+	return sortedSet(seen), nil, nil
+}
+
+func (q *snapshotQuerier) LabelNames(_ context.Context, _ *storage.LabelHints, matchers ...*labels.Matcher) ([]string, annotations.Annotations, error) {
+	seen := make(map[string]bool)
+	for _, series := range q.store.series {
+		if matchLabels(series.labels, matchers) {
+			series.labels.Range(func(l labels.Label) {
+				seen[l.Name] = true
+			})
+		}
+	}
+	return sortedSet(seen), nil, nil
+}
+
+func (q *snapshotQuerier) Close() error {
+	return nil
+}
+
+type snapshotSeriesSet struct {
+	series []storage.Series
+	index  int
+}
+
+func (s *snapshotSeriesSet) Next() bool {
+	s.index++
+	return s.index < len(s.series)
+}
+
+func (s *snapshotSeriesSet) At() storage.Series {
+	return s.series[s.index]
+}
+
+func (s *snapshotSeriesSet) Err() error {
+	return nil
+}
+
+func (s *snapshotSeriesSet) Warnings() annotations.Annotations {
+	return nil
+}
+
+func matchLabels(lset labels.Labels, matchers []*labels.Matcher) bool {
+	for _, matcher := range matchers {
+		if !matcher.Matches(lset.Get(matcher.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedSet(set map[string]bool) []string {
+	values := make([]string, 0, len(set))
+	for value := range set {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
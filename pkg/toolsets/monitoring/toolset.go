@@ -23,6 +23,13 @@ func (t *MonitoringToolset) GetTools() []api.ServerTool {
 	tools := []api.ServerTool{}
 	tools = append(tools, prometheusTools()...)
 	tools = append(tools, alertTools()...)
+	tools = append(tools, alertManagerTools()...)
 	tools = append(tools, configTools()...)
+	tools = append(tools, explainTools()...)
+	tools = append(tools, replicaTools()...)
+	tools = append(tools, correlateTools()...)
+	tools = append(tools, queryTools()...)
+	tools = append(tools, cardinalityTools()...)
+	tools = append(tools, validateTools()...)
 	return tools
 }
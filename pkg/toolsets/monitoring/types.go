@@ -177,6 +177,106 @@ type AlertManagerConfig struct {
 	Original string `json:"original"`
 }
 
+// AlertManagerConfigFile represents the parsed alertmanager.yaml
+type AlertManagerConfigFile struct {
+	Route             *AlertManagerRoute         `yaml:"route"`
+	Receivers         []AlertManagerReceiver     `yaml:"receivers"`
+	InhibitRules      []AlertManagerInhibitRule  `yaml:"inhibit_rules"`
+	MuteTimeIntervals []AlertManagerTimeInterval `yaml:"mute_time_intervals"`
+	TimeIntervals     []AlertManagerTimeInterval `yaml:"time_intervals"`
+}
+
+// AlertManagerRoute represents a node of the routing tree
+type AlertManagerRoute struct {
+	Receiver            string               `yaml:"receiver"`
+	GroupBy             []string             `yaml:"group_by"`
+	Continue            bool                 `yaml:"continue"`
+	Matchers            []string             `yaml:"matchers"`
+	Match               map[string]string    `yaml:"match"`
+	MatchRE             map[string]string    `yaml:"match_re"`
+	MuteTimeIntervals   []string             `yaml:"mute_time_intervals"`
+	ActiveTimeIntervals []string             `yaml:"active_time_intervals"`
+	GroupWait           string               `yaml:"group_wait"`
+	GroupInterval       string               `yaml:"group_interval"`
+	RepeatInterval      string               `yaml:"repeat_interval"`
+	Routes              []*AlertManagerRoute `yaml:"routes"`
+}
+
+// AlertManagerReceiver represents a receiver, Integrations holds its *_configs
+type AlertManagerReceiver struct {
+	Name         string                 `yaml:"name"`
+	Integrations map[string]interface{} `yaml:",inline"`
+}
+
+// AlertManagerInhibitRule represents an inhibition rule
+type AlertManagerInhibitRule struct {
+	SourceMatchers []string          `yaml:"source_matchers"`
+	SourceMatch    map[string]string `yaml:"source_match"`
+	SourceMatchRE  map[string]string `yaml:"source_match_re"`
+	TargetMatchers []string          `yaml:"target_matchers"`
+	TargetMatch    map[string]string `yaml:"target_match"`
+	TargetMatchRE  map[string]string `yaml:"target_match_re"`
+	Equal          []string          `yaml:"equal"`
+}
+
+// AlertManagerTimeInterval represents a named time interval
+type AlertManagerTimeInterval struct {
+	Name string `yaml:"name"`
+}
+
+// AlertManagerSilence represents a silence from the Alertmanager API
+type AlertManagerSilence struct {
+	ID        string                `json:"id"`
+	Status    AlertManagerState     `json:"status"`
+	Matchers  []AlertManagerMatcher `json:"matchers"`
+	StartsAt  string                `json:"startsAt"`
+	EndsAt    string                `json:"endsAt"`
+	CreatedBy string                `json:"createdBy"`
+	Comment   string                `json:"comment"`
+}
+
+// AlertManagerState represents the state of a silence
+type AlertManagerState struct {
+	State string `json:"state"`
+}
+
+// AlertManagerMatcher represents a silence matcher, IsEqual defaults to true
+type AlertManagerMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual *bool  `json:"isEqual,omitempty"`
+}
+
+// AlertManagerAlertGroup represents an alert group from the Alertmanager API
+type AlertManagerAlertGroup struct {
+	Labels   map[string]string   `json:"labels"`
+	Receiver AlertManagerName    `json:"receiver"`
+	Alerts   []AlertManagerAlert `json:"alerts"`
+}
+
+// AlertManagerAlert represents an alert as Alertmanager sees it
+type AlertManagerAlert struct {
+	Labels      map[string]string       `json:"labels"`
+	Annotations map[string]string       `json:"annotations"`
+	Fingerprint string                  `json:"fingerprint"`
+	StartsAt    string                  `json:"startsAt"`
+	Status      AlertManagerAlertStatus `json:"status"`
+	Receivers   []AlertManagerName      `json:"receivers"`
+}
+
+// AlertManagerAlertStatus represents whether an alert is active or suppressed
+type AlertManagerAlertStatus struct {
+	State       string   `json:"state"`
+	SilencedBy  []string `json:"silencedBy"`
+	InhibitedBy []string `json:"inhibitedBy"`
+}
+
+// AlertManagerName references a receiver by name
+type AlertManagerName struct {
+	Name string `json:"name"`
+}
+
 // PrometheusConfig represents Prometheus configuration
 type PrometheusConfig struct {
 	YAML string `json:"yaml"`
@@ -0,0 +1,756 @@
+package monitoring
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"github.com/openshift/must-gather-mcp-server/pkg/toolsets/internal/toolutil"
+	"github.com/prometheus/prometheus/promql/parser"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+)
+
+// selectorFields are the resource and namespace selectors a Prometheus or
+// ThanosRuler uses to pick up each kind
+var selectorFields = map[string][2]string{
+	"ServiceMonitor": {"serviceMonitorSelector", "serviceMonitorNamespaceSelector"},
+	"PodMonitor":     {"podMonitorSelector", "podMonitorNamespaceSelector"},
+	"PrometheusRule": {"ruleSelector", "ruleNamespaceSelector"},
+}
+
+// ruleFileUID matches the UID suffix of rule files generated by the operator,
+// e.g. openshift-monitoring-prometheus-k8s-rules-<uid>.yaml
+var ruleFileUID = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\.yaml$`)
+
+// monitoringInstance is a Prometheus or ThanosRuler that selects monitors and rules
+type monitoringInstance struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Spec      map[string]interface{}
+	Replicas  []string // gathered replicas, whose targets and rules can be compared
+	Synthetic bool     // derived from gathered replicas, the resource itself is not in the gather
+}
+
+func (i monitoringInstance) String() string {
+	return i.Namespace + "/" + i.Name
+}
+
+// poolTargets counts the active targets of a scrape pool
+type poolTargets struct {
+	Total int
+	Up    int
+}
+
+// validationResult collects the findings for one monitor or rule resource
+type validationResult struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Summary   []string
+	Errors    []string
+	Warnings  []string
+	Details   []string
+}
+
+func (r *validationResult) symbol() string {
+	switch {
+	case len(r.Errors) > 0:
+		return "✗"
+	case len(r.Warnings) > 0:
+		return "⚠"
+	}
+	return "✓"
+}
+
+// monitorValidator holds what the checks share: the selecting instances, their
+// active targets and loaded rules, and namespace labels
+type monitorValidator struct {
+	params      api.ToolHandlerParams
+	instances   []monitoringInstance
+	pools       map[string]map[string]poolTargets
+	ruleFiles   map[string][]RuleGroup
+	rulesLoaded bool
+	namespaces  map[string]map[string]string
+	missingNS   map[string]bool
+}
+
+func validateTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "monitoring_servicemonitor_validate",
+				Description: "Validate ServiceMonitors, PodMonitors and PrometheusRules: flags monitors not selected by any Prometheus (serviceMonitorSelector/serviceMonitorNamespaceSelector), selectors matching no Services or Pods, port names missing from the Service or Pods, ports without ready Endpoints and endpoints without active targets, and PrometheusRules with invalid expressions or groups and rules that never got loaded into rules.json",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"namespace": {
+							Type:        "string",
+							Description: "Filter by namespace (partial match)",
+						},
+						"kind": {
+							Type:        "string",
+							Description: "Resource kind to validate (default: all)",
+							Enum:        []interface{}{"ServiceMonitor", "PodMonitor", "PrometheusRule", "all"},
+						},
+						"showValid": {
+							Type:        "boolean",
+							Description: "Also list resources without problems (default: false)",
+						},
+					},
+				},
+			},
+			Handler: validateMonitors,
+		},
+	}
+}
+
+func validateMonitors(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	nsFilter := params.GetString("namespace", "")
+	kindParam := params.GetString("kind", "all")
+	showValid := params.GetBool("showValid", false)
+
+	kinds := []string{"ServiceMonitor", "PodMonitor", "PrometheusRule"}
+	if kindParam != "" && kindParam != "all" {
+		if _, ok := selectorFields[kindParam]; !ok {
+			return api.NewToolCallResult("", fmt.Errorf("invalid kind %q, expected ServiceMonitor, PodMonitor, PrometheusRule or all", kindParam)), nil
+		}
+		kinds = []string{kindParam}
+	}
+
+	files := params.MustGatherProvider.Files()
+	v := &monitorValidator{
+		params:     params,
+		pools:      make(map[string]map[string]poolTargets),
+		ruleFiles:  make(map[string][]RuleGroup),
+		namespaces: make(map[string]map[string]string),
+		missingNS:  make(map[string]bool),
+	}
+	v.loadInstances(files)
+
+	output := "Monitor and Rule Validation\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	if len(v.instances) == 0 {
+		output += "Prometheus: no Prometheus resources or replica data gathered, selection not checked\n"
+	}
+	for _, instance := range v.instances {
+		line := fmt.Sprintf("%s: %s", instance.Kind, instance)
+		switch {
+		case instance.Synthetic:
+			line += fmt.Sprintf(" (resource not gathered, %s data)", strings.Join(instance.Replicas, ", "))
+		case len(instance.Replicas) > 0:
+			line += fmt.Sprintf(" (%s data)", strings.Join(instance.Replicas, ", "))
+		}
+		output += line + "\n"
+	}
+	if !v.rulesLoaded {
+		output += "rules.json: not gathered, loaded rules not compared\n"
+	}
+
+	checked := make([]string, 0, len(kinds))
+	sections := ""
+	var withErrors, withWarnings, valid int
+	for _, kind := range kinds {
+		list, err := params.MustGatherProvider.ListResources(params.Context, parseGVK("monitoring.coreos.com/v1", kind), "", api.ListOptions{})
+		if err != nil {
+			output += fmt.Sprintf("⚠ Failed to list %s resources: %v\n", kind, err)
+			continue
+		}
+
+		results := make([]validationResult, 0, len(list.Items))
+		for i := range list.Items {
+			obj := &list.Items[i]
+			if nsFilter != "" && !strings.Contains(strings.ToLower(obj.GetNamespace()), strings.ToLower(nsFilter)) {
+				continue
+			}
+			switch kind {
+			case "ServiceMonitor":
+				results = append(results, v.validateServiceMonitor(obj))
+			case "PodMonitor":
+				results = append(results, v.validatePodMonitor(obj))
+			case "PrometheusRule":
+				results = append(results, v.validatePrometheusRule(obj))
+			}
+		}
+		sort.Slice(results, func(i, j int) bool {
+			if results[i].Namespace != results[j].Namespace {
+				return results[i].Namespace < results[j].Namespace
+			}
+			return results[i].Name < results[j].Name
+		})
+		checked = append(checked, fmt.Sprintf("%d %ss", len(results), kind))
+
+		sections += fmt.Sprintf("%ss (%d)\n", kind, len(results))
+		sections += strings.Repeat("-", 80) + "\n"
+		hidden := 0
+		for i := range results {
+			result := &results[i]
+			switch result.symbol() {
+			case "✗":
+				withErrors++
+			case "⚠":
+				withWarnings++
+			default:
+				valid++
+				if !showValid {
+					hidden++
+					continue
+				}
+			}
+			sections += formatValidationResult(result)
+		}
+		if len(results) == 0 {
+			sections += fmt.Sprintf("No %s resources found.\n", kind)
+		} else if hidden > 0 {
+			sections += fmt.Sprintf("✓ %d valid, not shown (set showValid to list them)\n", hidden)
+		}
+		sections += "\n"
+	}
+
+	output += fmt.Sprintf("Checked: %s\n", strings.Join(checked, ", "))
+	output += fmt.Sprintf("Result: %d with errors, %d with warnings, %d valid\n\n", withErrors, withWarnings, valid)
+	output += sections
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// loadInstances reads the Prometheus and ThanosRuler resources with the
+// targets and rules gathered for their replicas
+func (v *monitorValidator) loadInstances(files api.GatherFS) {
+	replicas := listPrometheusReplicas(files)
+	claimed := make(map[string]bool)
+	for _, kind := range []string{"Prometheus", "ThanosRuler"} {
+		list, err := v.params.MustGatherProvider.ListResources(v.params.Context, parseGVK("monitoring.coreos.com/v1", kind), "", api.ListOptions{})
+		if err != nil {
+			continue
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			spec, _, _ := unstructured.NestedMap(obj.Object, "spec")
+			if spec == nil {
+				spec = make(map[string]interface{})
+			}
+			instance := monitoringInstance{Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName(), Spec: spec}
+			if kind == "Prometheus" {
+				for _, replica := range replicas {
+					if strings.HasPrefix(replica, "prometheus-"+obj.GetName()+"-") {
+						instance.Replicas = append(instance.Replicas, replica)
+						claimed[replica] = true
+					}
+				}
+			}
+			v.instances = append(v.instances, instance)
+		}
+	}
+
+	// Replica data without its Prometheus resource, e.g. a gather without CRs
+	unclaimed := make([]string, 0)
+	for _, replica := range replicas {
+		if !claimed[replica] {
+			unclaimed = append(unclaimed, replica)
+		}
+	}
+	if len(unclaimed) > 0 {
+		name := strings.TrimPrefix(unclaimed[0], "prometheus-")
+		if i := strings.LastIndex(name, "-"); i > 0 {
+			name = name[:i]
+		}
+		v.instances = append(v.instances, monitoringInstance{Kind: "Prometheus", Namespace: "openshift-monitoring", Name: name, Replicas: unclaimed, Synthetic: true})
+	}
+	sort.SliceStable(v.instances, func(i, j int) bool {
+		return len(v.instances[i].Replicas) > len(v.instances[j].Replicas)
+	})
+
+	// Replicas scrape the same targets, keep the fuller view of each pool
+	for _, instance := range v.instances {
+		if len(instance.Replicas) == 0 {
+			continue
+		}
+		pools := make(map[string]poolTargets)
+		for _, replica := range instance.Replicas {
+			var targetsAPIResp ActiveTargetsAPIResponse
+			if err := readPrometheusJSON(files, getPrometheusReplicaPath(replica), "active-targets.json", &targetsAPIResp); err != nil {
+				continue
+			}
+			counts := make(map[string]poolTargets)
+			for _, target := range targetsAPIResp.Data.ActiveTargets {
+				count := counts[target.ScrapePool]
+				count.Total++
+				if target.Health == "up" {
+					count.Up++
+				}
+				counts[target.ScrapePool] = count
+			}
+			for pool, count := range counts {
+				if count.Total > pools[pool].Total {
+					pools[pool] = count
+				}
+			}
+		}
+		if len(pools) > 0 {
+			v.pools[instance.String()] = pools
+		}
+	}
+
+	var rulesAPIResp RuleGroupsAPIResponse
+	if err := readJSON(files, path.Join(getPrometheusCommonPath(), "rules.json"), &rulesAPIResp); err == nil {
+		v.rulesLoaded = true
+		for _, group := range rulesAPIResp.Data.Groups {
+			base := path.Base(group.File)
+			v.ruleFiles[base] = append(v.ruleFiles[base], group)
+		}
+	}
+}
+
+// namespaceLabels returns the labels of a namespace, noting namespaces missing from the gather
+func (v *monitorValidator) namespaceLabels(namespace string) map[string]string {
+	if nsLabels, ok := v.namespaces[namespace]; ok {
+		return nsLabels
+	}
+	nsLabels, gathered := toolutil.NamespaceLabels(v.params, namespace)
+	if !gathered {
+		v.missingNS[namespace] = true
+	}
+	v.namespaces[namespace] = nsLabels
+	return nsLabels
+}
+
+// selects reports whether an instance picks up the resource, or why not. A null
+// resource selector matches nothing, a null namespace selector only the
+// instance's own namespace, and an empty selector matches everything
+func (v *monitorValidator) selects(instance monitoringInstance, obj *unstructured.Unstructured) (bool, string) {
+	kind := obj.GetKind()
+	if instance.Kind == "ThanosRuler" && kind != "PrometheusRule" {
+		return false, ""
+	}
+	if instance.Synthetic {
+		return true, ""
+	}
+	fields := selectorFields[kind]
+
+	selector, err := toolutil.LabelSelector(instance.Spec, fields[0])
+	switch {
+	case err != nil:
+		return false, fmt.Sprintf("invalid %s: %v", fields[0], err)
+	case selector == nil:
+		return false, fields[0] + " is unset and selects nothing"
+	case !selector.Matches(k8slabels.Set(obj.GetLabels())):
+		return false, fmt.Sprintf("%s {%s} does not match the labels {%s}", fields[0], selector, k8slabels.Set(obj.GetLabels()))
+	}
+
+	nsSelector, err := toolutil.LabelSelector(instance.Spec, fields[1])
+	namespace := obj.GetNamespace()
+	switch {
+	case err != nil:
+		return false, fmt.Sprintf("invalid %s: %v", fields[1], err)
+	case nsSelector == nil:
+		if namespace != instance.Namespace {
+			return false, fmt.Sprintf("%s is unset, so only %s is watched", fields[1], instance.Namespace)
+		}
+	case !nsSelector.Matches(k8slabels.Set(v.namespaceLabels(namespace))):
+		reason := fmt.Sprintf("%s {%s} does not match namespace %s", fields[1], nsSelector, namespace)
+		if v.missingNS[namespace] {
+			reason += " (namespace not in gather, its labels are unknown)"
+		}
+		return false, reason
+	}
+	return true, ""
+}
+
+// checkSelection records which instances pick up the resource and returns them
+func (v *monitorValidator) checkSelection(result *validationResult, obj *unstructured.Unstructured) []monitoringInstance {
+	if len(v.instances) == 0 {
+		return nil
+	}
+	selecting := make([]monitoringInstance, 0)
+	reasons := make([]string, 0)
+	for _, instance := range v.instances {
+		ok, reason := v.selects(instance, obj)
+		if ok {
+			selecting = append(selecting, instance)
+			result.Summary = append(result.Summary, fmt.Sprintf("selected by %s %s", instance.Kind, instance))
+		} else if reason != "" {
+			reasons = append(reasons, fmt.Sprintf("%s %s: %s", instance.Kind, instance, reason))
+		}
+	}
+	if len(selecting) == 0 {
+		result.Errors = append(result.Errors, "not selected by any Prometheus, it is ignored")
+		result.Details = append(result.Details, reasons...)
+	}
+	return selecting
+}
+
+func (v *monitorValidator) validateServiceMonitor(monitor *unstructured.Unstructured) validationResult {
+	result := validationResult{Kind: "ServiceMonitor", Namespace: monitor.GetNamespace(), Name: monitor.GetName()}
+	selecting := v.checkSelection(&result, monitor)
+
+	services, gathered, scope, ok := v.selectedObjects(&result, monitor, "Service")
+	if !ok {
+		return result
+	}
+
+	endpoints, _, _ := unstructured.NestedSlice(monitor.Object, "spec", "endpoints")
+	if len(endpoints) == 0 {
+		result.Errors = append(result.Errors, "spec.endpoints is empty, nothing is scraped")
+	}
+	for i, endpoint := range endpoints {
+		endpointMap, _ := endpoint.(map[string]interface{})
+		label := fmt.Sprintf("endpoint %d", i)
+		port, _, _ := unstructured.NestedString(endpointMap, "port")
+		targetPort, hasTargetPort := endpointMap["targetPort"]
+		switch {
+		case !gathered:
+		case port != "":
+			v.checkServicePort(&result, label, port, services)
+		case hasTargetPort:
+			result.Details = append(result.Details, fmt.Sprintf("%s: targetPort %v is not checked, it names a container port", label, targetPort))
+		default:
+			result.Errors = append(result.Errors, label+" sets neither port nor targetPort")
+		}
+		v.checkTargets(&result, label, fmt.Sprintf("serviceMonitor/%s/%s/%d", monitor.GetNamespace(), monitor.GetName(), i), selecting)
+	}
+	if gathered {
+		names := make([]string, 0, len(services))
+		for _, service := range services {
+			names = append(names, service.GetName())
+		}
+		result.Details = append(result.Details, fmt.Sprintf("Services in %s: %s", scope, strings.Join(names, ", ")))
+	}
+	return result
+}
+
+func (v *monitorValidator) validatePodMonitor(monitor *unstructured.Unstructured) validationResult {
+	result := validationResult{Kind: "PodMonitor", Namespace: monitor.GetNamespace(), Name: monitor.GetName()}
+	selecting := v.checkSelection(&result, monitor)
+
+	pods, gathered, scope, ok := v.selectedObjects(&result, monitor, "Pod")
+	if !ok {
+		return result
+	}
+
+	// Container ports of the selected pods
+	portNames := make(map[string]bool)
+	portNumbers := make(map[int64]bool)
+	for _, pod := range pods {
+		containers, _, _ := unstructured.NestedSlice(pod.Object, "spec", "containers")
+		for _, container := range containers {
+			containerMap, _ := container.(map[string]interface{})
+			ports, _, _ := unstructured.NestedSlice(containerMap, "ports")
+			for _, port := range ports {
+				portMap, _ := port.(map[string]interface{})
+				if name, _, _ := unstructured.NestedString(portMap, "name"); name != "" {
+					portNames[name] = true
+				}
+				if number, found, _ := unstructured.NestedInt64(portMap, "containerPort"); found {
+					portNumbers[number] = true
+				}
+			}
+		}
+	}
+
+	endpoints, _, _ := unstructured.NestedSlice(monitor.Object, "spec", "podMetricsEndpoints")
+	if len(endpoints) == 0 {
+		result.Errors = append(result.Errors, "spec.podMetricsEndpoints is empty, nothing is scraped")
+	}
+	for i, endpoint := range endpoints {
+		endpointMap, _ := endpoint.(map[string]interface{})
+		label := fmt.Sprintf("endpoint %d", i)
+		port, _, _ := unstructured.NestedString(endpointMap, "port")
+		portNumber, hasPortNumber, _ := unstructured.NestedInt64(endpointMap, "portNumber")
+		switch {
+		case !gathered:
+		case port != "":
+			if !portNames[port] {
+				result.Errors = append(result.Errors, fmt.Sprintf("%s: port %q is not a container port of the selected pods (named ports: %s)",
+					label, port, valueOr(strings.Join(sortedPools(portNames), ", "), "none")))
+			}
+		case hasPortNumber:
+			if !portNumbers[portNumber] {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: portNumber %d is not declared by the selected pods", label, portNumber))
+			}
+		default:
+			if _, found := endpointMap["targetPort"]; !found {
+				result.Errors = append(result.Errors, label+" sets neither port nor portNumber")
+			}
+		}
+		v.checkTargets(&result, label, fmt.Sprintf("podMonitor/%s/%s/%d", monitor.GetNamespace(), monitor.GetName(), i), selecting)
+	}
+	if gathered {
+		ready := 0
+		for _, pod := range pods {
+			if symbol, _ := objectStatus(pod); symbol == "✓" {
+				ready++
+			}
+		}
+		result.Details = append(result.Details, fmt.Sprintf("Pods in %s: %d selected, %d running and ready", scope, len(pods), ready))
+	}
+	return result
+}
+
+// selectedObjects lists the Services or Pods a monitor's selector and
+// namespaceSelector pick. It returns false when the monitor can't select
+// anything, and gathered=false when the namespaces have no such objects in
+// the gather to check against
+func (v *monitorValidator) selectedObjects(result *validationResult, monitor *unstructured.Unstructured, kind string) ([]*unstructured.Unstructured, bool, string, bool) {
+	selector, err := toolutil.LabelSelector(monitor.Object, "spec", "selector")
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("invalid selector: %v", err))
+		return nil, false, "", false
+	}
+	if selector == nil {
+		selector = k8slabels.Everything()
+	}
+
+	namespaces := []string{monitor.GetNamespace()}
+	scope := monitor.GetNamespace()
+	if anyNamespace, _, _ := unstructured.NestedBool(monitor.Object, "spec", "namespaceSelector", "any"); anyNamespace {
+		namespaces, scope = []string{""}, "all namespaces"
+	} else if names, _, _ := unstructured.NestedStringSlice(monitor.Object, "spec", "namespaceSelector", "matchNames"); len(names) > 0 {
+		namespaces, scope = names, strings.Join(names, ", ")
+	}
+
+	matched := make([]*unstructured.Unstructured, 0)
+	total := 0
+	for _, namespace := range namespaces {
+		list, err := v.params.MustGatherProvider.ListResources(v.params.Context, parseGVK("v1", kind), namespace, api.ListOptions{})
+		if err != nil {
+			continue
+		}
+		total += len(list.Items)
+		for i := range list.Items {
+			if selector.Matches(k8slabels.Set(list.Items[i].GetLabels())) {
+				matched = append(matched, &list.Items[i])
+			}
+		}
+	}
+
+	if total == 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("no %ss gathered in %s, selector and ports not checked", kind, scope))
+		return nil, false, scope, true
+	}
+	if len(matched) == 0 {
+		result.Errors = append(result.Errors, fmt.Sprintf("selector {%s} matches none of the %d %ss in %s", selector, total, kind, scope))
+		return nil, true, scope, false
+	}
+	return matched, true, scope, true
+}
+
+// checkServicePort checks that a named port exists on the selected Services
+// and resolves to ready Endpoints addresses
+func (v *monitorValidator) checkServicePort(result *validationResult, label, port string, services []*unstructured.Unstructured) {
+	available := make(map[string]bool)
+	withPort := make([]*unstructured.Unstructured, 0, len(services))
+	for _, service := range services {
+		ports, _, _ := unstructured.NestedSlice(service.Object, "spec", "ports")
+		for _, servicePort := range ports {
+			portMap, _ := servicePort.(map[string]interface{})
+			name, _, _ := unstructured.NestedString(portMap, "name")
+			available[name] = true
+			if name == port {
+				withPort = append(withPort, service)
+			}
+		}
+	}
+	if len(withPort) == 0 {
+		delete(available, "")
+		result.Errors = append(result.Errors, fmt.Sprintf("%s: port %q is not a port name of the selected Services (ports: %s)",
+			label, port, valueOr(strings.Join(sortedPools(available), ", "), "unnamed only")))
+		return
+	}
+
+	for _, service := range withPort {
+		endpoints, err := v.params.MustGatherProvider.GetResource(v.params.Context, parseGVK("v1", "Endpoints"), service.GetNamespace(), service.GetName())
+		if err != nil {
+			result.Details = append(result.Details, fmt.Sprintf("%s: Endpoints %s not in gather", label, service.GetName()))
+			continue
+		}
+		ready, notReady, otherPorts := 0, 0, 0
+		subsets, _, _ := unstructured.NestedSlice(endpoints.Object, "subsets")
+		for _, subset := range subsets {
+			subsetMap, _ := subset.(map[string]interface{})
+			addresses, _, _ := unstructured.NestedSlice(subsetMap, "addresses")
+			notReadyAddresses, _, _ := unstructured.NestedSlice(subsetMap, "notReadyAddresses")
+			if !endpointsHavePort(subsetMap, port) {
+				otherPorts += len(addresses) + len(notReadyAddresses)
+				continue
+			}
+			ready += len(addresses)
+			notReady += len(notReadyAddresses)
+		}
+		switch {
+		case ready > 0:
+			result.Details = append(result.Details, fmt.Sprintf("%s: port %s on %s has %d ready addresses", label, port, service.GetName(), ready))
+			if notReady > 0 {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %d addresses of %s are not ready", label, notReady, service.GetName()))
+			}
+		case notReady > 0:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: port %s on %s has no ready addresses (%d not ready)", label, port, service.GetName(), notReady))
+		case otherPorts > 0:
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: port %s on %s has no endpoints while other ports do, its targetPort names no container port of the pods", label, port, service.GetName()))
+		default:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %s has no endpoints, its selector matches no ready pods", label, service.GetName()))
+		}
+	}
+}
+
+// endpointsHavePort reports whether an Endpoints subset exposes the named port
+func endpointsHavePort(subset map[string]interface{}, port string) bool {
+	ports, _, _ := unstructured.NestedSlice(subset, "ports")
+	for _, endpointPort := range ports {
+		portMap, _ := endpointPort.(map[string]interface{})
+		if name, _, _ := unstructured.NestedString(portMap, "name"); name == port {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTargets compares a monitor endpoint with the active targets of the
+// instances selecting it
+func (v *monitorValidator) checkTargets(result *validationResult, label, pool string, selecting []monitoringInstance) {
+	for _, instance := range selecting {
+		pools, ok := v.pools[instance.String()]
+		if !ok {
+			continue
+		}
+		count, found := pools[pool]
+		switch {
+		case !found && len(result.Errors) == 0:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: no active targets in %s, relabelings drop every target or the configuration is not reloaded yet", label, instance))
+		case !found:
+			result.Details = append(result.Details, fmt.Sprintf("%s: no active targets in %s", label, instance))
+		case count.Up < count.Total:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %d of %d targets down in %s, see monitoring_prometheus_targets with mode clusters", label, count.Total-count.Up, count.Total, instance))
+		default:
+			result.Summary = append(result.Summary, fmt.Sprintf("%s: %d targets up", label, count.Total))
+		}
+	}
+}
+
+func (v *monitorValidator) validatePrometheusRule(rule *unstructured.Unstructured) validationResult {
+	result := validationResult{Kind: "PrometheusRule", Namespace: rule.GetNamespace(), Name: rule.GetName()}
+	selecting := v.checkSelection(&result, rule)
+
+	// The operator rejects the whole resource when one rule is invalid
+	type specGroup struct {
+		Name  string
+		Rules []string
+	}
+	groups := make([]specGroup, 0)
+	seen := make(map[string]bool)
+	invalid := 0
+	specGroups, _, _ := unstructured.NestedSlice(rule.Object, "spec", "groups")
+	for _, group := range specGroups {
+		groupMap, _ := group.(map[string]interface{})
+		name, _, _ := unstructured.NestedString(groupMap, "name")
+		if seen[name] {
+			result.Errors = append(result.Errors, fmt.Sprintf("group %q is defined twice", name))
+			invalid++
+		}
+		seen[name] = true
+		current := specGroup{Name: name}
+		rules, _, _ := unstructured.NestedSlice(groupMap, "rules")
+		for i, specRule := range rules {
+			ruleMap, _ := specRule.(map[string]interface{})
+			alert, _, _ := unstructured.NestedString(ruleMap, "alert")
+			record, _, _ := unstructured.NestedString(ruleMap, "record")
+			ruleName := valueOr(alert, record)
+			if ruleName == "" || (alert != "" && record != "") {
+				result.Errors = append(result.Errors, fmt.Sprintf("group %q rule %d must set exactly one of alert and record", name, i))
+				invalid++
+				continue
+			}
+			current.Rules = append(current.Rules, ruleName)
+			if _, err := parser.ParseExpr(fmt.Sprint(ruleMap["expr"])); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("group %q %s: invalid expression: %v", name, ruleName, err))
+				invalid++
+			}
+		}
+		groups = append(groups, current)
+	}
+	if len(groups) == 0 {
+		result.Warnings = append(result.Warnings, "spec.groups is empty")
+	}
+
+	for _, instance := range selecting {
+		if instance.Kind != "Prometheus" || len(instance.Replicas) == 0 || !v.rulesLoaded {
+			result.Details = append(result.Details, fmt.Sprintf("evaluated by %s %s, its loaded rules are not in the gather", instance.Kind, instance))
+			continue
+		}
+		loaded := v.loadedGroups(rule)
+		if len(loaded) == 0 {
+			if invalid > 0 {
+				result.Errors = append(result.Errors, fmt.Sprintf("not loaded by %s, the operator rejected it for the invalid rules above", instance))
+			} else {
+				result.Errors = append(result.Errors, fmt.Sprintf("not loaded by %s, check the prometheus-operator logs for why it was rejected", instance))
+			}
+			continue
+		}
+
+		loadedRules := 0
+		for _, group := range groups {
+			loadedGroup, found := loaded[group.Name]
+			if !found {
+				result.Errors = append(result.Errors, fmt.Sprintf("group %q (%d rules) is not loaded by %s", group.Name, len(group.Rules), instance))
+				continue
+			}
+			names := make(map[string]bool)
+			for _, loadedRule := range loadedGroup.Rules {
+				names[loadedRule.Name] = true
+				if loadedRule.Health == "err" || loadedRule.LastError != "" {
+					result.Errors = append(result.Errors, fmt.Sprintf("group %q %s fails to evaluate: %s", group.Name, loadedRule.Name, valueOr(loadedRule.LastError, "health err")))
+				}
+			}
+			for _, name := range group.Rules {
+				if names[name] {
+					loadedRules++
+				} else {
+					result.Errors = append(result.Errors, fmt.Sprintf("group %q %s is not loaded by %s", group.Name, name, instance))
+				}
+			}
+		}
+		result.Summary = append(result.Summary, fmt.Sprintf("%d rules loaded", loadedRules))
+	}
+	return result
+}
+
+// loadedGroups returns the rules.json groups generated from a PrometheusRule.
+// The operator writes each one to <namespace>-<name>-<uid>.yaml
+func (v *monitorValidator) loadedGroups(rule *unstructured.Unstructured) map[string]RuleGroup {
+	prefix := rule.GetNamespace() + "-" + rule.GetName()
+	loaded := make(map[string]RuleGroup)
+	for base, groups := range v.ruleFiles {
+		suffix, found := strings.CutPrefix(base, prefix)
+		if !found || (suffix != ".yaml" && !(strings.HasPrefix(suffix, "-") && ruleFileUID.MatchString(suffix[1:]))) {
+			continue
+		}
+		for _, group := range groups {
+			loaded[group.Name] = group
+		}
+	}
+	return loaded
+}
+
+func formatValidationResult(result *validationResult) string {
+	line := fmt.Sprintf("%s %s/%s", result.symbol(), result.Namespace, result.Name)
+	if len(result.Summary) > 0 {
+		line += " (" + strings.Join(result.Summary, ", ") + ")"
+	}
+	output := line + "\n"
+	for _, err := range result.Errors {
+		output += fmt.Sprintf("    ✗ %s\n", err)
+	}
+	for _, warning := range result.Warnings {
+		output += fmt.Sprintf("    ⚠ %s\n", warning)
+	}
+	for _, detail := range result.Details {
+		output += fmt.Sprintf("    • %s\n", detail)
+	}
+	return output
+}
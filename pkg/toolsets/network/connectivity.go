@@ -2,8 +2,7 @@ package network
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
+	"path"
 	"sort"
 	"strings"
 
@@ -39,21 +38,16 @@ func networkConnectivityTools() []api.ServerTool {
 func networkConnectivityCheck(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
 	statusFilter := params.GetString("status", "all")
 
-	// Find container directory
-	containerDir, err := findContainerDir(params.MustGatherProvider.GetMetadata().Path)
-	if err != nil {
-		containerDir = params.MustGatherProvider.GetMetadata().Path
-	}
-
-	connectivityFile := filepath.Join(containerDir, "pod_network_connectivity_check", "podnetworkconnectivitychecks.yaml")
+	files := params.MustGatherProvider.Files()
+	connectivityFile := path.Join("pod_network_connectivity_check", "podnetworkconnectivitychecks.yaml")
 
 	// Check if file exists
-	if _, err := os.Stat(connectivityFile); os.IsNotExist(err) {
+	if _, err := files.Stat(connectivityFile); err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("network connectivity check data not found")), nil
 	}
 
 	// Read and parse YAML
-	data, err := os.ReadFile(connectivityFile)
+	data, err := files.ReadFile(connectivityFile)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to read connectivity check data: %w", err)), nil
 	}
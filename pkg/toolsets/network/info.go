@@ -3,8 +3,7 @@ package network
 import (
 	"bufio"
 	"fmt"
-	"os"
-	"path/filepath"
+	"path"
 	"strconv"
 	"strings"
 
@@ -38,21 +37,16 @@ func networkInfoTools() []api.ServerTool {
 }
 
 func networkScaleGet(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	// Find container directory
-	containerDir, err := findContainerDir(params.MustGatherProvider.GetMetadata().Path)
-	if err != nil {
-		containerDir = params.MustGatherProvider.GetMetadata().Path
-	}
-
-	scaleFile := filepath.Join(containerDir, "network_logs", "cluster_scale")
+	files := params.MustGatherProvider.Files()
+	scaleFile := path.Join("network_logs", "cluster_scale")
 
 	// Check if file exists
-	if _, err := os.Stat(scaleFile); os.IsNotExist(err) {
+	if _, err := files.Stat(scaleFile); err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("network scale data not found")), nil
 	}
 
 	// Read the file
-	data, err := os.ReadFile(scaleFile)
+	data, err := files.ReadFile(scaleFile)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to read network scale data: %w", err)), nil
 	}
@@ -74,21 +68,16 @@ func networkScaleGet(params api.ToolHandlerParams) (*api.ToolCallResult, error)
 }
 
 func networkOVNResources(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
-	// Find container directory
-	containerDir, err := findContainerDir(params.MustGatherProvider.GetMetadata().Path)
-	if err != nil {
-		containerDir = params.MustGatherProvider.GetMetadata().Path
-	}
-
-	ovnFile := filepath.Join(containerDir, "network_logs", "ovn_kubernetes_top_pods")
+	files := params.MustGatherProvider.Files()
+	ovnFile := path.Join("network_logs", "ovn_kubernetes_top_pods")
 
 	// Check if file exists
-	if _, err := os.Stat(ovnFile); os.IsNotExist(err) {
+	if _, err := files.Stat(ovnFile); err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("OVN resource data not found")), nil
 	}
 
 	// Read the file
-	file, err := os.Open(ovnFile)
+	file, err := files.Open(ovnFile)
 	if err != nil {
 		return api.NewToolCallResult("", fmt.Errorf("failed to read OVN resource data: %w", err)), nil
 	}
@@ -185,24 +174,6 @@ func networkOVNResources(params api.ToolHandlerParams) (*api.ToolCallResult, err
 
 // Helper functions
 
-func findContainerDir(basePath string) (string, error) {
-	entries, err := os.ReadDir(basePath)
-	if err != nil {
-		return "", err
-	}
-
-	for _, entry := range entries {
-		if entry.IsDir() {
-			name := entry.Name()
-			if strings.HasPrefix(name, "quay") || strings.Contains(name, "sha256") {
-				return filepath.Join(basePath, name), nil
-			}
-		}
-	}
-
-	return "", fmt.Errorf("container directory not found")
-}
-
 func truncatePodName(name string, maxLen int) string {
 	if len(name) <= maxLen {
 		return name
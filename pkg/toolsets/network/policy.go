@@ -0,0 +1,703 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"github.com/openshift/must-gather-mcp-server/pkg/toolsets/internal/toolutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// policyEndpoint is one side of a flow: a pod from the gather or an address
+// outside of it
+type policyEndpoint struct {
+	Pod       *unstructured.Unstructured
+	Namespace string
+	Labels    map[string]string
+	IP        string
+}
+
+func (e policyEndpoint) String() string {
+	if e.Pod == nil {
+		return e.IP
+	}
+	return e.Namespace + "/" + e.Pod.GetName()
+}
+
+// policyTarget is the port a flow is sent to; an empty port means any port
+type policyTarget struct {
+	Port     string
+	Number   int64
+	Protocol string
+}
+
+func (t policyTarget) String() string {
+	if t.Port == "" {
+		return "any port/" + t.Protocol
+	}
+	if t.Number > 0 && t.Port != strconv.FormatInt(t.Number, 10) {
+		return fmt.Sprintf("%s (%d)/%s", t.Port, t.Number, t.Protocol)
+	}
+	return t.Port + "/" + t.Protocol
+}
+
+// policyDecision is what the NetworkPolicies selecting a pod decide for one direction
+type policyDecision struct {
+	Isolated  bool
+	Allowed   bool
+	Selecting []string
+	Lines     []string
+}
+
+// policyEvaluator caches namespace labels across evaluations
+type policyEvaluator struct {
+	params     api.ToolHandlerParams
+	policies   []*unstructured.Unstructured
+	namespaces map[string]map[string]string
+}
+
+func networkPolicyTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "network_policy_check",
+				Description: "Evaluate NetworkPolicies for a flow from a source pod to a destination pod and port: egress policies of the source and ingress policies of the destination, with podSelector, namespaceSelector, ipBlock and ports, explaining which policy allows or denies it. Mode 'isolated' lists pods whose ingress or egress is denied entirely by policy",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"mode": {
+							Type:        "string",
+							Description: "check: evaluate a flow (default), isolated: list pods fully isolated by policy",
+							Enum:        []interface{}{"check", "isolated"},
+						},
+						"source": {
+							Type:        "string",
+							Description: "Source as namespace/pod, or an IP address outside the gather (required for check mode)",
+						},
+						"destination": {
+							Type:        "string",
+							Description: "Destination as namespace/pod, or an IP address outside the gather (required for check mode)",
+						},
+						"port": {
+							Type:        "string",
+							Description: "Destination port number or named container port, e.g. 8080 or metrics (default: any port)",
+						},
+						"protocol": {
+							Type:        "string",
+							Description: "Protocol of the flow (default: TCP)",
+							Enum:        []interface{}{"TCP", "UDP", "SCTP"},
+						},
+						"namespace": {
+							Type:        "string",
+							Description: "Filter pods by namespace in isolated mode (partial match)",
+						},
+						"limit": {
+							Type:        "integer",
+							Description: "Maximum pods to list in isolated mode (default: 100)",
+						},
+					},
+				},
+			},
+			Handler: networkPolicyCheck,
+		},
+	}
+}
+
+func networkPolicyCheck(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	mode := params.GetString("mode", "check")
+
+	list, err := params.MustGatherProvider.ListResources(params.Context, parseGVK("networking.k8s.io/v1", "NetworkPolicy"), "", api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list NetworkPolicy resources: %w", err)), nil
+	}
+	evaluator := &policyEvaluator{params: params, namespaces: make(map[string]map[string]string)}
+	for i := range list.Items {
+		evaluator.policies = append(evaluator.policies, &list.Items[i])
+	}
+	sort.Slice(evaluator.policies, func(i, j int) bool {
+		if evaluator.policies[i].GetNamespace() != evaluator.policies[j].GetNamespace() {
+			return evaluator.policies[i].GetNamespace() < evaluator.policies[j].GetNamespace()
+		}
+		return evaluator.policies[i].GetName() < evaluator.policies[j].GetName()
+	})
+
+	switch mode {
+	case "check":
+		return evaluator.checkFlow()
+	case "isolated":
+		return evaluator.isolatedPods()
+	}
+	return api.NewToolCallResult("", fmt.Errorf("invalid mode %q, expected check or isolated", mode)), nil
+}
+
+func (e *policyEvaluator) checkFlow() (*api.ToolCallResult, error) {
+	params := e.params
+	protocol := strings.ToUpper(params.GetString("protocol", "TCP"))
+
+	source, err := e.resolveEndpoint(params.GetString("source", ""), "source")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	destination, err := e.resolveEndpoint(params.GetString("destination", ""), "destination")
+	if err != nil {
+		return api.NewToolCallResult("", err), nil
+	}
+	if source.Pod == nil && destination.Pod == nil {
+		return api.NewToolCallResult("", fmt.Errorf("source or destination must be a pod in the gather")), nil
+	}
+
+	target := policyTarget{Port: params.GetString("port", ""), Protocol: protocol}
+	if port, found := strings.CutSuffix(target.Port, "/"+protocol); found {
+		target.Port = port
+	}
+	if number, err := strconv.ParseInt(target.Port, 10, 64); err == nil {
+		target.Number = number
+	} else if target.Port != "" {
+		target.Number = containerPort(destination.Pod, target.Port, protocol)
+		if target.Number == 0 {
+			return api.NewToolCallResult("", fmt.Errorf("named port %q is not a %s container port of %s", target.Port, protocol, destination)), nil
+		}
+	}
+
+	output := "Network Policy Check\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+	output += fmt.Sprintf("Source: %s\n", describeEndpoint(source))
+	output += fmt.Sprintf("Destination: %s\n", describeEndpoint(destination))
+	output += fmt.Sprintf("Port: %s\n", target)
+	output += fmt.Sprintf("NetworkPolicies: %d in the gather\n", len(e.policies))
+	output += e.adminPolicyNote()
+	output += "\n"
+
+	verdict, blocked := true, make([]string, 0)
+	for _, direction := range []string{"Egress", "Ingress"} {
+		subject, peer := source, destination
+		title := fmt.Sprintf("Egress from %s", source)
+		if direction == "Ingress" {
+			subject, peer = destination, source
+			title = fmt.Sprintf("Ingress to %s", destination)
+		}
+		output += title + "\n"
+		output += strings.Repeat("-", 80) + "\n"
+
+		switch {
+		case subject.Pod == nil:
+			output += fmt.Sprintf("Not evaluated: %s is not a pod in the gather\n\n", subject)
+			continue
+		case isHostNetwork(subject.Pod):
+			output += "✓ Allowed: host-network pod, NetworkPolicies do not apply to it\n\n"
+			continue
+		}
+
+		decision := e.evaluate(subject, peer, direction, target)
+		if !decision.Isolated {
+			output += fmt.Sprintf("✓ Allowed: no NetworkPolicy with policyType %s selects %s\n\n", direction, subject)
+			continue
+		}
+		output += fmt.Sprintf("Isolated by: %s\n", strings.Join(decision.Selecting, ", "))
+		for _, line := range decision.Lines {
+			output += line + "\n"
+		}
+		if decision.Allowed {
+			output += "Result: ✓ ALLOWED\n\n"
+		} else {
+			output += fmt.Sprintf("Result: ✗ DENIED, no %s rule of these policies matches the flow\n\n", strings.ToLower(direction))
+			verdict = false
+			blocked = append(blocked, strings.ToLower(title))
+		}
+	}
+
+	if verdict {
+		output += fmt.Sprintf("Verdict: ✓ ALLOWED, %s can reach %s on %s\n", source, destination, target)
+	} else {
+		output += fmt.Sprintf("Verdict: ✗ DENIED, blocked by %s\n", strings.Join(blocked, " and "))
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+func (e *policyEvaluator) isolatedPods() (*api.ToolCallResult, error) {
+	params := e.params
+	nsFilter := params.GetString("namespace", "")
+	limit := params.GetInt("limit", 100)
+
+	pods, err := params.MustGatherProvider.ListResources(params.Context, parseGVK("v1", "Pod"), "", api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list pods: %w", err)), nil
+	}
+
+	type isolatedPod struct {
+		Namespace string
+		Name      string
+		Symbol    string
+		Ingress   string
+		Egress    string
+	}
+	isolated := make([]isolatedPod, 0)
+	checked, ingressIsolated, egressIsolated := 0, 0, 0
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if nsFilter != "" && !strings.Contains(strings.ToLower(pod.GetNamespace()), strings.ToLower(nsFilter)) {
+			continue
+		}
+		if isHostNetwork(pod) || isFinished(pod) {
+			continue
+		}
+		checked++
+
+		subject := e.podEndpoint(pod)
+		entry := isolatedPod{Namespace: pod.GetNamespace(), Name: pod.GetName()}
+		denied := 0
+		for _, direction := range []string{"Ingress", "Egress"} {
+			state := "open"
+			selecting, rules := e.selectingPolicies(subject, direction)
+			if len(selecting) > 0 {
+				if direction == "Ingress" {
+					ingressIsolated++
+				} else {
+					egressIsolated++
+				}
+				state = fmt.Sprintf("restricted to %d rule(s) (%s)", rules, strings.Join(selecting, ", "))
+				if rules == 0 {
+					state = fmt.Sprintf("deny all (%s)", strings.Join(selecting, ", "))
+					denied++
+				}
+			}
+			if direction == "Ingress" {
+				entry.Ingress = state
+			} else {
+				entry.Egress = state
+			}
+		}
+		if denied == 0 {
+			continue
+		}
+		entry.Symbol = "⚠"
+		if denied == 2 {
+			entry.Symbol = "✗"
+		}
+		isolated = append(isolated, entry)
+	}
+
+	sort.Slice(isolated, func(i, j int) bool {
+		if isolated[i].Namespace != isolated[j].Namespace {
+			return isolated[i].Namespace < isolated[j].Namespace
+		}
+		return isolated[i].Name < isolated[j].Name
+	})
+
+	output := "Pods Isolated by NetworkPolicy\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+	output += fmt.Sprintf("NetworkPolicies: %d\n", len(e.policies))
+	output += e.adminPolicyNote()
+	output += fmt.Sprintf("Pods Checked: %d (host-network and finished pods skipped)\n", checked)
+	output += fmt.Sprintf("Isolated for Ingress: %d, for Egress: %d\n", ingressIsolated, egressIsolated)
+	output += fmt.Sprintf("Fully Isolated: %d pods with all ingress or egress denied\n\n", len(isolated))
+
+	if len(isolated) == 0 {
+		output += "No pods are fully isolated by NetworkPolicy.\n"
+		return api.NewToolCallResult(output, nil), nil
+	}
+
+	currentNS := ""
+	for i, pod := range isolated {
+		if limit > 0 && i >= limit {
+			output += fmt.Sprintf("\n... and %d more pods\n", len(isolated)-limit)
+			break
+		}
+		if pod.Namespace != currentNS {
+			if currentNS != "" {
+				output += "\n"
+			}
+			currentNS = pod.Namespace
+			output += fmt.Sprintf("Namespace: %s\n", currentNS)
+		}
+		output += fmt.Sprintf("  %s %s\n", pod.Symbol, pod.Name)
+		output += fmt.Sprintf("      Ingress: %s\n", pod.Ingress)
+		output += fmt.Sprintf("      Egress: %s\n", pod.Egress)
+	}
+	output += "\n✗ = ingress and egress denied, ⚠ = one direction denied\n"
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// resolveEndpoint parses namespace/pod or an IP, matching IPs to gathered pods
+func (e *policyEvaluator) resolveEndpoint(value, name string) (policyEndpoint, error) {
+	if value == "" {
+		return policyEndpoint{}, fmt.Errorf("%s is required in check mode, as namespace/pod or an IP address", name)
+	}
+	if ip := net.ParseIP(value); ip != nil {
+		pods, err := e.params.MustGatherProvider.ListResources(e.params.Context, parseGVK("v1", "Pod"), "", api.ListOptions{})
+		if err == nil {
+			for i := range pods.Items {
+				pod := &pods.Items[i]
+				if podIP, _, _ := unstructured.NestedString(pod.Object, "status", "podIP"); podIP == value && !isHostNetwork(pod) && !isFinished(pod) {
+					return e.podEndpoint(pod), nil
+				}
+			}
+		}
+		return policyEndpoint{IP: value}, nil
+	}
+	namespace, podName, found := strings.Cut(value, "/")
+	if !found || namespace == "" || podName == "" {
+		return policyEndpoint{}, fmt.Errorf("invalid %s %q, expected namespace/pod or an IP address", name, value)
+	}
+	pod, err := e.params.MustGatherProvider.GetResource(e.params.Context, parseGVK("v1", "Pod"), namespace, podName)
+	if err != nil {
+		return policyEndpoint{}, fmt.Errorf("%s pod %s/%s not found: %w", name, namespace, podName, err)
+	}
+	return e.podEndpoint(pod), nil
+}
+
+func (e *policyEvaluator) podEndpoint(pod *unstructured.Unstructured) policyEndpoint {
+	podIP, _, _ := unstructured.NestedString(pod.Object, "status", "podIP")
+	return policyEndpoint{Pod: pod, Namespace: pod.GetNamespace(), Labels: pod.GetLabels(), IP: podIP}
+}
+
+// namespaceLabels returns the labels of a namespace, cached per evaluation
+func (e *policyEvaluator) namespaceLabels(namespace string) map[string]string {
+	if nsLabels, ok := e.namespaces[namespace]; ok {
+		return nsLabels
+	}
+	nsLabels, _ := toolutil.NamespaceLabels(e.params, namespace)
+	e.namespaces[namespace] = nsLabels
+	return nsLabels
+}
+
+// adminPolicyNote warns about cluster-scoped policies that take precedence
+// over NetworkPolicies and are not evaluated
+func (e *policyEvaluator) adminPolicyNote() string {
+	note := ""
+	for _, kind := range []string{"AdminNetworkPolicy", "BaselineAdminNetworkPolicy"} {
+		list, err := e.params.MustGatherProvider.ListResources(e.params.Context, parseGVK("policy.networking.k8s.io/v1alpha1", kind), "", api.ListOptions{})
+		if err == nil && len(list.Items) > 0 {
+			note += fmt.Sprintf("⚠ %d %s resources exist and are not evaluated here\n", len(list.Items), kind)
+		}
+	}
+	return note
+}
+
+// selectingPolicies returns the policies isolating a pod in a direction and
+// how many rules they have for it
+func (e *policyEvaluator) selectingPolicies(subject policyEndpoint, direction string) ([]string, int) {
+	selecting := make([]string, 0)
+	rules := 0
+	for _, policy := range e.policies {
+		if !policySelects(policy, subject, direction) {
+			continue
+		}
+		selecting = append(selecting, policy.GetName())
+		policyRules, _, _ := unstructured.NestedSlice(policy.Object, "spec", strings.ToLower(direction))
+		rules += len(policyRules)
+	}
+	return selecting, rules
+}
+
+// evaluate decides a direction of the flow for the pod the policies select.
+// The pod is isolated when any policy of that type selects it, and the flow
+// is then allowed when a rule of any of those policies matches both the peer
+// and the port
+func (e *policyEvaluator) evaluate(subject, peer policyEndpoint, direction string, target policyTarget) policyDecision {
+	decision := policyDecision{}
+	peerField := "from"
+	if direction == "Egress" {
+		peerField = "to"
+	}
+	for _, policy := range e.policies {
+		if !policySelects(policy, subject, direction) {
+			continue
+		}
+		decision.Isolated = true
+		decision.Selecting = append(decision.Selecting, policy.GetName())
+
+		rules, _, _ := unstructured.NestedSlice(policy.Object, "spec", strings.ToLower(direction))
+		if len(rules) == 0 {
+			line := fmt.Sprintf("✗ %s: no %s rules, denies all", policy.GetName(), strings.ToLower(direction))
+			if direction == "Ingress" && !hasPolicyTypes(policy) {
+				line += " (policyTypes is unset, so the policy isolates ingress too)"
+			}
+			decision.Lines = append(decision.Lines, line)
+			continue
+		}
+		reasons := make([]string, 0, len(rules))
+		allowed := false
+		for i, rule := range rules {
+			ruleMap, _ := rule.(map[string]interface{})
+			peers, _, _ := unstructured.NestedSlice(ruleMap, peerField)
+			ports, _, _ := unstructured.NestedSlice(ruleMap, "ports")
+
+			peerMatch, peerDesc := e.matchPeers(peers, policy.GetNamespace(), peer)
+			portMatch, portDesc := matchPorts(ports, target, direction, subject, peer)
+			if peerMatch && portMatch {
+				decision.Allowed, allowed = true, true
+				decision.Lines = append(decision.Lines, fmt.Sprintf("✓ %s: rule %d allows %s %s on %s", policy.GetName(), i+1, peerField, peerDesc, portDesc))
+				break
+			}
+			switch {
+			case !peerMatch:
+				reasons = append(reasons, fmt.Sprintf("rule %d: %s", i+1, peerDesc))
+			default:
+				reasons = append(reasons, fmt.Sprintf("rule %d: %s", i+1, portDesc))
+			}
+		}
+		if !allowed {
+			decision.Lines = append(decision.Lines, fmt.Sprintf("✗ %s: no rule matches", policy.GetName()))
+			for _, reason := range reasons {
+				decision.Lines = append(decision.Lines, "    "+reason)
+			}
+		}
+	}
+	return decision
+}
+
+// matchPeers checks the from/to peers of a rule; an empty list matches everything
+func (e *policyEvaluator) matchPeers(peers []interface{}, policyNamespace string, peer policyEndpoint) (bool, string) {
+	if len(peers) == 0 {
+		return true, "all peers"
+	}
+	reasons := make([]string, 0, len(peers))
+	for _, p := range peers {
+		peerMap, _ := p.(map[string]interface{})
+		if ipBlock, found, _ := unstructured.NestedMap(peerMap, "ipBlock"); found {
+			match, reason := matchIPBlock(ipBlock, peer)
+			if match {
+				return true, reason
+			}
+			reasons = append(reasons, reason)
+			continue
+		}
+
+		podSelector, podErr := toolutil.LabelSelector(peerMap, "podSelector")
+		nsSelector, nsErr := toolutil.LabelSelector(peerMap, "namespaceSelector")
+		desc := describePeer(podSelector, nsSelector, policyNamespace)
+		switch {
+		case podErr != nil || nsErr != nil:
+			reasons = append(reasons, fmt.Sprintf("invalid selector in %s", desc))
+			continue
+		case peer.Pod == nil:
+			reasons = append(reasons, fmt.Sprintf("%s only matches pods, %s is not one", desc, peer))
+			continue
+		}
+
+		if nsSelector == nil {
+			if peer.Namespace != policyNamespace {
+				reasons = append(reasons, fmt.Sprintf("%s is limited to namespace %s", desc, policyNamespace))
+				continue
+			}
+		} else if !nsSelector.Matches(labels.Set(e.namespaceLabels(peer.Namespace))) {
+			reasons = append(reasons, fmt.Sprintf("%s does not match namespace %s", desc, peer.Namespace))
+			continue
+		}
+		if podSelector != nil && !podSelector.Matches(labels.Set(peer.Labels)) {
+			reasons = append(reasons, fmt.Sprintf("%s does not match pod labels {%s}", desc, labels.Set(peer.Labels)))
+			continue
+		}
+		return true, desc
+	}
+	return false, strings.Join(reasons, "; ")
+}
+
+// matchIPBlock checks an ipBlock peer; for pod IPs the result depends on the
+// network plugin, as ipBlock is meant for addresses outside the cluster
+func matchIPBlock(ipBlock map[string]interface{}, peer policyEndpoint) (bool, string) {
+	cidr, _, _ := unstructured.NestedString(ipBlock, "cidr")
+	except, _, _ := unstructured.NestedStringSlice(ipBlock, "except")
+	desc := "ipBlock " + cidr
+	if len(except) > 0 {
+		desc += " except " + strings.Join(except, ", ")
+	}
+	ip := net.ParseIP(peer.IP)
+	if ip == nil {
+		return false, fmt.Sprintf("%s: %s has no known IP", desc, peer)
+	}
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return false, fmt.Sprintf("%s: invalid cidr", desc)
+	}
+	if !network.Contains(ip) {
+		return false, fmt.Sprintf("%s does not contain %s", desc, peer.IP)
+	}
+	for _, excluded := range except {
+		if _, exceptNetwork, err := net.ParseCIDR(excluded); err == nil && exceptNetwork.Contains(ip) {
+			return false, fmt.Sprintf("%s excludes %s", desc, peer.IP)
+		}
+	}
+	if peer.Pod != nil {
+		return true, desc + " (contains the pod IP, whether ipBlock applies to pod IPs depends on the network plugin)"
+	}
+	return true, desc
+}
+
+// matchPorts checks the ports of a rule; an empty list matches every port.
+// Named ports refer to container ports of the destination pod
+func matchPorts(ports []interface{}, target policyTarget, direction string, subject, peer policyEndpoint) (bool, string) {
+	if len(ports) == 0 {
+		return true, "all ports"
+	}
+	destination := peer
+	if direction == "Ingress" {
+		destination = subject
+	}
+	allowed := make([]string, 0, len(ports))
+	for _, p := range ports {
+		portMap, _ := p.(map[string]interface{})
+		protocol, _, _ := unstructured.NestedString(portMap, "protocol")
+		protocol = valueOr(protocol, "TCP")
+		endPort, _, _ := unstructured.NestedInt64(portMap, "endPort")
+
+		var number int64
+		desc := "all ports"
+		switch value := portMap["port"].(type) {
+		case int64:
+			number, desc = value, strconv.FormatInt(value, 10)
+		case float64:
+			number, desc = int64(value), strconv.FormatInt(int64(value), 10)
+		case string:
+			desc = value
+			if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+				number = n
+			} else {
+				number = containerPort(destination.Pod, value, protocol)
+				if number > 0 {
+					desc = fmt.Sprintf("%s (%d)", value, number)
+				}
+			}
+		}
+		if endPort > 0 {
+			desc += fmt.Sprintf("-%d", endPort)
+		}
+		desc += "/" + protocol
+		allowed = append(allowed, desc)
+
+		if protocol != target.Protocol {
+			continue
+		}
+		if target.Port == "" {
+			return true, desc + " (no port given, allowed on this port only)"
+		}
+		if _, hasPort := portMap["port"]; !hasPort {
+			return true, desc
+		}
+		if number > 0 && (number == target.Number || (endPort > 0 && target.Number >= number && target.Number <= endPort)) {
+			return true, desc
+		}
+	}
+	return false, fmt.Sprintf("%s not in ports %s", target, strings.Join(allowed, ", "))
+}
+
+// policySelects reports whether a policy selects the pod for a direction.
+// Policies without policyTypes always cover Ingress, and Egress when they
+// have an egress section
+func policySelects(policy *unstructured.Unstructured, subject policyEndpoint, direction string) bool {
+	if subject.Pod == nil || policy.GetNamespace() != subject.Namespace {
+		return false
+	}
+	types, _, _ := unstructured.NestedStringSlice(policy.Object, "spec", "policyTypes")
+	if len(types) == 0 {
+		types = []string{"Ingress"}
+		if _, hasEgress, _ := unstructured.NestedFieldNoCopy(policy.Object, "spec", "egress"); hasEgress {
+			types = append(types, "Egress")
+		}
+	}
+	covered := false
+	for _, policyType := range types {
+		if policyType == direction {
+			covered = true
+		}
+	}
+	if !covered {
+		return false
+	}
+	selector, err := toolutil.LabelSelector(policy.Object, "spec", "podSelector")
+	if err != nil {
+		return false
+	}
+	return selector == nil || selector.Matches(labels.Set(subject.Labels))
+}
+
+func hasPolicyTypes(policy *unstructured.Unstructured) bool {
+	types, _, _ := unstructured.NestedStringSlice(policy.Object, "spec", "policyTypes")
+	return len(types) > 0
+}
+
+// containerPort resolves a named container port of a pod, 0 when not found
+func containerPort(pod *unstructured.Unstructured, name, protocol string) int64 {
+	if pod == nil {
+		return 0
+	}
+	containers, _, _ := unstructured.NestedSlice(pod.Object, "spec", "containers")
+	for _, container := range containers {
+		containerMap, _ := container.(map[string]interface{})
+		ports, _, _ := unstructured.NestedSlice(containerMap, "ports")
+		for _, port := range ports {
+			portMap, _ := port.(map[string]interface{})
+			portName, _, _ := unstructured.NestedString(portMap, "name")
+			portProtocol, _, _ := unstructured.NestedString(portMap, "protocol")
+			if portName == name && valueOr(portProtocol, "TCP") == protocol {
+				number, _, _ := unstructured.NestedInt64(portMap, "containerPort")
+				return number
+			}
+		}
+	}
+	return 0
+}
+
+func describePeer(podSelector, nsSelector labels.Selector, policyNamespace string) string {
+	parts := make([]string, 0, 2)
+	if nsSelector != nil {
+		parts = append(parts, fmt.Sprintf("namespaceSelector {%s}", nsSelector))
+	}
+	if podSelector != nil {
+		parts = append(parts, fmt.Sprintf("podSelector {%s}", podSelector))
+	}
+	if nsSelector == nil {
+		parts = append(parts, "in "+policyNamespace)
+	}
+	return strings.Join(parts, " ")
+}
+
+func describeEndpoint(endpoint policyEndpoint) string {
+	if endpoint.Pod == nil {
+		return endpoint.IP + " (not a pod in the gather)"
+	}
+	desc := endpoint.String()
+	details := make([]string, 0, 3)
+	if endpoint.IP != "" {
+		details = append(details, endpoint.IP)
+	}
+	if isHostNetwork(endpoint.Pod) {
+		details = append(details, "host network")
+	}
+	if len(endpoint.Labels) > 0 {
+		details = append(details, "labels "+labels.Set(endpoint.Labels).String())
+	}
+	if len(details) > 0 {
+		desc += " (" + strings.Join(details, ", ") + ")"
+	}
+	return desc
+}
+
+func isHostNetwork(pod *unstructured.Unstructured) bool {
+	if pod == nil {
+		return false
+	}
+	hostNetwork, _, _ := unstructured.NestedBool(pod.Object, "spec", "hostNetwork")
+	return hostNetwork
+}
+
+func isFinished(pod *unstructured.Unstructured) bool {
+	phase, _, _ := unstructured.NestedString(pod.Object, "status", "phase")
+	return phase == "Succeeded" || phase == "Failed"
+}
+
+func valueOr(value, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return fallback
+}
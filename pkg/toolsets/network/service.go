@@ -0,0 +1,742 @@
+package network
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"github.com/openshift/must-gather-mcp-server/pkg/mustgather"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// chainFinding is a broken or degraded link in the Route → Service → Endpoints → Pod chain
+type chainFinding struct {
+	Symbol string
+	Link   string
+	Text   string
+}
+
+// routeBackend is a Service a Route sends traffic to
+type routeBackend struct {
+	Name   string
+	Weight int64
+}
+
+// endpointAddress is an address from Endpoints or EndpointSlices
+type endpointAddress struct {
+	IP    string
+	Pod   string
+	Ready bool
+}
+
+// chainDiagnosis builds the report while walking the chain
+type chainDiagnosis struct {
+	params   api.ToolHandlerParams
+	refTime  time.Time
+	output   string
+	findings []chainFinding
+}
+
+func (d *chainDiagnosis) section(title string) {
+	d.output += title + "\n"
+	d.output += strings.Repeat("-", 80) + "\n"
+}
+
+func (d *chainDiagnosis) line(format string, args ...interface{}) {
+	d.output += "  " + fmt.Sprintf(format, args...) + "\n"
+}
+
+func (d *chainDiagnosis) fail(link, format string, args ...interface{}) {
+	text := fmt.Sprintf(format, args...)
+	d.findings = append(d.findings, chainFinding{Symbol: "✗", Link: link, Text: text})
+	d.line("✗ %s", text)
+}
+
+func (d *chainDiagnosis) warn(link, format string, args ...interface{}) {
+	text := fmt.Sprintf(format, args...)
+	d.findings = append(d.findings, chainFinding{Symbol: "⚠", Link: link, Text: text})
+	d.line("⚠ %s", text)
+}
+
+func serviceTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "service_diagnose",
+				Description: "Diagnose the Route → Service → Endpoints → Pod chain for a Route or Service: Route admission, host, TLS certificate and backends, Service selector resolved to Pods, targetPort checked against container ports, and ready/not-ready addresses in Endpoints and EndpointSlices, reporting every broken link",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"namespace": {
+							Type:        "string",
+							Description: "Namespace of the Route or Service",
+						},
+						"name": {
+							Type:        "string",
+							Description: "Name of the Route or Service",
+						},
+						"kind": {
+							Type:        "string",
+							Description: "Route or Service (default: the Route with this name if one exists, else the Service)",
+							Enum:        []interface{}{"Route", "Service"},
+						},
+					},
+					Required: []string{"namespace", "name"},
+				},
+			},
+			Handler: serviceDiagnose,
+		},
+	}
+}
+
+func serviceDiagnose(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	namespace := params.GetString("namespace", "")
+	name := params.GetString("name", "")
+	kind := params.GetString("kind", "")
+
+	if namespace == "" || name == "" {
+		return api.NewToolCallResult("", fmt.Errorf("namespace and name are required")), nil
+	}
+	if kind != "" && kind != "Route" && kind != "Service" {
+		return api.NewToolCallResult("", fmt.Errorf("invalid kind %q, expected Route or Service", kind)), nil
+	}
+
+	d := &chainDiagnosis{params: params, refTime: params.MustGatherProvider.GetMetadata().EndTime}
+	if d.refTime.IsZero() {
+		d.refTime = time.Now()
+	}
+
+	var route *unstructured.Unstructured
+	if kind != "Service" {
+		found, err := params.MustGatherProvider.GetResource(params.Context, parseGVK("route.openshift.io/v1", "Route"), namespace, name)
+		if err == nil {
+			route = found
+		} else if kind == "Route" {
+			return api.NewToolCallResult("", fmt.Errorf("route %s/%s not found: %w", namespace, name, err)), nil
+		}
+	}
+
+	chains := make([]string, 0)
+	if route != nil {
+		backends, targetPort := d.diagnoseRoute(route)
+		for _, backend := range backends {
+			summary := d.diagnoseService(namespace, backend.Name, true, targetPort)
+			if backend.Weight == 0 {
+				summary += " (weight 0, receives no traffic)"
+			}
+			chains = append(chains, fmt.Sprintf("Route %s → %s", name, summary))
+		}
+	} else {
+		if _, err := params.MustGatherProvider.GetResource(params.Context, parseGVK("v1", "Service"), namespace, name); err != nil {
+			return api.NewToolCallResult("", fmt.Errorf("no Route or Service %s/%s found: %w", namespace, name, err)), nil
+		}
+		chains = append(chains, d.diagnoseService(namespace, name, false, nil))
+	}
+
+	output := "Service Chain Diagnosis\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+	if route != nil {
+		output += fmt.Sprintf("Route: %s/%s\n", namespace, name)
+	} else {
+		output += fmt.Sprintf("Service: %s/%s\n", namespace, name)
+	}
+	for _, chain := range chains {
+		output += fmt.Sprintf("Chain: %s\n", chain)
+	}
+	output += "\n" + d.output
+
+	output += "Findings\n"
+	output += strings.Repeat("-", 80) + "\n"
+	if len(d.findings) == 0 {
+		output += "✓ No broken links found in the chain\n"
+	}
+	sort.SliceStable(d.findings, func(i, j int) bool {
+		return d.findings[i].Symbol == "✗" && d.findings[j].Symbol != "✗"
+	})
+	for _, finding := range d.findings {
+		output += fmt.Sprintf("%s [%s] %s\n", finding.Symbol, finding.Link, finding.Text)
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// diagnoseRoute checks admission, host, TLS and backends, returning the
+// backends and the route's target port
+func (d *chainDiagnosis) diagnoseRoute(route *unstructured.Unstructured) ([]routeBackend, interface{}) {
+	link := "Route " + route.GetName()
+	d.section(fmt.Sprintf("Route %s/%s", route.GetNamespace(), route.GetName()))
+
+	host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+	routePath, _, _ := unstructured.NestedString(route.Object, "spec", "path")
+	ingresses, _, _ := unstructured.NestedSlice(route.Object, "status", "ingress")
+	if host == "" && len(ingresses) > 0 {
+		ingress, _ := ingresses[0].(map[string]interface{})
+		host, _, _ = unstructured.NestedString(ingress, "host")
+	}
+	if host == "" {
+		d.fail(link, "no host set or generated")
+	} else {
+		d.line("Host: %s%s", host, routePath)
+	}
+
+	// Admission by each router shard
+	if len(ingresses) == 0 {
+		d.fail(link, "not admitted by any router, status.ingress is empty")
+	}
+	for _, ingress := range ingresses {
+		ingressMap, _ := ingress.(map[string]interface{})
+		routerName, _, _ := unstructured.NestedString(ingressMap, "routerName")
+		conditions, _, _ := unstructured.NestedSlice(ingressMap, "conditions")
+		admitted := false
+		for _, condition := range conditions {
+			conditionMap, _ := condition.(map[string]interface{})
+			if conditionMap["type"] != "Admitted" {
+				continue
+			}
+			admitted = true
+			if conditionMap["status"] == "True" {
+				d.line("✓ Admitted by router %s", routerName)
+			} else {
+				reason, _ := conditionMap["reason"].(string)
+				message, _ := conditionMap["message"].(string)
+				d.fail(link, "rejected by router %s: %s %s", routerName, reason, message)
+			}
+		}
+		if !admitted {
+			d.warn(link, "router %s reports no Admitted condition", routerName)
+		}
+	}
+
+	d.checkRouteTLS(route, host, link)
+
+	// Backends and their weights
+	backends := make([]routeBackend, 0)
+	references := []map[string]interface{}{}
+	if to, found, _ := unstructured.NestedMap(route.Object, "spec", "to"); found {
+		references = append(references, to)
+	}
+	alternates, _, _ := unstructured.NestedSlice(route.Object, "spec", "alternateBackends")
+	for _, alternate := range alternates {
+		if alternateMap, ok := alternate.(map[string]interface{}); ok {
+			references = append(references, alternateMap)
+		}
+	}
+	var totalWeight int64
+	for _, reference := range references {
+		kind, _, _ := unstructured.NestedString(reference, "kind")
+		name, _, _ := unstructured.NestedString(reference, "name")
+		weight, found, _ := unstructured.NestedInt64(reference, "weight")
+		if !found {
+			weight = 100
+		}
+		if kind != "" && kind != "Service" {
+			d.fail(link, "backend %s is a %s, only Services are supported", name, kind)
+			continue
+		}
+		totalWeight += weight
+		d.line("Backend: Service %s (weight %d)", name, weight)
+		backends = append(backends, routeBackend{Name: name, Weight: weight})
+	}
+	if len(backends) == 0 {
+		d.fail(link, "no Service backend in spec.to")
+	} else if totalWeight == 0 {
+		d.fail(link, "all backends have weight 0, the router sends no traffic")
+	}
+
+	targetPort, found, _ := unstructured.NestedFieldNoCopy(route.Object, "spec", "port", "targetPort")
+	if found {
+		d.line("Target Port: %v", targetPort)
+	} else {
+		d.line("Target Port: not set, the router uses the first endpoint port")
+	}
+	d.output += "\n"
+	return backends, targetPort
+}
+
+// checkRouteTLS checks the termination type and an inline certificate
+func (d *chainDiagnosis) checkRouteTLS(route *unstructured.Unstructured, host, link string) {
+	tlsConfig, found, _ := unstructured.NestedMap(route.Object, "spec", "tls")
+	if !found {
+		d.line("TLS: none, plain HTTP")
+		return
+	}
+	termination, _, _ := unstructured.NestedString(tlsConfig, "termination")
+	insecure, _, _ := unstructured.NestedString(tlsConfig, "insecureEdgeTerminationPolicy")
+	certificate, _, _ := unstructured.NestedString(tlsConfig, "certificate")
+	key, _, _ := unstructured.NestedString(tlsConfig, "key")
+	d.line("TLS: %s termination, insecure traffic: %s", valueOr(termination, "unset"), valueOr(insecure, "None"))
+
+	switch termination {
+	case "edge", "reencrypt":
+	case "passthrough":
+		if certificate != "" || key != "" {
+			d.warn(link, "certificate and key are ignored with passthrough termination, the pods serve TLS themselves")
+		}
+		if insecure == "Allow" {
+			d.fail(link, "insecureEdgeTerminationPolicy Allow is not valid with passthrough termination")
+		}
+		return
+	default:
+		d.fail(link, "invalid TLS termination %q, expected edge, passthrough or reencrypt", termination)
+		return
+	}
+	if termination == "reencrypt" {
+		if destinationCA, _, _ := unstructured.NestedString(tlsConfig, "destinationCACertificate"); destinationCA == "" {
+			d.line("Destination CA: not set, the pods must serve a certificate signed by the service CA")
+		}
+	}
+
+	if certificate == "" {
+		d.line("Certificate: router default certificate")
+		return
+	}
+	certs := make([]*x509.Certificate, 0)
+	rest := []byte(certificate)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if cert, err := x509.ParseCertificate(block.Bytes); err == nil {
+			certs = append(certs, cert)
+		}
+	}
+	if len(certs) == 0 {
+		d.fail(link, "spec.tls.certificate contains no parseable certificate")
+		return
+	}
+	leaf := certs[0]
+	d.line("Certificate: %s, expires %s", leaf.Subject.CommonName, leaf.NotAfter.UTC().Format(time.RFC3339))
+	switch {
+	case d.refTime.After(leaf.NotAfter):
+		d.fail(link, "certificate expired %s before the gather", d.refTime.Sub(leaf.NotAfter).Round(time.Hour))
+	case d.refTime.Before(leaf.NotBefore):
+		d.fail(link, "certificate is not valid until %s", leaf.NotBefore.UTC().Format(time.RFC3339))
+	case leaf.NotAfter.Sub(d.refTime) < 30*24*time.Hour:
+		d.warn(link, "certificate expires in %s", leaf.NotAfter.Sub(d.refTime).Round(time.Hour))
+	}
+	if host != "" {
+		if err := leaf.VerifyHostname(host); err != nil {
+			d.fail(link, "certificate does not cover host %s (%v)", host, err)
+		}
+	}
+	switch {
+	case mustgather.IsRedacted(key):
+		d.line("Key: redacted, not checked against the certificate (start the server with --redact=false to check it)")
+	case bytes.Contains([]byte(key), []byte("PRIVATE KEY")):
+		certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leaf.Raw})
+		if _, err := tls.X509KeyPair(certPEM, []byte(key)); err != nil {
+			d.fail(link, "spec.tls.key does not match the certificate: %v", err)
+		}
+	}
+}
+
+// diagnoseService resolves a Service to its Pods and Endpoints and returns a
+// one-line summary of the chain from the Service on
+func (d *chainDiagnosis) diagnoseService(namespace, name string, fromRoute bool, routePort interface{}) string {
+	link := "Service " + name
+	service, err := d.params.MustGatherProvider.GetResource(d.params.Context, parseGVK("v1", "Service"), namespace, name)
+	if err != nil {
+		d.section(fmt.Sprintf("Service %s/%s", namespace, name))
+		d.fail(link, "Service %s/%s not found", namespace, name)
+		d.output += "\n"
+		return fmt.Sprintf("✗ Service %s (missing)", name)
+	}
+	d.section(fmt.Sprintf("Service %s/%s", namespace, name))
+
+	serviceType, _, _ := unstructured.NestedString(service.Object, "spec", "type")
+	clusterIP, _, _ := unstructured.NestedString(service.Object, "spec", "clusterIP")
+	d.line("Type: %s, Cluster IP: %s", valueOr(serviceType, "ClusterIP"), valueOr(clusterIP, "none"))
+	if !fromRoute {
+		if routes := d.routesFor(namespace, name); len(routes) > 0 {
+			d.line("Exposed by Routes: %s", strings.Join(routes, ", "))
+		}
+	}
+	if serviceType == "ExternalName" {
+		externalName, _, _ := unstructured.NestedString(service.Object, "spec", "externalName")
+		d.line("External Name: %s, no Endpoints or Pods behind it", externalName)
+		d.output += "\n"
+		return fmt.Sprintf("Service %s → %s", name, externalName)
+	}
+
+	// Ports and the target ports they map to
+	type servicePort struct {
+		Name       string
+		Port       int64
+		TargetPort string
+		Protocol   string
+	}
+	ports := make([]servicePort, 0)
+	portNames := make([]string, 0)
+	specPorts, _, _ := unstructured.NestedSlice(service.Object, "spec", "ports")
+	for _, specPort := range specPorts {
+		portMap, _ := specPort.(map[string]interface{})
+		port := servicePort{}
+		port.Name, _, _ = unstructured.NestedString(portMap, "name")
+		port.Port, _, _ = unstructured.NestedInt64(portMap, "port")
+		port.Protocol, _, _ = unstructured.NestedString(portMap, "protocol")
+		port.Protocol = valueOr(port.Protocol, "TCP")
+		port.TargetPort = strconv.FormatInt(port.Port, 10)
+		if targetPort, found := portMap["targetPort"]; found {
+			port.TargetPort = fmt.Sprint(targetPort)
+		}
+		ports = append(ports, port)
+		portNames = append(portNames, port.Name)
+		d.line("Port: %s %d → %s/%s", valueOr(port.Name, "(unnamed)"), port.Port, port.TargetPort, port.Protocol)
+	}
+	if len(ports) == 0 {
+		d.fail(link, "Service has no ports")
+	}
+	if name, ok := routePort.(string); ok {
+		if _, err := strconv.Atoi(name); err != nil && !containsString(portNames, name) {
+			d.fail(link, "Route targetPort %q is not a port name of the Service (ports: %s)", name, strings.Join(portNames, ", "))
+		}
+	}
+
+	// Pods behind the selector
+	selector, _, _ := unstructured.NestedStringMap(service.Object, "spec", "selector")
+	pods := make([]*unstructured.Unstructured, 0)
+	if len(selector) == 0 {
+		d.line("Selector: none, Endpoints are managed outside of Kubernetes")
+	} else {
+		d.line("Selector: %s", labels.Set(selector))
+		list, err := d.params.MustGatherProvider.ListResources(d.params.Context, parseGVK("v1", "Pod"), namespace, api.ListOptions{})
+		if err == nil {
+			for i := range list.Items {
+				pod := &list.Items[i]
+				if !isFinished(pod) && labels.SelectorFromSet(selector).Matches(labels.Set(pod.GetLabels())) {
+					pods = append(pods, pod)
+				}
+			}
+		}
+		if len(pods) == 0 {
+			d.fail(link, "selector %s matches no running pods in %s", labels.Set(selector), namespace)
+		}
+	}
+
+	// Target ports against the container ports of the selected pods
+	for _, port := range ports {
+		if len(pods) == 0 {
+			break
+		}
+		missing := make([]string, 0)
+		number, err := strconv.ParseInt(port.TargetPort, 10, 64)
+		for _, pod := range pods {
+			if err == nil {
+				if !declaresPort(pod, number, port.Protocol) {
+					missing = append(missing, pod.GetName())
+				}
+			} else if containerPort(pod, port.TargetPort, port.Protocol) == 0 {
+				missing = append(missing, pod.GetName())
+			}
+		}
+		switch {
+		case len(missing) == 0:
+		case err == nil:
+			d.line("• targetPort %d is not declared by %d of %d pods, fine if the process listens on it", number, len(missing), len(pods))
+		case len(missing) == len(pods):
+			d.fail(link, "targetPort %q of port %s is not a %s container port of any selected pod", port.TargetPort, valueOr(port.Name, strconv.FormatInt(port.Port, 10)), port.Protocol)
+		default:
+			d.warn(link, "targetPort %q is missing on %d of %d pods: %s", port.TargetPort, len(missing), len(pods), strings.Join(missing, ", "))
+		}
+	}
+	d.output += "\n"
+
+	endpointSummary := d.diagnoseEndpoints(service, pods, portNames, routePort)
+
+	readyPods := 0
+	if len(pods) > 0 {
+		d.section(fmt.Sprintf("Pods (%d)", len(pods)))
+		sort.Slice(pods, func(i, j int) bool { return pods[i].GetName() < pods[j].GetName() })
+		for i, pod := range pods {
+			ready, reason := podReadiness(pod)
+			podIP, _, _ := unstructured.NestedString(pod.Object, "status", "podIP")
+			nodeName, _, _ := unstructured.NestedString(pod.Object, "spec", "nodeName")
+			if ready {
+				readyPods++
+			}
+			if i >= 20 {
+				continue
+			}
+			symbol := "✓"
+			if !ready {
+				symbol = "✗"
+			}
+			line := fmt.Sprintf("%s %s %s on %s", symbol, pod.GetName(), valueOr(podIP, "(no IP)"), valueOr(nodeName, "(unscheduled)"))
+			if reason != "" {
+				line += ": " + reason
+			}
+			d.line("%s", line)
+		}
+		if len(pods) > 20 {
+			d.line("... and %d more pods", len(pods)-20)
+		}
+		if readyPods == 0 {
+			d.fail("Pods", "none of the %d pods behind %s is ready", len(pods), name)
+		}
+		d.output += "\n"
+	}
+
+	summary := fmt.Sprintf("Service %s → %s", name, endpointSummary)
+	if len(selector) > 0 {
+		summary += fmt.Sprintf(" → %d/%d pods ready", readyPods, len(pods))
+	}
+	return summary
+}
+
+// diagnoseEndpoints compares Endpoints and EndpointSlices with the selected pods
+func (d *chainDiagnosis) diagnoseEndpoints(service *unstructured.Unstructured, pods []*unstructured.Unstructured, portNames []string, routePort interface{}) string {
+	namespace, name := service.GetNamespace(), service.GetName()
+	link := "Endpoints " + name
+	d.section(fmt.Sprintf("Endpoints %s/%s", namespace, name))
+
+	addresses := make(map[string]endpointAddress)
+	endpointPorts := make(map[string]bool)
+	portNumbers := make(map[int64]bool)
+	sources := make([]string, 0, 2)
+
+	if endpoints, err := d.params.MustGatherProvider.GetResource(d.params.Context, parseGVK("v1", "Endpoints"), namespace, name); err == nil {
+		sources = append(sources, "Endpoints")
+		subsets, _, _ := unstructured.NestedSlice(endpoints.Object, "subsets")
+		for _, subset := range subsets {
+			subsetMap, _ := subset.(map[string]interface{})
+			for field, ready := range map[string]bool{"addresses": true, "notReadyAddresses": false} {
+				list, _, _ := unstructured.NestedSlice(subsetMap, field)
+				for _, address := range list {
+					addressMap, _ := address.(map[string]interface{})
+					ip, _, _ := unstructured.NestedString(addressMap, "ip")
+					pod, _, _ := unstructured.NestedString(addressMap, "targetRef", "name")
+					addresses[ip] = endpointAddress{IP: ip, Pod: pod, Ready: ready}
+				}
+			}
+			collectEndpointPorts(subsetMap, endpointPorts, portNumbers)
+		}
+	}
+
+	slices, err := d.params.MustGatherProvider.ListResources(d.params.Context, parseGVK("discovery.k8s.io/v1", "EndpointSlice"), namespace,
+		api.ListOptions{LabelSelector: "kubernetes.io/service-name=" + name})
+	if err == nil && len(slices.Items) > 0 {
+		sources = append(sources, fmt.Sprintf("%d EndpointSlices", len(slices.Items)))
+		for i := range slices.Items {
+			slice := &slices.Items[i]
+			list, _, _ := unstructured.NestedSlice(slice.Object, "endpoints")
+			for _, endpoint := range list {
+				endpointMap, _ := endpoint.(map[string]interface{})
+				ready, found, _ := unstructured.NestedBool(endpointMap, "conditions", "ready")
+				ready = ready || !found
+				pod, _, _ := unstructured.NestedString(endpointMap, "targetRef", "name")
+				ips, _, _ := unstructured.NestedStringSlice(endpointMap, "addresses")
+				for _, ip := range ips {
+					addresses[ip] = endpointAddress{IP: ip, Pod: pod, Ready: ready}
+				}
+			}
+			collectEndpointPorts(slice.Object, endpointPorts, portNumbers)
+		}
+	}
+
+	if len(sources) == 0 {
+		d.line("No Endpoints or EndpointSlices for %s in the gather", name)
+		d.output += "\n"
+		return "endpoints not gathered"
+	}
+
+	ready, notReady := 0, 0
+	ips := make([]string, 0, len(addresses))
+	for ip, address := range addresses {
+		ips = append(ips, ip)
+		if address.Ready {
+			ready++
+		} else {
+			notReady++
+		}
+	}
+	sort.Strings(ips)
+	d.line("Source: %s", strings.Join(sources, ", "))
+	d.line("Addresses: %d ready, %d not ready", ready, notReady)
+	for _, ip := range ips {
+		address := addresses[ip]
+		symbol := "✓"
+		if !address.Ready {
+			symbol = "✗"
+		}
+		d.line("%s %s", symbol, strings.TrimSpace(ip+" "+address.Pod))
+	}
+
+	switch {
+	case ready == 0 && notReady > 0:
+		d.fail(link, "no ready addresses, %d not ready: connections fail and a Route returns 503", notReady)
+	case ready == 0:
+		d.fail(link, "no addresses: connections fail and a Route returns 503")
+	}
+
+	// Ports that didn't resolve on any pod are left out of the endpoints
+	if len(addresses) > 0 {
+		for _, portName := range portNames {
+			if portName != "" && !endpointPorts[portName] {
+				d.fail(link, "port %s has no endpoints, its targetPort does not resolve on the pods", portName)
+			}
+		}
+	}
+	if number, ok := routePort.(int64); ok && !portNumbers[number] {
+		d.fail(link, "Route targetPort %d is not an endpoint port (ports: %s)", number, formatPortNumbers(portNumbers))
+	} else if value, ok := routePort.(string); ok {
+		if number, err := strconv.ParseInt(value, 10, 64); err == nil && !portNumbers[number] {
+			d.fail(link, "Route targetPort %d is not an endpoint port (ports: %s)", number, formatPortNumbers(portNumbers))
+		}
+	}
+
+	// Pods and addresses that disagree
+	podIPs := make(map[string]bool)
+	for _, pod := range pods {
+		podIP, _, _ := unstructured.NestedString(pod.Object, "status", "podIP")
+		podIPs[podIP] = true
+		if podReady, _ := podReadiness(pod); podReady && podIP != "" {
+			if _, found := addresses[podIP]; !found {
+				d.warn(link, "ready pod %s (%s) is missing from the endpoints", pod.GetName(), podIP)
+			}
+		}
+	}
+	if len(pods) > 0 {
+		for _, ip := range ips {
+			if !podIPs[ip] {
+				d.warn(link, "address %s (%s) belongs to no selected pod, the endpoints are stale", ip, valueOr(addresses[ip].Pod, "no pod"))
+			}
+		}
+	}
+	d.output += "\n"
+
+	if notReady > 0 {
+		return fmt.Sprintf("%d ready, %d not ready endpoints", ready, notReady)
+	}
+	return fmt.Sprintf("%d ready endpoints", ready)
+}
+
+// routesFor lists the Routes in a namespace sending traffic to a Service
+func (d *chainDiagnosis) routesFor(namespace, service string) []string {
+	list, err := d.params.MustGatherProvider.ListResources(d.params.Context, parseGVK("route.openshift.io/v1", "Route"), namespace, api.ListOptions{})
+	if err != nil {
+		return nil
+	}
+	routes := make([]string, 0)
+	for i := range list.Items {
+		route := &list.Items[i]
+		names := make([]string, 0)
+		if name, _, _ := unstructured.NestedString(route.Object, "spec", "to", "name"); name != "" {
+			names = append(names, name)
+		}
+		alternates, _, _ := unstructured.NestedSlice(route.Object, "spec", "alternateBackends")
+		for _, alternate := range alternates {
+			alternateMap, _ := alternate.(map[string]interface{})
+			if name, _, _ := unstructured.NestedString(alternateMap, "name"); name != "" {
+				names = append(names, name)
+			}
+		}
+		if containsString(names, service) {
+			routes = append(routes, route.GetName())
+		}
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+func collectEndpointPorts(object map[string]interface{}, names map[string]bool, numbers map[int64]bool) {
+	ports, _, _ := unstructured.NestedSlice(object, "ports")
+	for _, port := range ports {
+		portMap, _ := port.(map[string]interface{})
+		if name, _, _ := unstructured.NestedString(portMap, "name"); name != "" {
+			names[name] = true
+		}
+		if number, found, _ := unstructured.NestedInt64(portMap, "port"); found {
+			numbers[number] = true
+		}
+	}
+}
+
+func formatPortNumbers(numbers map[int64]bool) string {
+	sorted := make([]string, 0, len(numbers))
+	for number := range numbers {
+		sorted = append(sorted, strconv.FormatInt(number, 10))
+	}
+	sort.Strings(sorted)
+	return valueOr(strings.Join(sorted, ", "), "none")
+}
+
+// declaresPort reports whether a container of the pod declares the port number
+func declaresPort(pod *unstructured.Unstructured, number int64, protocol string) bool {
+	containers, _, _ := unstructured.NestedSlice(pod.Object, "spec", "containers")
+	for _, container := range containers {
+		containerMap, _ := container.(map[string]interface{})
+		ports, _, _ := unstructured.NestedSlice(containerMap, "ports")
+		for _, port := range ports {
+			portMap, _ := port.(map[string]interface{})
+			containerPort, _, _ := unstructured.NestedInt64(portMap, "containerPort")
+			portProtocol, _, _ := unstructured.NestedString(portMap, "protocol")
+			if containerPort == number && valueOr(portProtocol, "TCP") == protocol {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// podReadiness reports whether a pod is ready, with the reason when it isn't
+func podReadiness(pod *unstructured.Unstructured) (bool, string) {
+	if pod.GetDeletionTimestamp() != nil {
+		return false, "terminating"
+	}
+	conditions, _, _ := unstructured.NestedSlice(pod.Object, "status", "conditions")
+	for _, condition := range conditions {
+		conditionMap, _ := condition.(map[string]interface{})
+		if conditionMap["type"] == "Ready" && conditionMap["status"] == "True" {
+			return true, ""
+		}
+	}
+
+	reasons := make([]string, 0)
+	statuses, _, _ := unstructured.NestedSlice(pod.Object, "status", "containerStatuses")
+	for _, status := range statuses {
+		statusMap, _ := status.(map[string]interface{})
+		if ready, _, _ := unstructured.NestedBool(statusMap, "ready"); ready {
+			continue
+		}
+		containerName, _, _ := unstructured.NestedString(statusMap, "name")
+		reason, _, _ := unstructured.NestedString(statusMap, "state", "waiting", "reason")
+		if reason == "" {
+			reason, _, _ = unstructured.NestedString(statusMap, "state", "terminated", "reason")
+		}
+		if reason == "" {
+			reason = "not ready"
+		}
+		if restarts, _, _ := unstructured.NestedInt64(statusMap, "restartCount"); restarts > 0 {
+			reason += fmt.Sprintf(", %d restarts", restarts)
+		}
+		reasons = append(reasons, fmt.Sprintf("%s %s", containerName, reason))
+	}
+	if len(reasons) > 0 {
+		return false, strings.Join(reasons, "; ")
+	}
+	phase, _, _ := unstructured.NestedString(pod.Object, "status", "phase")
+	return false, valueOr(phase, "Unknown") + ", not ready"
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
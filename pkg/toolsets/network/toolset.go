@@ -23,5 +23,7 @@ func (t *NetworkToolset) GetTools() []api.ServerTool {
 	tools := []api.ServerTool{}
 	tools = append(tools, networkInfoTools()...)
 	tools = append(tools, networkConnectivityTools()...)
+	tools = append(tools, networkPolicyTools()...)
+	tools = append(tools, serviceTools()...)
 	return tools
 }
@@ -0,0 +1,667 @@
+package odf
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"github.com/openshift/must-gather-mcp-server/pkg/toolsets/internal/toolutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// nearFullRatio and fullRatio are the Ceph default OSD fill thresholds
+const (
+	nearFullRatio = 85.0
+	fullRatio     = 95.0
+)
+
+// criticalPGStates make data unavailable or at risk
+var criticalPGStates = []string{"down", "incomplete", "stale", "inconsistent", "unknown", "unfound", "snaptrim_error"}
+
+// cephStatus is the subset of `ceph status --format json` used here
+type cephStatus struct {
+	FSID   string `json:"fsid"`
+	Health struct {
+		Status string               `json:"status"`
+		Checks map[string]cephCheck `json:"checks"`
+	} `json:"health"`
+	QuorumNames []string `json:"quorum_names"`
+	MonMap      struct {
+		NumMons int `json:"num_mons"`
+		Mons    []struct {
+			Name string `json:"name"`
+		} `json:"mons"`
+	} `json:"monmap"`
+	OSDMap json.RawMessage `json:"osdmap"`
+	PGMap  struct {
+		PGsByState []struct {
+			StateName string `json:"state_name"`
+			Count     int    `json:"count"`
+		} `json:"pgs_by_state"`
+		NumPGs           int   `json:"num_pgs"`
+		BytesUsed        int64 `json:"bytes_used"`
+		BytesAvail       int64 `json:"bytes_avail"`
+		BytesTotal       int64 `json:"bytes_total"`
+		DegradedObjects  int64 `json:"degraded_objects"`
+		DegradedTotal    int64 `json:"degraded_total"`
+		MisplacedObjects int64 `json:"misplaced_objects"`
+		MisplacedTotal   int64 `json:"misplaced_total"`
+		UnfoundObjects   int64 `json:"unfound_objects"`
+	} `json:"pgmap"`
+}
+
+// cephOSDMap counts OSDs; older releases nest it under another "osdmap" key
+type cephOSDMap struct {
+	NumOSDs   int `json:"num_osds"`
+	NumUpOSDs int `json:"num_up_osds"`
+	NumInOSDs int `json:"num_in_osds"`
+}
+
+// cephCheck is a health check from `ceph status` or `ceph health detail`
+type cephCheck struct {
+	Severity string `json:"severity"`
+	Summary  struct {
+		Message string `json:"message"`
+		Count   int    `json:"count"`
+	} `json:"summary"`
+	Detail []struct {
+		Message string `json:"message"`
+	} `json:"detail"`
+	Muted bool `json:"muted"`
+}
+
+// cephHealthDetail is `ceph health detail --format json`
+type cephHealthDetail struct {
+	Status string               `json:"status"`
+	Checks map[string]cephCheck `json:"checks"`
+}
+
+// cephOSDNode is a CRUSH bucket or OSD from `ceph osd tree` or `ceph osd df`
+type cephOSDNode struct {
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	Children    []int   `json:"children"`
+	Status      string  `json:"status"`
+	Reweight    float64 `json:"reweight"`
+	CrushWeight float64 `json:"crush_weight"`
+	DeviceClass string  `json:"device_class"`
+	Utilization float64 `json:"utilization"`
+	PGs         int     `json:"pgs"`
+}
+
+type cephOSDTree struct {
+	Nodes []cephOSDNode `json:"nodes"`
+	Stray []cephOSDNode `json:"stray"`
+}
+
+// cephPGStat is a placement group from `ceph pg dump`
+type cephPGStat struct {
+	PGID      string `json:"pgid"`
+	State     string `json:"state"`
+	Up        []int  `json:"up"`
+	Acting    []int  `json:"acting"`
+	LastClean string `json:"last_clean"`
+}
+
+// cephPGDump handles both layouts of `ceph pg dump`: pg_stats at the top
+// level (older releases) or under pg_map
+type cephPGDump struct {
+	PGStats []cephPGStat `json:"pg_stats"`
+	PGMap   struct {
+		PGStats []cephPGStat `json:"pg_stats"`
+	} `json:"pg_map"`
+}
+
+func cephTools() []api.ServerTool {
+	return []api.ServerTool{
+		{
+			Tool: api.Tool{
+				Name:        "odf_ceph_health",
+				Description: "Show OpenShift Data Foundation health: StorageCluster and CephCluster status, Ceph health checks with details, monitor quorum, OSD counts, placement group summary and capacity",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"maxDetails": {
+							Type:        "integer",
+							Description: "Maximum detail lines shown per health check (0 for all, default: 10)",
+						},
+					},
+				},
+			},
+			Handler: odfCephHealth,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "odf_osd_tree",
+				Description: "Show the Ceph OSD tree (CRUSH hierarchy) with OSD status, weight and utilization, flagging down, out, near-full and full OSDs",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"problemsOnly": {
+							Type:        "boolean",
+							Description: "Only list OSDs that are down, out or near full (default: false)",
+						},
+					},
+				},
+			},
+			Handler: odfOSDTree,
+		},
+		{
+			Tool: api.Tool{
+				Name:        "odf_pg_states",
+				Description: "Summarize Ceph placement group states and list placement groups that are not active+clean (degraded, undersized, peering, stale, incomplete, ...)",
+				InputSchema: &jsonschema.Schema{
+					Type: "object",
+					Properties: map[string]*jsonschema.Schema{
+						"limit": {
+							Type:        "integer",
+							Description: "Maximum unhealthy placement groups to list (0 for all, default: 50)",
+						},
+					},
+				},
+			},
+			Handler: odfPGStates,
+		},
+	}
+}
+
+func odfCephHealth(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	maxDetails := params.GetInt("maxDetails", 10)
+	provider := params.MustGatherProvider
+	cephDirs := findCephDirs(provider)
+	files := provider.RootFiles()
+
+	output := "ODF / Ceph Health\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	found := false
+
+	// Operator resources
+	storageClusterList, err := provider.ListResourcesByGroupKind(params.Context, "ocs.openshift.io", "StorageCluster", "", api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list StorageClusters: %w", err)), nil
+	}
+	storageClusters := storageClusterList.Items
+	if len(storageClusters) > 0 {
+		found = true
+		output += "Storage Clusters:\n"
+		output += strings.Repeat("-", 80) + "\n"
+		for i := range storageClusters {
+			output += formatOperatorResource(&storageClusters[i], "")
+		}
+		output += "\n"
+	}
+
+	cephClusterList, err := provider.ListResourcesByGroupKind(params.Context, "ceph.rook.io", "CephCluster", "", api.ListOptions{})
+	if err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to list CephClusters: %w", err)), nil
+	}
+	cephClusters := cephClusterList.Items
+	if len(cephClusters) > 0 {
+		found = true
+		output += "Ceph Clusters:\n"
+		output += strings.Repeat("-", 80) + "\n"
+		for i := range cephClusters {
+			cluster := &cephClusters[i]
+			health, _, _ := unstructured.NestedString(cluster.Object, "status", "ceph", "health")
+			output += formatOperatorResource(cluster, health)
+
+			used, _, _ := unstructured.NestedInt64(cluster.Object, "status", "ceph", "capacity", "bytesUsed")
+			total, _, _ := unstructured.NestedInt64(cluster.Object, "status", "ceph", "capacity", "bytesTotal")
+			if total > 0 {
+				output += fmt.Sprintf("      Capacity: %s used of %s (%.1f%%)\n", formatBytes(used), formatBytes(total), float64(used)*100/float64(total))
+			}
+
+			details, _, _ := unstructured.NestedMap(cluster.Object, "status", "ceph", "details")
+			names := make([]string, 0, len(details))
+			for name := range details {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				detail, _ := details[name].(map[string]interface{})
+				severity, _ := detail["severity"].(string)
+				message, _ := detail["message"].(string)
+				output += fmt.Sprintf("      %s %s: %s\n", healthSymbol(severity), name, message)
+			}
+		}
+		output += "\n"
+	}
+
+	// Ceph command output
+	var status cephStatus
+	data, err := readCephJSON(files, cephDirs, "ceph status")
+	if err == nil && json.Unmarshal(data, &status) == nil {
+		found = true
+		output += formatCephStatus(&status)
+
+		checks := status.Health.Checks
+		var detail cephHealthDetail
+		if data, err := readCephJSON(files, cephDirs, "ceph health detail"); err == nil && json.Unmarshal(data, &detail) == nil && len(detail.Checks) > 0 {
+			checks = detail.Checks
+		}
+		output += formatCephChecks(checks, maxDetails)
+	} else if text, err := readCephText(files, cephDirs, "ceph status"); err == nil {
+		found = true
+		output += "Ceph Status (text output):\n"
+		output += strings.Repeat("-", 80) + "\n"
+		output += strings.TrimRight(text, "\n") + "\n"
+		if text, err := readCephText(files, cephDirs, "ceph health detail"); err == nil {
+			output += "\nCeph Health Detail (text output):\n"
+			output += strings.Repeat("-", 80) + "\n"
+			output += strings.TrimRight(text, "\n") + "\n"
+		}
+	} else if len(cephDirs) == 0 {
+		output += "Ceph command output not collected (no ceph/ directory from the ODF must-gather)\n"
+	}
+
+	if !found {
+		return api.NewToolCallResult("", fmt.Errorf("no ODF data found (StorageCluster, CephCluster or ceph command output)")), nil
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+func formatOperatorResource(obj *unstructured.Unstructured, health string) string {
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	problems := toolutil.ProblemConditions(obj)
+
+	symbol := "✓"
+	if len(problems) > 0 || (phase != "" && phase != "Ready" && phase != "Connected") {
+		symbol = "✗"
+	}
+	if health != "" && health != "HEALTH_OK" && symbol == "✓" {
+		symbol = healthSymbol(health)
+	}
+
+	output := fmt.Sprintf("  %s %s/%s", symbol, obj.GetNamespace(), obj.GetName())
+	if phase != "" {
+		output += fmt.Sprintf("  phase=%s", phase)
+	}
+	if health != "" {
+		output += fmt.Sprintf("  health=%s", health)
+	}
+	output += "\n"
+	for _, problem := range problems {
+		output += fmt.Sprintf("      ✗ %s\n", problem)
+	}
+	return output
+}
+
+func formatCephStatus(status *cephStatus) string {
+	output := "Ceph Status:\n"
+	output += strings.Repeat("-", 80) + "\n"
+	output += fmt.Sprintf("  Health: %s %s\n", healthSymbol(status.Health.Status), status.Health.Status)
+
+	numMons := status.MonMap.NumMons
+	if numMons == 0 {
+		numMons = len(status.MonMap.Mons)
+	}
+	if numMons > 0 {
+		symbol := "✓"
+		if len(status.QuorumNames) < numMons {
+			symbol = "⚠"
+		}
+		output += fmt.Sprintf("  Monitors: %s %d, quorum %d (%s)\n", symbol, numMons, len(status.QuorumNames), strings.Join(status.QuorumNames, ", "))
+	}
+
+	if osdMap, ok := parseOSDMap(status.OSDMap); ok {
+		symbol := "✓"
+		if osdMap.NumUpOSDs < osdMap.NumOSDs {
+			symbol = "✗"
+		} else if osdMap.NumInOSDs < osdMap.NumOSDs {
+			symbol = "⚠"
+		}
+		output += fmt.Sprintf("  OSDs: %s %d total, %d up, %d in\n", symbol, osdMap.NumOSDs, osdMap.NumUpOSDs, osdMap.NumInOSDs)
+	}
+
+	if status.PGMap.NumPGs > 0 {
+		clean := 0
+		for _, state := range status.PGMap.PGsByState {
+			if state.StateName == "active+clean" {
+				clean += state.Count
+			}
+		}
+		symbol := "✓"
+		if clean < status.PGMap.NumPGs {
+			symbol = "⚠"
+		}
+		output += fmt.Sprintf("  PGs: %s %d total, %d active+clean\n", symbol, status.PGMap.NumPGs, clean)
+	}
+	if status.PGMap.DegradedObjects > 0 && status.PGMap.DegradedTotal > 0 {
+		output += fmt.Sprintf("  Degraded Objects: ⚠ %d/%d (%.2f%%)\n", status.PGMap.DegradedObjects, status.PGMap.DegradedTotal,
+			float64(status.PGMap.DegradedObjects)*100/float64(status.PGMap.DegradedTotal))
+	}
+	if status.PGMap.MisplacedObjects > 0 && status.PGMap.MisplacedTotal > 0 {
+		output += fmt.Sprintf("  Misplaced Objects: %d/%d (%.2f%%)\n", status.PGMap.MisplacedObjects, status.PGMap.MisplacedTotal,
+			float64(status.PGMap.MisplacedObjects)*100/float64(status.PGMap.MisplacedTotal))
+	}
+	if status.PGMap.UnfoundObjects > 0 {
+		output += fmt.Sprintf("  Unfound Objects: ✗ %d\n", status.PGMap.UnfoundObjects)
+	}
+	if status.PGMap.BytesTotal > 0 {
+		output += fmt.Sprintf("  Usage: %s used of %s (%.1f%%), %s available\n",
+			formatBytes(status.PGMap.BytesUsed), formatBytes(status.PGMap.BytesTotal),
+			float64(status.PGMap.BytesUsed)*100/float64(status.PGMap.BytesTotal), formatBytes(status.PGMap.BytesAvail))
+	}
+	output += "\n"
+	return output
+}
+
+func formatCephChecks(checks map[string]cephCheck, maxDetails int) string {
+	if len(checks) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	// Errors first, then by name
+	sort.Slice(names, func(i, j int) bool {
+		si, sj := checks[names[i]].Severity, checks[names[j]].Severity
+		if si != sj {
+			return si == "HEALTH_ERR"
+		}
+		return names[i] < names[j]
+	})
+
+	output := "Health Checks:\n"
+	output += strings.Repeat("-", 80) + "\n"
+	for _, name := range names {
+		check := checks[name]
+		muted := ""
+		if check.Muted {
+			muted = " (muted)"
+		}
+		output += fmt.Sprintf("  %s %s [%s]%s: %s\n", healthSymbol(check.Severity), name, check.Severity, muted, check.Summary.Message)
+		for i, detail := range check.Detail {
+			if maxDetails > 0 && i >= maxDetails {
+				output += fmt.Sprintf("      ... and %d more\n", len(check.Detail)-maxDetails)
+				break
+			}
+			output += fmt.Sprintf("      %s\n", detail.Message)
+		}
+	}
+	output += "\n"
+	return output
+}
+
+func parseOSDMap(raw json.RawMessage) (cephOSDMap, bool) {
+	var osdMap cephOSDMap
+	if len(raw) == 0 || json.Unmarshal(raw, &osdMap) != nil {
+		return osdMap, false
+	}
+	if osdMap.NumOSDs == 0 {
+		var nested struct {
+			OSDMap cephOSDMap `json:"osdmap"`
+		}
+		if json.Unmarshal(raw, &nested) == nil && nested.OSDMap.NumOSDs > 0 {
+			osdMap = nested.OSDMap
+		}
+	}
+	return osdMap, osdMap.NumOSDs > 0
+}
+
+func odfOSDTree(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	problemsOnly := params.GetBool("problemsOnly", false)
+	cephDirs := findCephDirs(params.MustGatherProvider)
+	files := params.MustGatherProvider.RootFiles()
+
+	data, err := readCephJSON(files, cephDirs, "ceph osd tree")
+	if err != nil {
+		if text, textErr := readCephText(files, cephDirs, "ceph osd tree"); textErr == nil {
+			return api.NewToolCallResult("Ceph OSD Tree (text output)\n"+strings.Repeat("=", 80)+"\n\n"+text, nil), nil
+		}
+		return api.NewToolCallResult("", fmt.Errorf("ceph osd tree output not found in the ODF must-gather: %w", err)), nil
+	}
+	var tree cephOSDTree
+	if err := json.Unmarshal(data, &tree); err != nil {
+		return api.NewToolCallResult("", fmt.Errorf("failed to parse ceph osd tree output: %w", err)), nil
+	}
+
+	// Utilization comes from ceph osd df, when collected
+	usage := make(map[int]cephOSDNode)
+	if data, err := readCephJSON(files, cephDirs, "ceph osd df"); err == nil {
+		var df cephOSDTree
+		if json.Unmarshal(data, &df) == nil {
+			for _, node := range df.Nodes {
+				usage[node.ID] = node
+			}
+		}
+	}
+
+	nodes := make(map[int]cephOSDNode, len(tree.Nodes))
+	isChild := make(map[int]bool)
+	for _, node := range tree.Nodes {
+		if df, ok := usage[node.ID]; ok && node.Type == "osd" {
+			node.Utilization = df.Utilization
+			node.PGs = df.PGs
+		}
+		nodes[node.ID] = node
+		for _, child := range node.Children {
+			isChild[child] = true
+		}
+	}
+
+	output := "Ceph OSD Tree\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	total, down, out, nearFull := 0, 0, 0, 0
+	problems := make([]string, 0)
+	var render func(id int, depth int, parent string)
+	render = func(id int, depth int, parent string) {
+		node, ok := nodes[id]
+		if !ok {
+			return
+		}
+		indent := strings.Repeat("  ", depth+1)
+		if node.Type != "osd" {
+			if !problemsOnly {
+				output += fmt.Sprintf("%s%s %s\n", indent, node.Type, node.Name)
+			}
+			children := append([]int(nil), node.Children...)
+			sort.Slice(children, func(i, j int) bool { return nodes[children[i]].Name < nodes[children[j]].Name })
+			for _, child := range children {
+				render(child, depth+1, node.Name)
+			}
+			return
+		}
+
+		total++
+		symbol, issues := osdIssues(node)
+		for _, issue := range issues {
+			switch issue {
+			case "down":
+				down++
+			case "out":
+				out++
+			case "near full", "full":
+				nearFull++
+			}
+		}
+		if len(issues) > 0 {
+			problems = append(problems, fmt.Sprintf("%s: %s", node.Name, strings.Join(issues, ", ")))
+		}
+		if problemsOnly && len(issues) == 0 {
+			return
+		}
+		if problemsOnly {
+			indent = fmt.Sprintf("  [%s] ", parent)
+		}
+		output += fmt.Sprintf("%s%s %s  %s  weight %.3f  %s  %s", indent, symbol, node.Name, node.DeviceClass, node.CrushWeight, node.Status, inOrOut(node))
+		if _, ok := usage[node.ID]; ok {
+			output += fmt.Sprintf("  util %.1f%%  pgs %d", node.Utilization, node.PGs)
+		}
+		output += "\n"
+	}
+
+	roots := make([]int, 0)
+	for _, node := range tree.Nodes {
+		if !isChild[node.ID] {
+			roots = append(roots, node.ID)
+		}
+	}
+	sort.Ints(roots)
+	for _, root := range roots {
+		render(root, 0, "")
+	}
+	for _, node := range tree.Stray {
+		total++
+		output += fmt.Sprintf("  ⚠ %s (stray, not in the CRUSH map)  %s\n", node.Name, node.Status)
+		problems = append(problems, fmt.Sprintf("%s: stray", node.Name))
+	}
+
+	output += "\n" + strings.Repeat("-", 80) + "\n"
+	output += fmt.Sprintf("OSDs: %d total, %d down, %d out, %d near full or full\n", total, down, out, nearFull)
+	if len(problems) == 0 {
+		output += "✓ All OSDs up and in\n"
+	} else {
+		output += "\nProblems:\n"
+		for _, problem := range problems {
+			output += fmt.Sprintf("  - %s\n", problem)
+		}
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// osdIssues returns the status symbol and the problems of an OSD
+func osdIssues(node cephOSDNode) (string, []string) {
+	symbol := "✓"
+	issues := make([]string, 0)
+	if node.Status != "up" {
+		symbol = "✗"
+		issues = append(issues, "down")
+	}
+	if node.Reweight == 0 {
+		if symbol == "✓" {
+			symbol = "⚠"
+		}
+		issues = append(issues, "out")
+	}
+	switch {
+	case node.Utilization >= fullRatio:
+		symbol = "✗"
+		issues = append(issues, "full")
+	case node.Utilization >= nearFullRatio:
+		if symbol == "✓" {
+			symbol = "⚠"
+		}
+		issues = append(issues, "near full")
+	}
+	return symbol, issues
+}
+
+func inOrOut(node cephOSDNode) string {
+	if node.Reweight == 0 {
+		return "out"
+	}
+	return fmt.Sprintf("in (reweight %.2f)", node.Reweight)
+}
+
+func odfPGStates(params api.ToolHandlerParams) (*api.ToolCallResult, error) {
+	limit := params.GetInt("limit", 50)
+	cephDirs := findCephDirs(params.MustGatherProvider)
+	files := params.MustGatherProvider.RootFiles()
+
+	output := "Ceph Placement Group States\n"
+	output += strings.Repeat("=", 80) + "\n\n"
+
+	found := false
+	var status cephStatus
+	if data, err := readCephJSON(files, cephDirs, "ceph status"); err == nil && json.Unmarshal(data, &status) == nil && status.PGMap.NumPGs > 0 {
+		found = true
+		states := status.PGMap.PGsByState
+		sort.SliceStable(states, func(i, j int) bool { return states[i].Count > states[j].Count })
+
+		output += fmt.Sprintf("Total PGs: %d\n\n", status.PGMap.NumPGs)
+		output += fmt.Sprintf("    %-60s %8s\n", "STATE", "COUNT")
+		output += strings.Repeat("-", 80) + "\n"
+		for _, state := range states {
+			output += fmt.Sprintf("%s %-60s %8d\n", pgStateSymbol(state.StateName), state.StateName, state.Count)
+		}
+		output += "\n"
+	}
+
+	var dump cephPGDump
+	if data, err := readCephJSON(files, cephDirs, "ceph pg dump"); err == nil && json.Unmarshal(data, &dump) == nil {
+		stats := dump.PGStats
+		if len(stats) == 0 {
+			stats = dump.PGMap.PGStats
+		}
+		if len(stats) > 0 {
+			found = true
+			unhealthy := make([]cephPGStat, 0)
+			for _, pg := range stats {
+				if pg.State != "active+clean" {
+					unhealthy = append(unhealthy, pg)
+				}
+			}
+			// Critical states first
+			sort.SliceStable(unhealthy, func(i, j int) bool {
+				ci, cj := isCriticalPGState(unhealthy[i].State), isCriticalPGState(unhealthy[j].State)
+				if ci != cj {
+					return ci
+				}
+				return unhealthy[i].PGID < unhealthy[j].PGID
+			})
+
+			output += fmt.Sprintf("Placement Groups Not active+clean: %d of %d\n", len(unhealthy), len(stats))
+			output += strings.Repeat("-", 80) + "\n"
+			for i, pg := range unhealthy {
+				if limit > 0 && i >= limit {
+					output += fmt.Sprintf("  ... and %d more\n", len(unhealthy)-limit)
+					break
+				}
+				output += fmt.Sprintf("%s %-10s %-45s up %v acting %v\n", pgStateSymbol(pg.State), pg.PGID, pg.State, pg.Up, pg.Acting)
+				if pg.LastClean != "" {
+					output += fmt.Sprintf("      last clean: %s\n", pg.LastClean)
+				}
+			}
+			output += "\n"
+		}
+	}
+
+	if !found {
+		if text, err := readCephText(files, cephDirs, "ceph pg stat"); err == nil {
+			return api.NewToolCallResult(output+strings.TrimRight(text, "\n")+"\n", nil), nil
+		}
+		return api.NewToolCallResult("", fmt.Errorf("no placement group data found (ceph status or ceph pg dump output from the ODF must-gather)")), nil
+	}
+
+	return api.NewToolCallResult(output, nil), nil
+}
+
+// pgStateSymbol returns a symbol for a placement group state such as "active+undersized+degraded"
+func pgStateSymbol(state string) string {
+	switch {
+	case state == "active+clean":
+		return "  ✓"
+	case isCriticalPGState(state):
+		return "  ✗"
+	default:
+		return "  ⚠"
+	}
+}
+
+func isCriticalPGState(state string) bool {
+	parts := strings.Split(state, "+")
+	active := false
+	for _, part := range parts {
+		if part == "active" {
+			active = true
+		}
+		for _, critical := range criticalPGStates {
+			if strings.Contains(part, critical) {
+				return true
+			}
+		}
+	}
+	// Inactive placement groups block I/O
+	return !active
+}
@@ -0,0 +1,112 @@
+package odf
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+)
+
+// The ODF gather writes the output of its ceph commands as text and as JSON
+// (the same commands run with --format json-pretty)
+const (
+	cephTextDir = "must_gather_commands"
+	cephJSONDir = "must_gather_commands_json_output"
+)
+
+// findCephDirs returns the ceph/ directories written by the ODF gather,
+// relative to the must-gather root
+func findCephDirs(provider api.MustGatherProvider) []string {
+	files := provider.RootFiles()
+	candidates := make([]string, 0)
+	for _, dir := range provider.GetMetadata().GatherDirs {
+		candidates = append(candidates, dir.Name)
+	}
+	if entries, err := files.ReadDir("."); err == nil {
+		for _, entry := range entries {
+			if entry.IsDir() {
+				candidates = append(candidates, entry.Name())
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	dirs := make([]string, 0)
+	for _, candidate := range candidates {
+		cephDir := path.Join(candidate, "ceph")
+		if seen[cephDir] {
+			continue
+		}
+		seen[cephDir] = true
+		if dirExists(files, path.Join(cephDir, cephTextDir)) || dirExists(files, path.Join(cephDir, cephJSONDir)) {
+			dirs = append(dirs, cephDir)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// readCephJSON returns the JSON output of a ceph command, e.g. "ceph osd tree"
+func readCephJSON(files api.GatherFS, cephDirs []string, command string) ([]byte, error) {
+	name := strings.ReplaceAll(command, " ", "_")
+	for _, dir := range cephDirs {
+		for _, suffix := range []string{"_--format_json-pretty", "_--format_json"} {
+			data, err := files.ReadFile(path.Join(dir, cephJSONDir, name+suffix))
+			if err != nil {
+				continue
+			}
+			// Ceph may print warnings before the document
+			if start := bytes.IndexAny(data, "{["); start > 0 {
+				data = data[start:]
+			}
+			return data, nil
+		}
+	}
+	return nil, fmt.Errorf("no JSON output for %q", command)
+}
+
+// readCephText returns the plain text output of a ceph command
+func readCephText(files api.GatherFS, cephDirs []string, command string) (string, error) {
+	name := strings.ReplaceAll(command, " ", "_")
+	for _, dir := range cephDirs {
+		data, err := files.ReadFile(path.Join(dir, cephTextDir, name))
+		if err == nil {
+			return string(data), nil
+		}
+	}
+	return "", fmt.Errorf("no output for %q", command)
+}
+
+// healthSymbol returns a symbol for a Ceph health status
+func healthSymbol(health string) string {
+	switch health {
+	case "HEALTH_OK":
+		return "✓"
+	case "HEALTH_WARN":
+		return "⚠"
+	default:
+		return "✗"
+	}
+}
+
+// formatBytes formats bytes as human-readable string
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+func dirExists(files api.GatherFS, name string) bool {
+	info, err := files.Stat(name)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,35 @@
+package odf
+
+import (
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+	"github.com/openshift/must-gather-mcp-server/pkg/toolsets"
+)
+
+// Toolset represents the OpenShift Data Foundation toolset, enabled when the
+// must-gather includes the ODF image output
+type Toolset struct{}
+
+// Name returns the toolset name
+func (t *Toolset) Name() string {
+	return "odf"
+}
+
+// GetTools returns all tools in this toolset
+func (t *Toolset) GetTools() []api.ServerTool {
+	tools := make([]api.ServerTool, 0)
+	tools = append(tools, cephTools()...)
+	return tools
+}
+
+// Detect reports whether Ceph command output or ODF resources were gathered
+func (t *Toolset) Detect(provider api.MustGatherProvider) bool {
+	if len(findCephDirs(provider)) > 0 {
+		return true
+	}
+	return provider.HasGroupKind("ocs.openshift.io", "StorageCluster") ||
+		provider.HasGroupKind("ceph.rook.io", "CephCluster")
+}
+
+func init() {
+	toolsets.Register(&Toolset{})
+}
@@ -1,6 +1,10 @@
 package toolsets
 
-import "github.com/openshift/must-gather-mcp-server/pkg/api"
+import (
+	"log/slog"
+
+	"github.com/openshift/must-gather-mcp-server/pkg/api"
+)
 
 // Registry holds all registered toolsets
 var registry []api.Toolset
@@ -14,3 +18,17 @@ func Register(toolset api.Toolset) {
 func All() []api.Toolset {
 	return registry
 }
+
+// Enabled returns the registered toolsets that apply to the must-gather,
+// skipping detectable toolsets whose data was not collected
+func Enabled(provider api.MustGatherProvider) []api.Toolset {
+	enabled := make([]api.Toolset, 0, len(registry))
+	for _, toolset := range registry {
+		if detectable, ok := toolset.(api.DetectableToolset); ok && !detectable.Detect(provider) {
+			slog.Info("toolset data not found, skipping", "toolset", toolset.Name())
+			continue
+		}
+		enabled = append(enabled, toolset)
+	}
+	return enabled
+}